@@ -75,6 +75,7 @@ func main() {
 		commands.CleanUpCommand,
 		commands.OptimizeCommand,
 		commands.MomentsCommand,
+		commands.DuplicatesCommand,
 		commands.ConvertCommand,
 		commands.ThumbsCommand,
 		commands.MigrationsCommand,
@@ -83,6 +84,7 @@ func main() {
 		commands.ResetCommand,
 		commands.PasswdCommand,
 		commands.UsersCommand,
+		commands.AuditCommand,
 		commands.ConfigCommand,
 		commands.VersionCommand,
 		commands.LabelsCommand,
@@ -39,6 +39,7 @@ export class Account extends RestModel {
       AccKey: "",
       AccUser: "",
       AccPass: "",
+      AccHostKey: "",
       AccTimeout: "",
       AccError: "",
       AccErrors: 0,
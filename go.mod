@@ -55,7 +55,7 @@ require (
 	golang.org/x/sys v0.19.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	gonum.org/v1/gonum v0.15.0
-	google.golang.org/protobuf v1.33.0 // indirect
+	google.golang.org/protobuf v1.33.0
 	gopkg.in/photoprism/go-tz.v2 v2.1.2
 	gopkg.in/yaml.v2 v2.4.0
 )
@@ -64,7 +64,9 @@ require (
 	github.com/carck/jpegli-go v1.0.3
 	github.com/carck/libheif-go v1.0.2
 	github.com/carck/vips-thumbnail-go v1.0.1
+	github.com/esimov/pigo v1.4.6
 	github.com/valyala/gozstd v1.20.1
+	google.golang.org/grpc v1.63.2
 )
 
 require (
@@ -97,6 +99,7 @@ require (
 	github.com/twitchyliquid64/golang-asm v0.15.1 // indirect
 	github.com/ugorji/go/codec v1.2.12 // indirect
 	golang.org/x/arch v0.7.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240227224415-6ceb2ff114de // indirect
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
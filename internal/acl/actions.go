@@ -19,4 +19,6 @@ const (
 	ActionComment    Action = "comment"
 	ActionExport     Action = "export"
 	ActionImport     Action = "import"
+	ActionAccessAll  Action = "access-all" // Access items of other users that are not private.
+	ActionManage     Action = "manage"     // Modify items owned by other users.
 )
@@ -15,12 +15,24 @@ var Permissions = ACL{
 		RoleAdmin: Actions{ActionDefault: true},
 	},
 	ResourceAlbums: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionShare: true, ActionLike: true, ActionAccessAll: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionLike: true, ActionAccessAll: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionShare: true, ActionLike: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true},
 	},
 	ResourcePhotos: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true, ActionPrivate: true, ActionUpload: true, ActionImport: true, ActionDownload: true, ActionShare: true, ActionLike: true, ActionComment: true, ActionAccessAll: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionDownload: true, ActionLike: true, ActionAccessAll: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true, ActionDelete: true, ActionPrivate: true, ActionUpload: true, ActionImport: true, ActionDownload: true, ActionLike: true, ActionComment: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+	},
+	ResourceLabels: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true, ActionAccessAll: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionAccessAll: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
 	},
 	ResourceUsers: Roles{
 		RoleDefault: Actions{ActionUpdateSelf: true},
@@ -28,6 +28,37 @@ func TestACL_Allow(t *testing.T) {
 	t.Run("albums/guest/default", func(t *testing.T) {
 		assert.False(t, Permissions.Allow(ResourceAlbums, RoleGuest, ActionDefault))
 	})
+	t.Run("albums/friend/share", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceAlbums, RoleFriend, ActionShare))
+		assert.False(t, Permissions.Allow(ResourceAlbums, RoleFriend, ActionAccessAll))
+		assert.False(t, Permissions.Allow(ResourceAlbums, RoleGuest, ActionShare))
+	})
+	t.Run("photos/family/access-all", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleFamily, ActionAccessAll))
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleFamily, ActionUpload))
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleFamily, ActionManage))
+	})
+	t.Run("photos/friend/access-all", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleFriend, ActionAccessAll))
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleFriend, ActionUpload))
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleFriend, ActionShare))
+	})
+	t.Run("photos/child/update", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleChild, ActionAccessAll))
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleChild, ActionUpdate))
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleChild, ActionUpload))
+	})
+	t.Run("photos/admin/manage", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleAdmin, ActionManage))
+	})
+	t.Run("labels/friend/update", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceLabels, RoleFriend, ActionSearch))
+		assert.False(t, Permissions.Allow(ResourceLabels, RoleFriend, ActionUpdate))
+		assert.True(t, Permissions.Allow(ResourceLabels, RoleFamily, ActionUpdate))
+	})
+	t.Run("labels/guest/search", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourceLabels, RoleGuest, ActionSearch))
+	})
 }
 
 func TestACL_Deny(t *testing.T) {
@@ -25,4 +25,5 @@ const (
 	ResourcePhotos        Resource = "photos"
 	ResourcePlaces        Resource = "places"
 	ResourceFeedback      Resource = "feedback"
+	ResourceAudit         Resource = "audit"
 )
@@ -0,0 +1,53 @@
+package acl
+
+import "strings"
+
+// Scope limits the actions a personal API token may perform.
+type Scope string
+
+const (
+	ScopeRead   Scope = "read"
+	ScopeUpload Scope = "upload"
+	ScopeFull   Scope = "full"
+)
+
+// Scopes maps API token scopes to the actions they allow, in addition to the user role permissions.
+var Scopes = map[Scope]Actions{
+	ScopeRead:   {ActionSearch: true, ActionRead: true, ActionDownload: true},
+	ScopeUpload: {ActionUpload: true, ActionImport: true},
+	ScopeFull:   {ActionDefault: true},
+}
+
+// ParseScope returns the scope matching the string, or an empty scope if it is invalid.
+func ParseScope(s string) Scope {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "read", "read-only", "readonly", "r":
+		return ScopeRead
+	case "upload", "upload-only", "uploadonly", "u":
+		return ScopeUpload
+	case "full", "all", "f", "*":
+		return ScopeFull
+	default:
+		return ""
+	}
+}
+
+// Valid tests if the scope is known.
+func (s Scope) Valid() bool {
+	_, ok := Scopes[s]
+	return ok
+}
+
+// Allow tests if the scope allows the action.
+func (s Scope) Allow(action Action) bool {
+	if a, ok := Scopes[s]; ok {
+		return a.Allow(action)
+	}
+
+	return false
+}
+
+// String returns the scope as string.
+func (s Scope) String() string {
+	return string(s)
+}
@@ -0,0 +1,40 @@
+package acl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScope(t *testing.T) {
+	assert.Equal(t, ScopeRead, ParseScope("read-only"))
+	assert.Equal(t, ScopeRead, ParseScope(" Read "))
+	assert.Equal(t, ScopeUpload, ParseScope("upload"))
+	assert.Equal(t, ScopeFull, ParseScope("full"))
+	assert.Equal(t, Scope(""), ParseScope("admin"))
+	assert.Equal(t, Scope(""), ParseScope(""))
+}
+
+func TestScope_Allow(t *testing.T) {
+	t.Run("read", func(t *testing.T) {
+		assert.True(t, ScopeRead.Allow(ActionSearch))
+		assert.True(t, ScopeRead.Allow(ActionDownload))
+		assert.False(t, ScopeRead.Allow(ActionUpdate))
+		assert.False(t, ScopeRead.Allow(ActionUpload))
+	})
+	t.Run("upload", func(t *testing.T) {
+		assert.True(t, ScopeUpload.Allow(ActionUpload))
+		assert.True(t, ScopeUpload.Allow(ActionImport))
+		assert.False(t, ScopeUpload.Allow(ActionRead))
+		assert.False(t, ScopeUpload.Allow(ActionDelete))
+	})
+	t.Run("full", func(t *testing.T) {
+		assert.True(t, ScopeFull.Allow(ActionDelete))
+		assert.True(t, ScopeFull.Allow(ActionUpdateSelf))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.False(t, Scope("foo").Allow(ActionRead))
+		assert.False(t, Scope("foo").Valid())
+		assert.True(t, ScopeRead.Valid())
+	})
+}
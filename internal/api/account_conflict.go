@@ -0,0 +1,130 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetAccountConflicts returns files that have been modified locally and remotely as JSON.
+//
+// GET /api/v1/accounts/:id/conflicts
+//
+// Parameters:
+//
+//	id: string Account ID as returned by the API
+func GetAccountConflicts(router *gin.RouterGroup) {
+	router.GET("/accounts/:id/conflicts", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		id := sanitize.IdUint(c.Param("id"))
+
+		if _, err := query.AccountByID(id); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		result, err := query.FileSyncs(id, entity.FileSyncConflict, 0)
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// ResolveAccountConflict resolves a sync conflict by keeping either the local or the remote file.
+//
+// POST /api/v1/accounts/:id/conflicts
+//
+// Parameters:
+//
+//	id: string Account ID as returned by the API
+func ResolveAccountConflict(router *gin.RouterGroup) {
+	router.POST("/accounts/:id/conflicts", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAccounts, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		id := sanitize.IdUint(c.Param("id"))
+
+		a, err := query.AccountByID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		var f form.AccountConflict
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.FindFileSync(a.ID, f.RemoteName)
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Resolve(f.Keep); err != nil {
+			log.Errorf("sync: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		if m.File != nil {
+			Audit(c, s, entity.AuditSyncResolve, entity.AuditDiff(gin.H{"Status": entity.FileSyncConflict}, gin.H{"Status": m.Status, "Keep": f.Keep}), m.File.FileUID)
+		}
+
+		// Start syncing the remaining changes.
+		if a.AccSync {
+			if err := a.Updates(entity.Values{"SyncStatus": entity.AccountSyncStatusRefresh}); err != nil {
+				log.Errorf("sync: %s", err)
+			}
+
+			workers.StartSync(conf)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
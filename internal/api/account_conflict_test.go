@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+)
+
+func TestGetAccountConflicts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := entity.NewFileSync(1000001, "/conflicts/api-list.jpg")
+		m.Status = entity.FileSyncConflict
+		m.FileID = 1000000
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, _ := NewApiTest()
+		GetAccountConflicts(router)
+		r := PerformRequest(app, "GET", "/api/v1/accounts/1000001/conflicts")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, gjson.Get(r.Body.String(), "#.RemoteName").String(), "/conflicts/api-list.jpg")
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAccountConflicts(router)
+		r := PerformRequest(app, "GET", "/api/v1/accounts/999000/conflicts")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAccountNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestResolveAccountConflict(t *testing.T) {
+	t.Run("KeepLocal", func(t *testing.T) {
+		m := entity.NewFileSync(1000001, "/conflicts/api-resolve.jpg")
+		m.Status = entity.FileSyncConflict
+		m.FileID = 1000000
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		app, router, _ := NewApiTest()
+		ResolveAccountConflict(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/accounts/1000001/conflicts", `{"RemoteName": "/conflicts/api-resolve.jpg", "Keep": "local"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.FileSyncChanged, gjson.Get(r.Body.String(), "Status").String())
+	})
+	t.Run("NoConflict", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveAccountConflict(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/accounts/1000001/conflicts", `{"RemoteName": "/20200706-092527-Landscape-Hamburg-2020.jpg", "Keep": "remote"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveAccountConflict(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/accounts/1000001/conflicts", `{"RemoteName": "/missing.jpg", "Keep": "remote"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveAccountConflict(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/accounts/1000001/conflicts", `{"Keep": "remote"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveAccountConflict(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/accounts/999000/conflicts", `{"RemoteName": "/missing.jpg", "Keep": "remote"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
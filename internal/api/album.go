@@ -56,7 +56,7 @@ func GetAlbum(router *gin.RouterGroup) {
 		id := sanitize.IdString(c.Param("uid"))
 		a, err := query.AlbumByUID(id)
 
-		if err != nil {
+		if err != nil || !CanViewAlbum(s, a) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -86,6 +86,7 @@ func CreateAlbum(router *gin.RouterGroup) {
 
 		a := entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
 		a.AlbumFavorite = f.AlbumFavorite
+		a.SetOwner(s.User.UserUID)
 
 		if res := entity.Db().Create(a); res.Error != nil {
 			AbortAlreadyExists(c, sanitize.Log(a.AlbumTitle))
@@ -122,6 +123,9 @@ func UpdateAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if !CanModify(s, acl.ResourceAlbums, a.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		f, err := form.NewAlbum(a)
@@ -132,6 +136,8 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		before := f
+
 		if err := c.BindJSON(&f); err != nil {
 			log.Error(err)
 			AbortBadRequest(c)
@@ -144,6 +150,10 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		if diff := entity.AuditDiff(before, f); diff != "" {
+			Audit(c, s, entity.AuditAlbumsUpdate, diff, uid)
+		}
+
 		UpdateClientConfig()
 
 		event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -175,6 +185,9 @@ func DeleteAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if !CanModify(s, acl.ResourceAlbums, a.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		// Regular, manually created album?
@@ -192,6 +205,8 @@ func DeleteAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		Audit(c, s, entity.AuditAlbumsDelete, "", id)
+
 		PublishAlbumEvent(EntityDeleted, id, c)
 
 		UpdateClientConfig()
@@ -223,7 +238,7 @@ func LikeAlbum(router *gin.RouterGroup) {
 		id := sanitize.IdString(c.Param("uid"))
 		a, err := query.AlbumByUID(id)
 
-		if err != nil {
+		if err != nil || !CanViewAlbum(s, a) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -262,7 +277,7 @@ func DislikeAlbum(router *gin.RouterGroup) {
 		id := sanitize.IdString(c.Param("uid"))
 		a, err := query.AlbumByUID(id)
 
-		if err != nil {
+		if err != nil || !CanViewAlbum(s, a) {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
 		}
@@ -299,6 +314,9 @@ func CloneAlbums(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if !CanModify(s, acl.ResourceAlbums, a.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		var f form.Selection
@@ -316,6 +334,9 @@ func CloneAlbums(router *gin.RouterGroup) {
 			if err != nil {
 				log.Errorf("album: %s", err)
 				continue
+			} else if !CanViewAlbum(s, cloneAlbum) {
+				log.Warnf("album: cannot clone %s, permission denied", sanitize.Log(uid))
+				continue
 			}
 
 			photos, err := search.AlbumPhotos(cloneAlbum, 10000, false)
@@ -365,6 +386,9 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if !CanContribute(s, a) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		// Fetch selection from index.
@@ -376,6 +400,19 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Users with restricted permissions may only add pictures they can see.
+		if Restricted(s, acl.ResourcePhotos) {
+			visible := make(entity.Photos, 0, len(photos))
+
+			for _, p := range photos {
+				if CanViewPhoto(s, p) {
+					visible = append(visible, p)
+				}
+			}
+
+			photos = visible
+		}
+
 		added := a.AddPhotos(photos.UIDs())
 
 		if len(added) > 0 {
@@ -425,6 +462,9 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
 			return
+		} else if !CanModify(s, acl.ResourceAlbums, a.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		removed := a.RemovePhotos(f.Photos)
@@ -452,13 +492,16 @@ func RemovePhotosFromAlbum(router *gin.RouterGroup) {
 // GET /api/v1/albums/:uid/dl
 func DownloadAlbum(router *gin.RouterGroup) {
 	router.GET("/albums/:uid/dl", func(c *gin.Context) {
-		if InvalidDownloadToken(c) {
+		uid := sanitize.IdString(c.Param("uid"))
+		link, ok := DownloadLink(c, uid)
+
+		if !ok {
 			AbortUnauthorized(c)
 			return
 		}
 
 		start := time.Now()
-		a, err := query.AlbumByUID(sanitize.IdString(c.Param("uid")))
+		a, err := query.AlbumByUID(uid)
 
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
@@ -515,5 +558,7 @@ func DownloadAlbum(router *gin.RouterGroup) {
 		}
 
 		log.Infof("download: created %s [%s]", sanitize.Log(zipFileName), time.Since(start))
+
+		RedeemDownload(c, link, zipFileName)
 	})
 }
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// memberAlbum returns the album whose members may be managed in the current session.
+func memberAlbum(c *gin.Context) (s session.Data, a entity.Album, ok bool) {
+	s = Auth(SessionID(c), acl.ResourceAlbums, acl.ActionShare)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return s, a, false
+	}
+
+	a, err := query.AlbumByUID(sanitize.IdString(c.Param("uid")))
+
+	if err != nil {
+		Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+		return s, a, false
+	} else if !CanModify(s, acl.ResourceAlbums, a.OwnerUID) {
+		AbortUnauthorized(c)
+		return s, a, false
+	}
+
+	return s, a, true
+}
+
+// GetAlbumMembers returns the users an album is shared with as JSON.
+//
+// GET /api/v1/albums/:uid/members
+func GetAlbumMembers(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/members", func(c *gin.Context) {
+		_, a, ok := memberAlbum(c)
+
+		if !ok {
+			return
+		}
+
+		members, err := entity.FindAlbumMembers(a.AlbumUID)
+
+		if err != nil {
+			log.Errorf("album: %s (find members)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, members)
+	})
+}
+
+// AddAlbumMember shares an album with a registered user as viewer or contributor,
+// or changes the role of an existing member.
+//
+// POST /api/v1/albums/:uid/members
+func AddAlbumMember(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/members", func(c *gin.Context) {
+		s, a, ok := memberAlbum(c)
+
+		if !ok {
+			return
+		}
+
+		var f form.AlbumMember
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		role := f.MemberRole()
+
+		if !entity.ValidMemberRole(role) {
+			AbortBadRequest(c)
+			return
+		}
+
+		var u *entity.User
+
+		if rnd.IsPPID(f.User, 'u') {
+			u = entity.FindUserByUID(f.User)
+		} else {
+			u = entity.FindUserByName(f.User)
+		}
+
+		if u == nil || !u.Registered() || u.Guest() {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		} else if a.OwnedBy(u.UserUID) {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.NewAlbumMember(a.AlbumUID, u.UserUID, role)
+		existing := entity.FindAlbumMember(a.AlbumUID, u.UserUID)
+
+		if existing != nil {
+			m.CreatedAt = existing.CreatedAt
+		}
+
+		if err := m.Save(); err != nil {
+			log.Errorf("album: %s (add member)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("album: shared %s with %s as %s", a.String(), u.String(), m.MemberRole)
+
+		Audit(c, s, entity.AuditAlbumsShare, entity.AuditDiff(existing, m), a.AlbumUID)
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// RemoveAlbumMember stops sharing an album with a user.
+//
+// DELETE /api/v1/albums/:uid/members/:user
+func RemoveAlbumMember(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/members/:user", func(c *gin.Context) {
+		s, a, ok := memberAlbum(c)
+
+		if !ok {
+			return
+		}
+
+		m := entity.FindAlbumMember(a.AlbumUID, sanitize.IdString(c.Param("user")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		} else if err := m.Delete(); err != nil {
+			log.Errorf("album: %s (remove member)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		log.Infof("album: stopped sharing %s with %s", a.String(), sanitize.Log(m.UserUID))
+
+		Audit(c, s, entity.AuditAlbumsUnshare, entity.AuditDiff(m, nil), a.AlbumUID)
+
+		PublishAlbumEvent(EntityUpdated, a.AlbumUID, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
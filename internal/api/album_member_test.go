@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumMembers(t *testing.T) {
+	t.Run("alice", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAlbumMembers(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/members", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "contributor", gjson.Get(r.Body.String(), "0.Role").String())
+	})
+	t.Run("bob", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAlbumMembers(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/members", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumMembers(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/xxx/members")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAddAlbumMember(t *testing.T) {
+	t.Run("add and remove", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		RemoveAlbumMember(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/members", `{"User": "friend", "Role": "contributor"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "uqxqg7i1kperxvu7", gjson.Get(r.Body.String(), "UserUID").String())
+		assert.Equal(t, "contributor", gjson.Get(r.Body.String(), "Role").String())
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/members", `{"User": "uqxqg7i1kperxvu7", "Role": "viewer"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "viewer", gjson.Get(r.Body.String(), "Role").String())
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba7/members/uqxqg7i1kperxvu7")
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba7/members/uqxqg7i1kperxvu7")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("invalid role", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/members", `{"User": "friend", "Role": "owner"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("owner", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba9/members", `{"User": "bob"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("user not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddAlbumMember(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/members", `{"User": "nobody"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
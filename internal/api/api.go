@@ -74,6 +74,14 @@ func Error(c *gin.Context, code int, err error, id i18n.Message, params ...inter
 	c.AbortWithStatusJSON(code, resp)
 }
 
+// AuthFailedKey is the context key of requests with an invalid password, passcode, or link token.
+const AuthFailedKey = "auth_failed"
+
+// AuthFailed marks the request as failed authentication attempt, so that the client can be throttled.
+func AuthFailed(c *gin.Context) {
+	c.Set(AuthFailedKey, true)
+}
+
 func AbortUnauthorized(c *gin.Context) {
 	Abort(c, http.StatusUnauthorized, i18n.ErrUnauthorized)
 }
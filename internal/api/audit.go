@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// Audit records a destructive or sharing action of the session user in the audit log,
+// one entry for each entity uid.
+func Audit(c *gin.Context, s session.Data, action, diff string, uids ...string) {
+	if len(uids) == 0 {
+		return
+	}
+
+	var u *entity.User
+
+	if s.Valid() {
+		u = &s.User
+	}
+
+	entity.AddAuditLog(u, auditSessUID(SessionID(c)), c.ClientIP(), action, uids, diff)
+}
+
+// auditSessUID returns the public uid of a session or personal API token.
+func auditSessUID(id string) string {
+	if id == "" {
+		return ""
+	} else if entity.IsUserToken(id) {
+		if t := entity.FindUserToken(id); t != nil {
+			return t.TokenUID
+		}
+	} else if m := entity.FindUserSession(id); m != nil {
+		return m.SessUID
+	}
+
+	return ""
+}
+
+// GetAuditLog returns audit log entries as JSON, most recent first.
+//
+// GET /api/v1/audit
+func GetAuditLog(router *gin.RouterGroup) {
+	router.GET("/audit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAudit, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.SearchAudit
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := search.AuditLog(f)
+
+		if err != nil {
+			log.Errorf("audit: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
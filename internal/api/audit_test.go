@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetAuditLog(t *testing.T) {
+	entity.AddAuditLog(entity.UserFixtures.Pointer("alice"), "", "127.0.0.1", entity.AuditLinksDelete, []string{"at9lxuqxpogaaba7"}, `{"Slug":["holiday-2030",null]}`)
+
+	t.Run("alice", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAuditLog(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/audit?count=10&action=links&uid=at9lxuqxpogaaba7", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "links.delete", gjson.Get(r.Body.String(), "0.Action").String())
+		assert.Equal(t, "alice", gjson.Get(r.Body.String(), "0.UserName").String())
+	})
+	t.Run("filter", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAuditLog(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/audit?count=10&action=photos.delete&uid=at9lxuqxpogaaba7", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("bob", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAuditLog(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/audit", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestAudit(t *testing.T) {
+	app, router, conf := NewApiTest()
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+	UpdateAlbum(router)
+	GetAuditLog(router)
+	sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+	r := AuthenticatedRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba7", `{"Notes": "Audited"}`, sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = AuthenticatedRequest(app, "GET", "/api/v1/audit?action=albums.update&uid=at9lxuqxpogaaba7", sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "albums.update", gjson.Get(r.Body.String(), "0.Action").String())
+	assert.Contains(t, gjson.Get(r.Body.String(), "0.Diff").String(), "Audited")
+	assert.True(t, gjson.Get(r.Body.String(), "0.SessUID").String() != "")
+}
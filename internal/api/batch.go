@@ -34,6 +34,10 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if err := ModifiableSelection(s, &f); err != nil {
+			log.Errorf("batch: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		if len(f.Photos) == 0 {
@@ -72,6 +76,8 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 
 		// UpdateClientConfig()
 
+		Audit(c, s, entity.AuditPhotosArchive, "", f.Photos...)
+
 		event.EntitiesArchived("photos", f.Photos)
 
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionArchived))
@@ -95,6 +101,10 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if err := ModifiableSelection(s, &f); err != nil {
+			log.Errorf("batch: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		if len(f.Photos) == 0 {
@@ -132,6 +142,8 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 
 		UpdateClientConfig()
 
+		Audit(c, s, entity.AuditPhotosRestore, "", f.Photos...)
+
 		event.EntitiesRestored("photos", f.Photos)
 
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgSelectionRestored))
@@ -155,6 +167,10 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if err := ModifiableSelection(s, &f); err != nil {
+			log.Errorf("batch: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		if len(f.Photos) == 0 {
@@ -208,6 +224,10 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if err := ModifiableSelection(s, &f); err != nil {
+			log.Errorf("batch: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		if len(f.Albums) == 0 {
@@ -222,6 +242,8 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 
 		UpdateClientConfig()
 
+		Audit(c, s, entity.AuditAlbumsDelete, "", f.Albums...)
+
 		event.EntitiesDeleted("albums", f.Albums)
 
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgAlbumsDeleted))
@@ -245,6 +267,10 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if err := ModifiableSelection(s, &f); err != nil {
+			log.Errorf("batch: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		if len(f.Photos) == 0 {
@@ -268,6 +294,7 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		if photos, err := query.SelectedPhotos(f); err == nil {
 			for _, p := range photos {
 				SavePhotoAsYaml(p)
+				Audit(c, s, entity.AuditPhotosPrivate, entity.AuditDiff(gin.H{"Private": !p.PhotoPrivate}, gin.H{"Private": p.PhotoPrivate}), p.PhotoUID)
 			}
 
 			event.EntitiesUpdated("photos", photos)
@@ -298,6 +325,10 @@ func BatchLabelsDelete(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if err := ModifiableSelection(s, &f); err != nil {
+			log.Errorf("batch: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		if len(f.Labels) == 0 {
@@ -351,6 +382,10 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		if err := c.BindJSON(&f); err != nil {
 			AbortBadRequest(c)
 			return
+		} else if err := ModifiableSelection(s, &f); err != nil {
+			log.Errorf("batch: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		if len(f.Photos) == 0 {
@@ -386,6 +421,8 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 
 			UpdateClientConfig()
 
+			Audit(c, s, entity.AuditPhotosDelete, "", deleted.UIDs()...)
+
 			event.EntitiesDeleted("photos", deleted.UIDs())
 		}
 
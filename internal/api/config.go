@@ -9,6 +9,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/service"
@@ -30,7 +31,7 @@ func GetConfig(router *gin.RouterGroup) {
 		conf := service.Config()
 
 		if s.User.Guest() {
-			c.JSON(http.StatusOK, conf.GuestConfig())
+			c.JSON(http.StatusOK, ShareConfig(s.Tokens))
 		} else if s.User.Registered() {
 			c.JSON(http.StatusOK, conf.UserConfig())
 		} else {
@@ -97,6 +98,12 @@ func SaveConfigOptions(router *gin.RouterGroup) {
 			}
 		}
 
+		before := make(valueMap, len(v))
+
+		for key, val := range v {
+			before[key] = val
+		}
+
 		if err := c.BindJSON(&v); err != nil {
 			log.Errorf("options: %s", err)
 			AbortBadRequest(c)
@@ -133,6 +140,10 @@ func SaveConfigOptions(router *gin.RouterGroup) {
 
 		conf.Propagate()
 
+		if diff := entity.AuditDiff(before, v); diff != "" {
+			Audit(c, s, entity.AuditConfigUpdate, diff, "options")
+		}
+
 		UpdateClientConfig()
 
 		event.InfoMsg(i18n.MsgSettingsSaved)
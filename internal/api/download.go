@@ -32,6 +32,38 @@ func DownloadName(c *gin.Context) entity.DownloadName {
 	}
 }
 
+// DownloadLink checks the download token of a request. If a share link token was used instead of the
+// regular download token, it returns the matching link so that the download can be counted. Links must
+// allow downloading originals and share the entity with the specified uid, unless it is empty.
+func DownloadLink(c *gin.Context, uid string) (link *entity.Link, ok bool) {
+	token := sanitize.Token(c.Query("t"))
+
+	if token == "" {
+		return nil, false
+	} else if !service.Config().InvalidDownloadToken(token) {
+		return nil, true
+	}
+
+	for _, m := range entity.FindValidLinks(token, "") {
+		if m.Downloadable() && (uid == "" || m.Shares(uid)) {
+			result := m
+			return &result, true
+		}
+	}
+
+	return nil, false
+}
+
+// RedeemDownload counts a download with a share link and adds it to the link's access history.
+func RedeemDownload(c *gin.Context, link *entity.Link, name string) {
+	if link == nil {
+		return
+	}
+
+	link.RedeemDownload()
+	link.AddAccess(entity.LinkAccessDownload, name, c.ClientIP(), c.Request.UserAgent())
+}
+
 // GET /api/v1/dl/:hash
 //
 // Parameters:
@@ -39,7 +71,7 @@ func DownloadName(c *gin.Context) entity.DownloadName {
 //	hash: string The file hash as returned by the search API
 func GetDownload(router *gin.RouterGroup) {
 	router.GET("/dl/:hash", func(c *gin.Context) {
-		if InvalidDownloadToken(c) {
+		if _, ok := DownloadLink(c, ""); !ok {
 			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
 			return
 		}
@@ -53,6 +85,14 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
+		// Share links must include the photo and allow downloading originals.
+		link, ok := DownloadLink(c, f.PhotoUID)
+
+		if !ok {
+			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
+			return
+		}
+
 		fileName := photoprism.FileName(f.FileRoot, f.FileName)
 
 		if !fs.FileExists(fileName) {
@@ -65,6 +105,10 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
+		downloadName := f.DownloadName(DownloadName(c), 0)
+
+		c.FileAttachment(fileName, downloadName)
+
+		RedeemDownload(c, link, downloadName)
 	})
 }
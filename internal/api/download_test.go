@@ -7,6 +7,8 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 func TestGetDownload(t *testing.T) {
@@ -33,3 +35,81 @@ func TestGetDownload(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestDownloadLink(t *testing.T) {
+	viewOnly := entity.NewLink("at9lxuqxpogaaba8", false, false)
+	viewOnly.ViewOnly = true
+
+	if err := viewOnly.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer viewOnly.Delete()
+
+	t.Run("link allows originals", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t=1jxf3jfn2k")
+		// Access is granted, but the original is missing in the test environment.
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("photo not shared", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11/dl?t=1jxf3jfn2k")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("view only", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoDownload(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/dl?t="+viewOnly.LinkToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("share config", func(t *testing.T) {
+		NewApiTest()
+		assert.Equal(t, "1jxf3jfn2k", ShareConfig([]string{"1jxf3jfn2k"}).DownloadToken)
+		assert.Equal(t, "", ShareConfig([]string{viewOnly.LinkToken}).DownloadToken)
+		assert.False(t, ShareConfig([]string{viewOnly.LinkToken}).Settings.Features.Download)
+	})
+}
+
+func TestGetDownload_ViewOnlyGuest(t *testing.T) {
+	link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+	link.ViewOnly = true
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	app, router, conf := NewApiTest()
+	conf.SetPublic(false)
+	defer conf.SetPublic(true)
+
+	CreateSession(router)
+	GetConfig(router)
+	GetDownload(router)
+
+	r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"token": "`+link.LinkToken+`"}`)
+
+	if r.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", r.Code)
+	}
+
+	assert.Equal(t, "", gjson.Get(r.Body.String(), "config.downloadToken").String())
+
+	sessId := r.Header().Get("X-Session-ID")
+
+	r = AuthenticatedRequest(app, http.MethodGet, "/api/v1/config", sessId)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	token := gjson.Get(r.Body.String(), "downloadToken").String()
+	assert.Equal(t, "", token)
+
+	r = PerformRequest(app, http.MethodGet, "/api/v1/dl/3cad9168fa6acc5c5c2965ddf6ec465ca42fd818?t="+token)
+	assert.Equal(t, http.StatusForbidden, r.Code)
+
+	r = PerformRequest(app, http.MethodGet, "/api/v1/dl/3cad9168fa6acc5c5c2965ddf6ec465ca42fd818?t="+link.LinkToken)
+	assert.Equal(t, http.StatusForbidden, r.Code)
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// duplicatesOptions returns the near-duplicate and burst detection options from the request.
+func duplicatesOptions(c *gin.Context) photoprism.DuplicatesOptions {
+	opt := photoprism.DuplicatesOptionsDefault()
+
+	if dist := c.Query("dist"); dist != "" {
+		opt.Distance = txt.Int(dist)
+	}
+
+	if interval := c.Query("interval"); interval != "" {
+		opt.BurstInterval = time.Duration(txt.Int(interval)) * time.Second
+	}
+
+	return opt
+}
+
+// GetDuplicates returns groups of near-duplicates and burst shots with the recommended photo to keep.
+//
+// GET /api/v1/duplicates
+//
+// Parameters:
+//
+//	dist: int Maximum perceptual hash distance of near-duplicates (optional)
+//	interval: int Maximum number of seconds between burst shots, 0 to disable (optional)
+func GetDuplicates(router *gin.RouterGroup) {
+	router.GET("/duplicates", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		// Duplicates are detected across the whole library, so users must be able to manage it.
+		if s.Invalid() || Restricted(s, acl.ResourcePhotos) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		groups, err := service.Duplicates().Find(duplicatesOptions(c))
+
+		if err != nil {
+			log.Errorf("duplicates: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(groups))
+
+		c.JSON(http.StatusOK, groups)
+	})
+}
+
+// AcceptDuplicates accepts a group suggestion, keeping the specified photo and stacking
+// or archiving the other photos in the group.
+//
+// POST /api/v1/duplicates/:uid
+//
+// Parameters:
+//
+//	uid: string Photo UID of the photo to keep
+func AcceptDuplicates(router *gin.RouterGroup) {
+	router.POST("/duplicates/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		// Duplicates are detected across the whole library, so users must be able to manage it.
+		if s.Invalid() || Restricted(s, acl.ResourcePhotos) {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Duplicates
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		switch f.Action {
+		case photoprism.DuplicateActionStack:
+		case photoprism.DuplicateActionArchive:
+			if s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionDelete); s.Invalid() {
+				AbortUnauthorized(c)
+				return
+			}
+		default:
+			AbortBadRequest(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+		w := service.Duplicates()
+
+		groups, err := w.Find(duplicatesOptions(c))
+
+		if err != nil {
+			log.Errorf("duplicates: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		g, ok := groups.Find(uid)
+
+		if !ok {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		keeper, others, err := w.Accept(g, uid, f.Action)
+
+		if err != nil {
+			log.Errorf("duplicates: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		uids := make([]string, len(others))
+
+		for i, p := range others {
+			uids[i] = p.PhotoUID
+
+			if service.Config().BackupYaml() {
+				SavePhotoAsYaml(p)
+			}
+		}
+
+		if service.Config().BackupYaml() {
+			SavePhotoAsYaml(keeper)
+		}
+
+		// Update precalculated photo and file counts.
+		logWarn("index", entity.UpdateCounts())
+
+		event.EntitiesArchived("photos", uids)
+
+		PublishPhotoEvent(EntityUpdated, keeper.PhotoUID, c)
+
+		c.JSON(http.StatusOK, g)
+	})
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetDuplicates(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "duplicate", gjson.Get(r.Body.String(), "0.Type").String())
+		assert.Equal(t, "pt9jtdre2lvl0yh7", gjson.Get(r.Body.String(), "0.Keeper.UID").String())
+		assert.Equal(t, "pt9jtdre2lvl0y11", gjson.Get(r.Body.String(), "0.Photos.0.UID").String())
+	})
+	t.Run("max distance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetDuplicates(router)
+		r := PerformRequest(app, "GET", "/api/v1/duplicates?dist=1&interval=0")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+}
+
+func TestAcceptDuplicates(t *testing.T) {
+	t.Run("invalid action", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AcceptDuplicates(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/pt9jtdre2lvl0yh7", `{"Action": "delete"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no suggestion", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AcceptDuplicates(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/duplicates/pt9jtdre2lvl0yh8", `{"Action": "stack"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -75,6 +76,8 @@ func DeleteFile(router *gin.RouterGroup) {
 			return
 		}
 
+		Audit(c, s, entity.AuditFilesDelete, entity.AuditDiff(gin.H{"Photo": photoUID, "Name": file.FileName, "Root": file.FileRoot}, nil), fileUID)
+
 		// Notify clients by publishing events.
 		PublishPhotoEvent(EntityUpdated, photoUID, c)
 
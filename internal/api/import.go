@@ -77,6 +77,9 @@ func StartImport(router *gin.RouterGroup) {
 			opt = photoprism.ImportOptionsCopy(path)
 		}
 
+		// Imported pictures belong to the user who started the import.
+		opt.OwnerUID = s.User.UserUID
+
 		if len(f.Albums) > 0 {
 			log.Debugf("import: adding files to album %s", sanitize.Log(strings.Join(f.Albums, " and ")))
 			opt.Albums = f.Albums
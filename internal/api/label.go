@@ -41,6 +41,9 @@ func UpdateLabel(router *gin.RouterGroup) {
 		if err != nil {
 			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
 			return
+		} else if !CanModify(s, acl.ResourceLabels, m.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		m.SetName(f.LabelName)
@@ -76,6 +79,9 @@ func LikeLabel(router *gin.RouterGroup) {
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
 			return
+		} else if !CanModify(s, acl.ResourceLabels, label.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		if err := label.Update("LabelFavorite", true); err != nil {
@@ -117,6 +123,9 @@ func DislikeLabel(router *gin.RouterGroup) {
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": txt.UcFirst(err.Error())})
 			return
+		} else if !CanModify(s, acl.ResourceLabels, label.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		if err := label.Update("LabelFavorite", false); err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
 
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -35,8 +36,21 @@ func UpdateLink(c *gin.Context) {
 
 	link := entity.FindLink(sanitize.Token(c.Param("link")))
 
+	if link == nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	before := *link
+
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
+	link.MaxDownloads = f.MaxDownloads
+	link.ViewOnly = f.ViewOnly
+	link.MaxUploads = f.MaxUploads
+	link.MaxUploadSize = f.MaxUploadSize
+	link.UploadTypes = strings.ToLower(strings.ReplaceAll(f.UploadTypes, " ", ""))
+	link.Moderate = f.Moderate
 	link.LinkExpires = f.LinkExpires
 
 	if f.LinkToken != "" {
@@ -55,6 +69,8 @@ func UpdateLink(c *gin.Context) {
 		return
 	}
 
+	Audit(c, s, entity.AuditLinksUpdate, entity.AuditDiff(before, link), link.ShareUID)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -75,11 +91,16 @@ func DeleteLink(c *gin.Context) {
 
 	link := entity.FindLink(sanitize.Token(c.Param("link")))
 
-	if err := link.Delete(); err != nil {
+	if link == nil {
+		AbortEntityNotFound(c)
+		return
+	} else if err := link.Delete(); err != nil {
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
 		return
 	}
 
+	Audit(c, s, entity.AuditLinksDelete, entity.AuditDiff(link, nil), link.ShareUID)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -89,6 +110,39 @@ func DeleteLink(c *gin.Context) {
 	c.JSON(http.StatusOK, link)
 }
 
+// GetLinkAccess returns the access history of a link as JSON, most recent first.
+func GetLinkAccess(c *gin.Context) {
+	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionRead)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return
+	}
+
+	link := entity.FindLink(sanitize.Token(c.Param("link")))
+
+	if link == nil || link.ShareUID != sanitize.IdString(c.Param("uid")) {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	limit := txt.Int(c.Query("count"))
+
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	result, err := link.Access(limit)
+
+	if err != nil {
+		log.Errorf("link: %s (access history)", err)
+		AbortUnexpected(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // CreateLink returns a new link entity initialized with request data
 func CreateLink(c *gin.Context) {
 	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionCreate)
@@ -107,8 +161,22 @@ func CreateLink(c *gin.Context) {
 
 	link := entity.NewLink(sanitize.IdString(c.Param("uid")), f.CanComment, f.CanEdit)
 
+	// Upload links may only be created for albums.
+	if f.LinkType == entity.LinkTypeUpload && rnd.IsPPID(link.ShareUID, 'a') {
+		link.LinkType = entity.LinkTypeUpload
+	} else if f.LinkType != entity.LinkTypeShare {
+		AbortBadRequest(c)
+		return
+	}
+
 	link.SetSlug(f.ShareSlug)
 	link.MaxViews = f.MaxViews
+	link.MaxDownloads = f.MaxDownloads
+	link.ViewOnly = f.ViewOnly
+	link.MaxUploads = f.MaxUploads
+	link.MaxUploadSize = f.MaxUploadSize
+	link.UploadTypes = strings.ToLower(strings.ReplaceAll(f.UploadTypes, " ", ""))
+	link.Moderate = f.Moderate
 	link.LinkExpires = f.LinkExpires
 
 	if f.Password != "" {
@@ -123,6 +191,8 @@ func CreateLink(c *gin.Context) {
 		return
 	}
 
+	Audit(c, s, entity.AuditLinksCreate, entity.AuditDiff(nil, link), link.ShareUID)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -158,6 +228,13 @@ func DeleteAlbumLink(router *gin.RouterGroup) {
 	})
 }
 
+// GET /api/v1/albums/:uid/links/:link/access
+func GetAlbumLinkAccess(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/links/:link/access", func(c *gin.Context) {
+		GetLinkAccess(c)
+	})
+}
+
 // GET /api/v1/albums/:uid/links
 func GetAlbumLinks(router *gin.RouterGroup) {
 	router.GET("/albums/:uid/links", func(c *gin.Context) {
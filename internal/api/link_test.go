@@ -403,3 +403,39 @@ func TestGetLabelLinks(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestGetAlbumLinkAccess(t *testing.T) {
+	link := entity.NewLink("at9lxuqxpogaaba7", false, false)
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	link.AddAccess(entity.LinkAccessView, "", "127.0.0.1", "Mozilla/5.0")
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumLinkAccess(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba7/links/"+link.LinkUID+"/access")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "view", gjson.Get(r.Body.String(), "0.Type").String())
+	})
+	t.Run("wrong album", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumLinkAccess(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/links/"+link.LinkUID+"/access")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetAlbumLinkAccess(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba7/links/"+link.LinkUID+"/access", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -0,0 +1,294 @@
+package api
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/oidc"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// OidcStateCookie is the name of the cookie that binds a pending authorization request to the browser.
+const OidcStateCookie = "oidc_state"
+
+// oidcAuth represents a pending OpenID Connect authorization request.
+type oidcAuth struct {
+	Nonce    string
+	Verifier string
+}
+
+// oidcRequestExpiration specifies how long authorization requests remain valid.
+const oidcRequestExpiration = 10 * time.Minute
+
+// oidcRequests contains pending authorization requests by state.
+var oidcRequests = gc.New(oidcRequestExpiration, time.Minute)
+
+// oidcClient caches the OpenID Connect client, so that the provider configuration is not fetched for every login.
+var oidcClient = struct {
+	sync.Mutex
+	client *oidc.Client
+	opt    oidc.Options
+}{}
+
+// OidcClient returns the OpenID Connect client for the current config options.
+func OidcClient() (*oidc.Client, error) {
+	opt := service.Config().OidcOptions()
+
+	oidcClient.Lock()
+	defer oidcClient.Unlock()
+
+	if oidcClient.client != nil && reflect.DeepEqual(oidcClient.opt, opt) {
+		return oidcClient.client, nil
+	}
+
+	client, err := oidc.NewClient(opt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	oidcClient.client = client
+	oidcClient.opt = opt
+
+	return client, nil
+}
+
+// oidcRedirectPage stores the session in the browser before opening the app.
+var oidcRedirectPage = template.Must(template.New("oidc").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body>
+<script>
+window.localStorage.setItem("session_id", {{.ID}});
+window.localStorage.setItem("data", {{.Data}});
+window.location.replace({{.Url}});
+</script>
+</body>
+</html>
+`))
+
+// OidcUser returns the user account for the OpenID Connect claims. Users are matched by subject identifier, or
+// by verified email address when logging in for the first time if linking is enabled. New accounts are only
+// created if registration is enabled. If groups are mapped to roles, the user role is updated on every login,
+// except for the initial admin account.
+func OidcUser(claims oidc.Claims) (*entity.User, error) {
+	conf := service.Config()
+
+	m := entity.FindUserByOidcSubject(claims.Subject)
+
+	if m == nil && claims.EmailVerified && conf.OidcLink() {
+		if m = entity.FindUserByEmail(claims.Email); m != nil && m.OidcSubject != "" {
+			return nil, fmt.Errorf("email %s is linked to another subject", sanitize.Log(claims.Email))
+		}
+	}
+
+	if m == nil {
+		if !conf.OidcRegister() {
+			return nil, fmt.Errorf("subject %s is not linked to a user account", sanitize.Log(claims.Subject))
+		}
+
+		m = &entity.User{
+			UserName: sanitize.Username(claims.UserName()),
+			FullName: claims.Name,
+		}
+
+		if claims.EmailVerified {
+			m.PrimaryEmail = claims.Email
+		}
+
+		if err := m.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply the mapped role before checking if the user may log in, so that demoted users are rejected.
+	if len(conf.OidcRoles()) > 0 && m.ID != entity.Admin.ID {
+		role, _ := conf.OidcRole(claims.Groups)
+		m.SetRole(role)
+	}
+
+	if m.UserDisabled || m.Guest() {
+		// Store the new role of existing users, so that they cannot keep using their previous role.
+		if m.ID != 0 {
+			if err := m.Save(); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, fmt.Errorf("%s may not log in", m.String())
+	}
+
+	m.OidcSubject = claims.Subject
+
+	if m.ID == 0 {
+		if err := m.Create(); err != nil {
+			return nil, err
+		}
+
+		log.Infof("oidc: created account %s", m.String())
+	} else if err := m.Save(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// OidcLogin redirects the browser to the identity provider for authentication.
+//
+// GET /api/v1/oidc/login
+func OidcLogin(router *gin.RouterGroup) {
+	router.GET("/oidc/login", func(c *gin.Context) {
+		if !service.Config().OidcEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		client, err := OidcClient()
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			Abort(c, http.StatusServiceUnavailable, i18n.ErrConnectionFailed)
+			return
+		}
+
+		state := oidc.RandomString(24)
+		auth := oidcAuth{Nonce: oidc.RandomString(24), Verifier: oidc.NewVerifier()}
+
+		oidcRequests.SetDefault(state, auth)
+
+		// Remember the state in the browser so that the authorization response can't be
+		// completed in a different one, e.g. after tricking a user into following a link.
+		// SameSite must be lax, as the identity provider redirects with a top-level cross-site request.
+		conf := service.Config()
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(OidcStateCookie, state, int(oidcRequestExpiration.Seconds()), conf.ApiUri(), "", strings.HasPrefix(conf.SiteUrl(), "https://"), true)
+
+		c.Redirect(http.StatusFound, client.AuthUrl(state, auth.Nonce, auth.Verifier))
+	})
+}
+
+// OidcRedirect handles the authorization response of the identity provider and creates a new session.
+// Browsers are redirected to the app, clients that accept JSON get the same response as for CreateSession.
+//
+// GET /api/v1/oidc/redirect
+func OidcRedirect(router *gin.RouterGroup) {
+	router.GET("/oidc/redirect", func(c *gin.Context) {
+		conf := service.Config()
+
+		if !conf.OidcEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		if e := c.Query("error"); e != "" {
+			log.Warnf("oidc: %s (%s)", sanitize.Log(e), sanitize.Log(c.Query("error_description")))
+			AbortUnauthorized(c)
+			return
+		}
+
+		state := c.Query("state")
+		cookie, _ := c.Cookie(OidcStateCookie)
+
+		// The state cookie is no longer needed.
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(OidcStateCookie, "", -1, conf.ApiUri(), "", strings.HasPrefix(conf.SiteUrl(), "https://"), true)
+
+		if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookie)) != 1 {
+			log.Warnf("oidc: state does not match browser")
+			AbortUnauthorized(c)
+			return
+		}
+
+		cached, found := oidcRequests.Get(state)
+
+		if !found {
+			log.Warnf("oidc: invalid state")
+			AbortUnauthorized(c)
+			return
+		}
+
+		// Authorization requests can only be used once.
+		oidcRequests.Delete(state)
+
+		auth := cached.(oidcAuth)
+
+		client, err := OidcClient()
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			Abort(c, http.StatusServiceUnavailable, i18n.ErrConnectionFailed)
+			return
+		}
+
+		tokens, err := client.Exchange(c.Query("code"), auth.Verifier)
+
+		if err != nil {
+			log.Warnf("%s", err)
+			AbortUnauthorized(c)
+			return
+		}
+
+		claims, err := client.Verify(tokens.IDToken, auth.Nonce)
+
+		if err != nil {
+			log.Warnf("%s", err)
+			AbortUnauthorized(c)
+			return
+		}
+
+		user, err := OidcUser(claims)
+
+		if err != nil {
+			log.Warnf("oidc: %s", err)
+			AbortUnauthorized(c)
+			return
+		}
+
+		log.Infof("oidc: %s logged in", user.String())
+
+		data := session.Data{User: *user}
+		id := service.Session().Create(data, SessionClient(c))
+
+		AddSessionHeader(c, id)
+
+		if strings.Contains(c.GetHeader("Accept"), "application/json") {
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.UserConfigFull(false)})
+			return
+		}
+
+		dataJson, err := json.Marshal(data)
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		var page bytes.Buffer
+
+		values := gin.H{"Title": conf.SiteTitle(), "ID": id, "Data": string(dataJson), "Url": conf.BaseUri("/")}
+
+		if err = oidcRedirectPage.Execute(&page, values); err != nil {
+			log.Errorf("oidc: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+	})
+}
@@ -0,0 +1,240 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+// oidcTest configures single sign-on with a local mock identity provider.
+func oidcTest(t *testing.T, claims oidc.Claims) (*gin.Engine, *oidc.MockProvider, *config.Config) {
+	app, router, conf := NewApiTest()
+
+	provider, err := oidc.NewMockProvider("photoprism", claims)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf.SetPublic(false)
+	conf.Options().OidcUri = provider.Issuer()
+	conf.Options().OidcClient = "photoprism"
+
+	t.Cleanup(func() {
+		provider.Close()
+		conf.Options().OidcUri = ""
+		conf.Options().OidcClient = ""
+		conf.Options().OidcRegister = false
+		conf.Options().OidcLink = false
+		conf.Options().OidcRoles = ""
+		conf.SetPublic(true)
+	})
+
+	OidcLogin(router)
+	OidcRedirect(router)
+
+	return app, provider, conf
+}
+
+// oidcLogin performs the login flow and returns the final response.
+func oidcLogin(t *testing.T, app *gin.Engine, provider *oidc.MockProvider, accept string) *httptest.ResponseRecorder {
+	r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+
+	if !assert.Equal(t, http.StatusFound, r.Code) {
+		return r
+	}
+
+	redirect, err := provider.Authorize(r.Header().Get("Location"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest("GET", "/api/v1/oidc/redirect?"+redirect.RawQuery, nil)
+	req.Header.Set("Accept", accept)
+
+	for _, cookie := range r.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestOidcLogin(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OidcLogin(router)
+		r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("redirect", func(t *testing.T) {
+		app, provider, _ := oidcTest(t, oidc.Claims{Subject: "jane"})
+		r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusFound, r.Code)
+		location := r.Header().Get("Location")
+		assert.True(t, strings.HasPrefix(location, provider.URL+"/authorize?"))
+		assert.Contains(t, location, "code_challenge_method=S256")
+		assert.Contains(t, location, "redirect_uri=http%3A%2F%2Flocalhost%3A2342%2Fapi%2Fv1%2Foidc%2Fredirect")
+	})
+}
+
+func TestOidcRedirect(t *testing.T) {
+	t.Run("register", func(t *testing.T) {
+		claims := oidc.Claims{Subject: "oidc-jane", PreferredUsername: "jane.doe", Name: "Jane Doe", Email: "jane@example.com", EmailVerified: true, Groups: []string{"Family"}}
+		app, provider, conf := oidcTest(t, claims)
+		conf.Options().OidcRegister = true
+		conf.Options().OidcRoles = "admins=admin,family=family"
+
+		r := oidcLogin(t, app, provider, "application/json")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "jane.doe", gjson.Get(r.Body.String(), "data.user.UserName").String())
+		assert.True(t, gjson.Get(r.Body.String(), "data.user.RoleFamily").Bool())
+		assert.NotEmpty(t, r.Header().Get("X-Session-ID"))
+
+		m := entity.FindUserByOidcSubject("oidc-jane")
+
+		if assert.NotNil(t, m) {
+			assert.Equal(t, "Jane Doe", m.FullName)
+			assert.Equal(t, "jane@example.com", m.PrimaryEmail)
+		}
+
+		// Roles are updated on every login.
+		claims.Groups = []string{"admins"}
+		provider.SetClaims(claims)
+
+		r = oidcLogin(t, app, provider, "text/html")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "session_id")
+		assert.True(t, entity.FindUserByOidcSubject("oidc-jane").RoleAdmin)
+
+		// Users demoted to guest may no longer log in.
+		conf.Options().OidcRoles = "admins=admin,family=family,guests=guest"
+		claims.Groups = []string{"guests"}
+		provider.SetClaims(claims)
+
+		r = oidcLogin(t, app, provider, "application/json")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		if m = entity.FindUserByOidcSubject("oidc-jane"); assert.NotNil(t, m) {
+			assert.False(t, m.RoleAdmin)
+			assert.True(t, m.Guest())
+		}
+	})
+	t.Run("link disabled", func(t *testing.T) {
+		app, provider, _ := oidcTest(t, oidc.Claims{Subject: "oidc-friend", Email: "friend@example.com", EmailVerified: true})
+
+		r := oidcLogin(t, app, provider, "application/json")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Nil(t, entity.FindUserByOidcSubject("oidc-friend"))
+	})
+	t.Run("link by email", func(t *testing.T) {
+		app, provider, conf := oidcTest(t, oidc.Claims{Subject: "oidc-bob", Email: "bob@example.com", EmailVerified: true})
+		conf.Options().OidcLink = true
+
+		r := oidcLogin(t, app, provider, "application/json")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "bob", gjson.Get(r.Body.String(), "data.user.UserName").String())
+	})
+	t.Run("unverified email", func(t *testing.T) {
+		app, provider, conf := oidcTest(t, oidc.Claims{Subject: "oidc-alice", Email: "alice@example.com"})
+		conf.Options().OidcLink = true
+
+		r := oidcLogin(t, app, provider, "application/json")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		app, provider, _ := oidcTest(t, oidc.Claims{Subject: "oidc-unknown", PreferredUsername: "unknown"})
+
+		r := oidcLogin(t, app, provider, "application/json")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.Nil(t, entity.FindUserByName("unknown"))
+	})
+	t.Run("invalid state", func(t *testing.T) {
+		app, _, _ := oidcTest(t, oidc.Claims{Subject: "jane"})
+		r := PerformRequest(app, "GET", "/api/v1/oidc/redirect?code=123&state=invalid")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("other browser", func(t *testing.T) {
+		app, provider, _ := oidcTest(t, oidc.Claims{Subject: "jane"})
+		r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+
+		if !assert.Equal(t, http.StatusFound, r.Code) {
+			return
+		}
+
+		redirect, err := provider.Authorize(r.Header().Get("Location"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Without the state cookie.
+		r = PerformRequest(app, "GET", "/api/v1/oidc/redirect?"+redirect.RawQuery)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		// With the cookie of another authorization request.
+		other := PerformRequest(app, "GET", "/api/v1/oidc/login")
+		req, _ := http.NewRequest("GET", "/api/v1/oidc/redirect?"+redirect.RawQuery, nil)
+
+		for _, cookie := range other.Result().Cookies() {
+			req.AddCookie(cookie)
+		}
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+	t.Run("error", func(t *testing.T) {
+		app, _, _ := oidcTest(t, oidc.Claims{Subject: "jane"})
+		r := PerformRequest(app, "GET", "/api/v1/oidc/redirect?error=access_denied")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestOidcUser(t *testing.T) {
+	t.Run("admin role", func(t *testing.T) {
+		_, _, conf := oidcTest(t, oidc.Claims{Subject: "oidc-admin"})
+		conf.Options().OidcRoles = "admins=admin,friends=friend"
+
+		admin := entity.FindUserByName("admin")
+
+		if admin == nil {
+			t.Fatal("admin not found")
+		}
+
+		admin.OidcSubject = "oidc-admin"
+
+		if err := admin.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() {
+			admin.OidcSubject = ""
+			admin.SetRole(acl.RoleAdmin)
+			_ = admin.Save()
+		}()
+
+		// The initial admin account is never demoted.
+		m, err := OidcUser(oidc.Claims{Subject: "oidc-admin", Groups: []string{"friends"}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.Admin())
+		assert.False(t, m.RoleFriend)
+	})
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// Restricted tests if the session user may only access their own and shared items.
+// Guests are not restricted by ownership, their permissions are limited to shared albums.
+func Restricted(s session.Data, resource acl.Resource) bool {
+	if s.Invalid() || s.Guest() {
+		return false
+	}
+
+	return acl.Permissions.Deny(resource, s.User.Role(), acl.ActionManage)
+}
+
+// Library tests if the session user may access items of other users that are not private.
+func Library(s session.Data, resource acl.Resource) bool {
+	return acl.Permissions.Allow(resource, s.User.Role(), acl.ActionAccessAll)
+}
+
+// SearchScope returns the search scope of the session user, it is empty if results don't need to be limited.
+func SearchScope(s session.Data, resource acl.Resource) form.SearchScope {
+	if s.Invalid() || s.Guest() {
+		return form.SearchScope{}
+	} else if !Restricted(s, resource) {
+		return form.SearchScope{Member: s.User.UserUID}
+	}
+
+	return form.SearchScope{
+		Owner:   s.User.UserUID,
+		Shared:  s.Shares,
+		Library: Library(s, resource),
+		Member:  s.User.UserUID,
+	}
+}
+
+// CanModify tests if the session user may change an item with the specified owner. Users with access
+// to the whole library may also change items without an owner.
+func CanModify(s session.Data, resource acl.Resource, ownerUID string) bool {
+	if !Restricted(s, resource) {
+		return true
+	} else if ownerUID == "" {
+		return Library(s, resource)
+	}
+
+	return ownerUID == s.User.UserUID
+}
+
+// CanViewPhoto tests if the session user may view the photo.
+func CanViewPhoto(s session.Data, p entity.Photo) bool {
+	if !Restricted(s, acl.ResourcePhotos) || p.OwnedBy(s.User.UserUID) {
+		return true
+	} else if p.PhotoPrivate {
+		return false
+	} else if Library(s, acl.ResourcePhotos) {
+		return true
+	}
+
+	// Photos in shared albums are visible as well.
+	f := form.SearchPhotos{UID: p.PhotoUID, Count: 1, Scope: SearchScope(s, acl.ResourcePhotos)}
+
+	if results, _, err := search.Photos(f); err != nil {
+		log.Warnf("photo: %s", err)
+		return false
+	} else {
+		return len(results) > 0
+	}
+}
+
+// CanViewAlbum tests if the session user may view the album.
+func CanViewAlbum(s session.Data, a entity.Album) bool {
+	if !Restricted(s, acl.ResourceAlbums) || a.OwnedBy(s.User.UserUID) || s.HasShare(a.AlbumUID) {
+		return true
+	} else if !a.AlbumPrivate && Library(s, acl.ResourceAlbums) {
+		return true
+	}
+
+	return entity.FindAlbumMember(a.AlbumUID, s.User.UserUID) != nil
+}
+
+// CanContribute tests if the session user may add pictures to the album.
+func CanContribute(s session.Data, a entity.Album) bool {
+	if s.Invalid() || s.Guest() {
+		return false
+	} else if CanModify(s, acl.ResourceAlbums, a.OwnerUID) {
+		return true
+	} else if m := entity.FindAlbumMember(a.AlbumUID, s.User.UserUID); m != nil {
+		return m.Contributor()
+	}
+
+	return false
+}
+
+// ModifiableSelection removes photos, albums, and labels from the selection that the session user may not change.
+func ModifiableSelection(s session.Data, f *form.Selection) (err error) {
+	if Restricted(s, acl.ResourcePhotos) && len(f.Photos) > 0 {
+		if f.Photos, err = query.OwnedPhotoUIDs(f.Photos, s.User.UserUID, Library(s, acl.ResourcePhotos)); err != nil {
+			return err
+		}
+	}
+
+	if Restricted(s, acl.ResourceAlbums) && len(f.Albums) > 0 {
+		if f.Albums, err = query.OwnedAlbumUIDs(f.Albums, s.User.UserUID, Library(s, acl.ResourceAlbums)); err != nil {
+			return err
+		}
+	}
+
+	if Restricted(s, acl.ResourceLabels) && len(f.Labels) > 0 {
+		if f.Labels, err = query.OwnedLabelUIDs(f.Labels, s.User.UserUID, Library(s, acl.ResourceLabels)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ViewableSelection replaces the selection with the photos in it that the session user may view,
+// so that files of other users' private pictures cannot be selected, e.g. by label or folder.
+func ViewableSelection(s session.Data, f *form.Selection) (err error) {
+	if !Restricted(s, acl.ResourcePhotos) || f.Empty() {
+		return nil
+	}
+
+	photos, err := query.SelectedPhotos(*f)
+
+	if err != nil {
+		return err
+	}
+
+	uids := make([]string, 0, len(photos))
+
+	for _, p := range photos {
+		uids = append(uids, p.PhotoUID)
+	}
+
+	if uids, err = query.ViewablePhotoUIDs(uids, SearchScope(s, acl.ResourcePhotos)); err != nil {
+		return err
+	}
+
+	*f = form.Selection{Photos: uids}
+
+	return nil
+}
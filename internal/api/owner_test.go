@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+func TestRestricted(t *testing.T) {
+	admin := session.Data{User: entity.UserFixtures.Get("alice")}
+	family := session.Data{User: entity.User{ID: 100, UserUID: "uqxc08w3d0ej2299", RoleFamily: true}}
+	guest := session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba9"}}
+
+	assert.False(t, Restricted(admin, acl.ResourcePhotos))
+	assert.True(t, Restricted(family, acl.ResourcePhotos))
+	assert.False(t, Restricted(guest, acl.ResourcePhotos))
+	assert.False(t, Restricted(session.Data{}, acl.ResourcePhotos))
+}
+
+func TestSearchScope(t *testing.T) {
+	t.Run("Admin", func(t *testing.T) {
+		s := session.Data{User: entity.UserFixtures.Get("alice")}
+		scope := SearchScope(s, acl.ResourcePhotos)
+		assert.True(t, scope.Empty())
+		assert.Equal(t, "uqxetse3cy5eo9z2", scope.Member)
+	})
+	t.Run("Family", func(t *testing.T) {
+		s := session.Data{User: entity.User{ID: 100, UserUID: "uqxc08w3d0ej2299", RoleFamily: true}}
+		scope := SearchScope(s, acl.ResourcePhotos)
+		assert.Equal(t, "uqxc08w3d0ej2299", scope.Owner)
+		assert.True(t, scope.Library)
+	})
+	t.Run("Friend", func(t *testing.T) {
+		s := session.Data{User: entity.User{ID: 100, UserUID: "uqxc08w3d0ej2299", RoleFriend: true}, Shares: session.UIDs{"at9lxuqxpogaaba8"}}
+		scope := SearchScope(s, acl.ResourceAlbums)
+		assert.Equal(t, "uqxc08w3d0ej2299", scope.Owner)
+		assert.Equal(t, []string{"at9lxuqxpogaaba8"}, scope.Shared)
+		assert.False(t, scope.Library)
+	})
+}
+
+func TestCanModify(t *testing.T) {
+	admin := session.Data{User: entity.UserFixtures.Get("alice")}
+	family := session.Data{User: entity.User{ID: 100, UserUID: "uqxc08w3d0ej2299", RoleFamily: true}}
+	friend := session.Data{User: entity.User{ID: 101, UserUID: "uqxc08w3d0ej2298", RoleFriend: true}}
+
+	assert.True(t, CanModify(admin, acl.ResourcePhotos, "uqxc08w3d0ej2283"))
+	assert.True(t, CanModify(family, acl.ResourcePhotos, "uqxc08w3d0ej2299"))
+	assert.True(t, CanModify(family, acl.ResourcePhotos, ""))
+	assert.False(t, CanModify(family, acl.ResourcePhotos, "uqxc08w3d0ej2283"))
+	assert.True(t, CanModify(friend, acl.ResourcePhotos, "uqxc08w3d0ej2298"))
+	assert.False(t, CanModify(friend, acl.ResourcePhotos, ""))
+}
+
+func TestCanViewAlbum(t *testing.T) {
+	album := entity.AlbumFixtures.Get("berlin-2019")
+	friend := session.Data{User: entity.User{ID: 101, UserUID: "uqxc08w3d0ej2298", RoleFriend: true}}
+	family := session.Data{User: entity.User{ID: 100, UserUID: "uqxc08w3d0ej2299", RoleFamily: true}}
+	bob := session.Data{User: entity.User{ID: 7, UserUID: "uqxc08w3d0ej2283", RoleFriend: true}}
+
+	assert.False(t, CanViewAlbum(friend, album))
+	assert.True(t, CanViewAlbum(family, album))
+	assert.True(t, CanViewAlbum(bob, album))
+
+	friend.Shares = session.UIDs{album.AlbumUID}
+
+	assert.True(t, CanViewAlbum(friend, album))
+}
+
+func TestCanContribute(t *testing.T) {
+	bob := session.Data{User: entity.User{ID: 7, UserUID: "uqxc08w3d0ej2283", RoleFriend: true}}
+	friend := session.Data{User: entity.User{ID: 101, UserUID: "uqxc08w3d0ej2298", RoleFriend: true}}
+	guest := session.Data{User: entity.Guest, Shares: session.UIDs{"at9lxuqxpogaaba8"}}
+
+	assert.True(t, CanContribute(bob, entity.AlbumFixtures.Get("berlin-2019")))
+	assert.True(t, CanContribute(bob, entity.AlbumFixtures.Get("holiday-2030")))
+	assert.False(t, CanContribute(bob, entity.AlbumFixtures.Get("christmas2030")))
+	assert.False(t, CanContribute(friend, entity.AlbumFixtures.Get("holiday-2030")))
+	assert.False(t, CanContribute(guest, entity.AlbumFixtures.Get("holiday-2030")))
+}
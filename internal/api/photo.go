@@ -3,8 +3,10 @@ package api
 import (
 	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -55,7 +57,7 @@ func GetPhoto(router *gin.RouterGroup) {
 
 		p, err := query.PhotoPreloadByUID(sanitize.IdString(c.Param("uid")))
 
-		if err != nil {
+		if err != nil || !CanViewPhoto(s, p) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -82,6 +84,9 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !CanModify(s, acl.ResourcePhotos, m.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		// TODO: Proof-of-concept for form handling - might need refactoring
@@ -133,12 +138,15 @@ func UpdatePhoto(router *gin.RouterGroup) {
 // - uid (string) PhotoUID as returned by the API
 func GetPhotoDownload(router *gin.RouterGroup) {
 	router.GET("/photos/:uid/dl", func(c *gin.Context) {
-		if InvalidDownloadToken(c) {
+		uid := sanitize.IdString(c.Param("uid"))
+		link, ok := DownloadLink(c, uid)
+
+		if !ok {
 			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
 			return
 		}
 
-		f, err := query.FileByPhotoUID(sanitize.IdString(c.Param("uid")))
+		f, err := query.FileByPhotoUID(uid)
 
 		if err != nil {
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
@@ -157,7 +165,11 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
+		downloadName := f.DownloadName(DownloadName(c), 0)
+
+		c.FileAttachment(fileName, downloadName)
+
+		RedeemDownload(c, link, downloadName)
 	})
 }
 
@@ -217,6 +229,9 @@ func ApprovePhoto(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !CanModify(s, acl.ResourcePhotos, m.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		if err := m.Approve(); err != nil {
@@ -250,7 +265,7 @@ func LikePhoto(router *gin.RouterGroup) {
 		id := sanitize.IdString(c.Param("uid"))
 		m, err := query.PhotoByUID(id)
 
-		if err != nil {
+		if err != nil || !CanViewPhoto(s, m) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -286,7 +301,7 @@ func DislikePhoto(router *gin.RouterGroup) {
 		id := sanitize.IdString(c.Param("uid"))
 		m, err := query.PhotoByUID(id)
 
-		if err != nil {
+		if err != nil || !CanViewPhoto(s, m) {
 			AbortEntityNotFound(c)
 			return
 		}
@@ -322,6 +337,15 @@ func PhotoPrimary(router *gin.RouterGroup) {
 
 		uid := sanitize.IdString(c.Param("uid"))
 		fileUID := sanitize.IdString(c.Param("file_uid"))
+
+		if m, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		} else if !CanModify(s, acl.ResourcePhotos, m.OwnerUID) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		err := query.SetPhotoPrimary(uid, fileUID)
 
 		if err != nil {
@@ -344,32 +368,75 @@ func PhotoPrimary(router *gin.RouterGroup) {
 	})
 }
 
+// GET /api/v1/database/sync.db
+//
+// Returns a SQLite database with the photo, file, album and label tables for clients.
+// The "X-Sync-Time" response header contains the Unix timestamp to pass as "since"
+// with the next request, so that only rows changed since the last sync are returned.
+//
+// Parameters:
+//
+//	since: string Unix timestamp or RFC 3339 time of the last sync (optional)
 func PhotoSync(router *gin.RouterGroup) {
 	router.GET("/database/sync.db", func(c *gin.Context) {
 		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
 
-		if s.Invalid() {
+		// The database contains the whole library, so users must be able to manage it.
+		if s.Invalid() || Restricted(s, acl.ResourcePhotos) {
 			AbortUnauthorized(c)
 			return
 		}
 
-		file := filepath.Join(os.TempDir(), "up.db")
+		since, err := ParseSyncTime(c.Query("since"))
 
-		cfg := service.Config()
-		cmd := exec.Command(cfg.ExportCommand(), file)
-		if err := cmd.Run(); err != nil {
-			c.AbortWithStatus(http.StatusInternalServerError)
+		if err != nil {
+			AbortBadRequest(c)
 			return
 		}
-		if !fs.FileExists(file) {
-			AbortEntityNotFound(c)
+
+		info, err := photoprism.NewSnapshot(service.Config()).Create(since)
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			AbortUnexpected(c)
 			return
 		}
 
+		defer func() {
+			if err := os.Remove(info.FileName); err != nil {
+				log.Warnf("sync: %s", err)
+			}
+		}()
+
+		if info.Delta() {
+			log.Debugf("sync: created delta since %s with %d photos", info.Since.Format(time.RFC3339), info.Rows[entity.Photo{}.TableName()])
+		}
+
+		c.Header("X-Sync-Time", strconv.FormatInt(info.CreatedAt.Unix(), 10))
+
 		AddContentTypeHeader(c, ContentTypeBinary)
 
 		AddDownloadHeader(c, "photos.db")
 
-		c.File(file)
+		c.File(info.FileName)
 	})
 }
+
+// ParseSyncTime parses a Unix timestamp or RFC 3339 time string, an empty string returns the zero time.
+func ParseSyncTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if sec <= 0 {
+			return time.Time{}, nil
+		}
+
+		return time.Unix(sec, 0).UTC(), nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
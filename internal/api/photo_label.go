@@ -35,6 +35,9 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !CanModify(s, acl.ResourcePhotos, m.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		var f form.Label
@@ -44,7 +47,14 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
-		labelEntity := entity.FirstOrCreateLabel(entity.NewLabel(f.LabelName, f.LabelPriority))
+		newLabel := entity.NewLabel(f.LabelName, f.LabelPriority)
+
+		// Labels created by users with restricted permissions belong to them.
+		if Restricted(s, acl.ResourceLabels) {
+			newLabel.SetOwner(s.User.UserUID)
+		}
+
+		labelEntity := entity.FirstOrCreateLabel(newLabel)
 
 		if labelEntity == nil {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed creating label"})
@@ -111,6 +121,9 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !CanModify(s, acl.ResourcePhotos, m.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(sanitize.Token(c.Param("id")))
@@ -178,6 +191,9 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 		if err != nil {
 			AbortEntityNotFound(c)
 			return
+		} else if !CanModify(s, acl.ResourcePhotos, m.OwnerUID) {
+			AbortUnauthorized(c)
+			return
 		}
 
 		labelId, err := strconv.Atoi(sanitize.Token(c.Param("id")))
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetPhotoSimilar returns near-duplicates and similar shots of a photo as JSON,
+// sorted by the Hamming distance of their perceptual hashes.
+//
+// GET /api/v1/photos/:uid/similar
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+//	dist: int Maximum Hamming distance (optional, default 12)
+//	count: int Maximum number of results (optional)
+func GetPhotoSimilar(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/similar", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if p, err := query.PhotoByUID(uid); err != nil || !CanViewPhoto(s, p) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		results, err := search.Similar(uid, txt.Int(c.Query("dist")), txt.Int(c.Query("count")))
+
+		if err != nil {
+			log.Errorf("photo: %s (find similar)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		// Remove pictures the user may not see.
+		if scope := SearchScope(s, acl.ResourcePhotos); !scope.Empty() && len(results) > 0 {
+			results = similarVisible(results, scope)
+		}
+
+		AddCountHeader(c, len(results))
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// similarVisible returns the similar photos that match the search scope.
+func similarVisible(results search.SimilarPhotos, scope form.SearchScope) search.SimilarPhotos {
+	uids := make([]string, len(results))
+
+	for i := range results {
+		uids[i] = results[i].PhotoUID
+	}
+
+	visible, _, err := search.Photos(form.SearchPhotos{UID: strings.Join(uids, txt.Or), Count: len(uids), Merged: true, Scope: scope})
+
+	if err != nil {
+		log.Errorf("photo: %s (find similar)", err)
+		return search.SimilarPhotos{}
+	}
+
+	allowed := make(map[string]bool, len(visible))
+
+	for _, p := range visible {
+		allowed[p.PhotoUID] = true
+	}
+
+	filtered := make(search.SimilarPhotos, 0, len(results))
+
+	for _, r := range results {
+		if allowed[r.PhotoUID] {
+			filtered = append(filtered, r)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoSimilar(t *testing.T) {
+	t.Run("existing photo", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/similar")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "pt9jtdre2lvl0y11", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, int64(3), gjson.Get(r.Body.String(), "0.Distance").Int())
+		assert.True(t, gjson.Get(r.Body.String(), "0.Duplicate").Bool())
+	})
+	t.Run("max distance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/similar?dist=2")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("not existing photo", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoSimilar(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/similar")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
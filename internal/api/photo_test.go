@@ -2,7 +2,9 @@ package api
 
 import (
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/stretchr/testify/assert"
@@ -182,3 +184,49 @@ func TestApprovePhoto(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestPhotoSync(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoSync(router)
+		r := PerformRequest(app, "GET", "/api/v1/database/sync.db")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, r.Header().Get("X-Sync-Time"))
+		assert.True(t, strings.HasPrefix(r.Body.String(), "SQLite format 3"))
+	})
+	t.Run("delta", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoSync(router)
+		r := PerformRequest(app, "GET", "/api/v1/database/sync.db?since=1600000000")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, r.Header().Get("X-Sync-Time"))
+	})
+	t.Run("invalid since", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PhotoSync(router)
+		r := PerformRequest(app, "GET", "/api/v1/database/sync.db?since=yesterday")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestParseSyncTime(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		result, err := ParseSyncTime("")
+		assert.NoError(t, err)
+		assert.True(t, result.IsZero())
+	})
+	t.Run("unix", func(t *testing.T) {
+		result, err := ParseSyncTime("1600000000")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Unix(1600000000, 0).UTC(), result)
+	})
+	t.Run("rfc3339", func(t *testing.T) {
+		result, err := ParseSyncTime("2020-09-13T12:26:40Z")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1600000000), result.Unix())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseSyncTime("yesterday")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetPhotoText returns the text regions found in a photo as JSON.
+//
+// GET /api/v1/photos/:uid/text
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+func GetPhotoText(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/text", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := sanitize.IdString(c.Param("uid"))
+
+		if p, err := query.PhotoByUID(uid); err != nil || !CanViewPhoto(s, p) {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		texts, err := query.PhotoTextsByUID(uid)
+
+		if err != nil {
+			log.Errorf("photo: %s (find text)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, texts)
+	})
+}
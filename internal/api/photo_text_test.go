@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoText(t *testing.T) {
+	t.Run("existing photo", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoText(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/text")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "Welcome to Berlin", gjson.Get(r.Body.String(), "0.Text").String())
+	})
+	t.Run("private photo of other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetPhotoText(router)
+		sessId := AuthenticateUser(app, router, "friend", "!Friend321")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y12/text", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("not existing photo", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoText(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/text")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
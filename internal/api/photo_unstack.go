@@ -74,6 +74,12 @@ func PhotoUnstack(router *gin.RouterGroup) {
 		}
 
 		stackPhoto := *file.Photo
+
+		if !CanModify(s, acl.ResourcePhotos, stackPhoto.OwnerUID) {
+			AbortUnauthorized(c)
+			return
+		}
+
 		stackPrimary, err := stackPhoto.PrimaryFile()
 
 		if err != nil {
@@ -146,7 +152,7 @@ func PhotoUnstack(router *gin.RouterGroup) {
 			}
 
 			if err := entity.UnscopedDb().Exec(`UPDATE files 
-				SET photo_id = ?, photo_uid = ?, file_name = ?, file_missing = 0
+				SET photo_id = ?, photo_uid = ?, file_name = ?, file_missing = FALSE
 				WHERE file_name = ? AND file_root = ?`,
 				newPhoto.ID, newPhoto.PhotoUID, r.RootRelName(),
 				relName, relRoot).Error; err != nil {
@@ -171,9 +177,11 @@ func PhotoUnstack(router *gin.RouterGroup) {
 		}
 
 		ind := service.Index()
+		indOpt := photoprism.IndexOptionsSingle()
+		indOpt.OwnerUID = stackPhoto.OwnerUID
 
 		// Index unstacked files.
-		if res := ind.FileName(unstackFile.FileName(), photoprism.IndexOptionsSingle()); res.Failed() {
+		if res := ind.FileName(unstackFile.FileName(), indOpt); res.Failed() {
 			log.Errorf("photo: %s (unstack %s)", res.Err, sanitize.Log(baseName))
 			AbortSaveFailed(c)
 			return
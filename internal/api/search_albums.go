@@ -38,6 +38,9 @@ func SearchAlbums(router *gin.RouterGroup) {
 			f.UID = s.Shares.Join(txt.Or)
 		}
 
+		// Users may only see their own and shared albums unless they can manage the library.
+		f.Scope = SearchScope(s, acl.ResourceAlbums)
+
 		result, err := search.Albums(f)
 
 		if err != nil {
@@ -75,6 +78,8 @@ func SearchAlbumsSlim(router *gin.RouterGroup) {
 			return
 		}
 
+		f.Scope = SearchScope(s, acl.ResourceAlbums)
+
 		result, err := search.AlbumsSlim(f)
 
 		if err != nil {
@@ -51,6 +51,9 @@ func SearchGeo(router *gin.RouterGroup) {
 			f.Review = false
 		}
 
+		// Users may only see their own and shared pictures unless they can manage the library.
+		f.Scope = SearchScope(s, acl.ResourcePhotos)
+
 		// Find matching pictures.
 		photos, err := search.Geo(f)
 
@@ -32,6 +32,8 @@ func SearchLabels(router *gin.RouterGroup) {
 			return
 		}
 
+		f.Scope = SearchScope(s, acl.ResourceLabels)
+
 		result, err := search.Labels(f)
 
 		if err != nil {
@@ -51,6 +51,9 @@ func SearchPhotos(router *gin.RouterGroup) {
 			f.Review = false
 		}
 
+		// Users may only see their own and shared pictures unless they can manage the library.
+		f.Scope = SearchScope(s, acl.ResourcePhotos)
+
 		return f, nil
 	}
 
@@ -143,6 +146,8 @@ func SearchPhotosSlim(router *gin.RouterGroup) {
 			return
 		}
 
+		f.Scope = SearchScope(s, acl.ResourcePhotos)
+
 		result, count, err := search.PhotosSlim(f)
 
 		if err != nil {
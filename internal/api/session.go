@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/photoprism/photoprism/pkg/sanitize"
 
@@ -14,12 +15,36 @@ import (
 	"github.com/photoprism/photoprism/internal/session"
 )
 
+// LoginFormKey is the context key of the login form, so that the request body is only parsed once.
+const LoginFormKey = "login_form"
+
+// BindLogin returns the login form sent with the request. Middleware such as the rate limiter
+// must use it instead of parsing the request body, so that it sees the same values as the handler.
+func BindLogin(c *gin.Context) (f form.Login, err error) {
+	if v, ok := c.Get(LoginFormKey); ok {
+		if f, ok = v.(form.Login); ok {
+			return f, nil
+		}
+	}
+
+	if err = c.ShouldBindJSON(&f); err != nil {
+		return f, err
+	}
+
+	c.Set(LoginFormKey, f)
+
+	return f, nil
+}
+
+// CreateSession creates a new client session and returns it as JSON if authentication was successful.
+// Users with two-factor authentication enabled must send a passcode along with their credentials.
+//
 // POST /api/v1/session
 func CreateSession(router *gin.RouterGroup) {
 	router.POST("/session", func(c *gin.Context) {
-		var f form.Login
+		f, err := BindLogin(c)
 
-		if err := c.BindJSON(&f); err != nil {
+		if err != nil {
 			AbortBadRequest(c)
 			return
 		}
@@ -28,11 +53,16 @@ func CreateSession(router *gin.RouterGroup) {
 
 		id := SessionID(c)
 
-		if s := Session(id); s.Valid() {
-			data = s
-		} else {
+		if s := Session(id); s.Invalid() {
 			data = session.Data{}
 			id = ""
+		} else {
+			data = s
+
+			// Create a new session if the request was authenticated otherwise, e.g. with an API token.
+			if !service.Session().Exists(id) {
+				id = ""
+			}
 		}
 
 		conf := service.Config()
@@ -41,16 +71,33 @@ func CreateSession(router *gin.RouterGroup) {
 			links := entity.FindValidLinks(f.Token, "")
 
 			if len(links) == 0 {
+				AuthFailed(c)
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidLink)})
+				return
 			}
 
-			data.Tokens = []string{f.Token}
+			redeemed := 0
 
 			for _, link := range links {
+				// Password-protected links require the password in addition to the token.
+				if link.InvalidPassword(f.Password) {
+					continue
+				}
+
 				data.Shares = append(data.Shares, link.ShareUID)
 				link.Redeem()
+				link.AddAccess(entity.LinkAccessView, "", c.ClientIP(), c.Request.UserAgent())
+				redeemed++
 			}
 
+			if redeemed == 0 {
+				AuthFailed(c)
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrInvalidPassword), "password": true})
+				return
+			}
+
+			data.Tokens = []string{f.Token}
+
 			// Upgrade from anonymous to guest. Don't downgrade.
 			if data.User.Anonymous() {
 				data.User = entity.Guest
@@ -59,29 +106,49 @@ func CreateSession(router *gin.RouterGroup) {
 			user := entity.FindUserByName(f.UserName)
 
 			if user == nil {
+				AuthFailed(c)
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
 				return
 			}
 
 			if user.InvalidPassword(f.Password) {
+				AuthFailed(c)
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
 				return
 			}
 
+			// Users with two-factor authentication must also enter a valid passcode or recovery code.
+			if user.TwoFactorEnabled() {
+				if !f.HasPasscode() {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": i18n.Msg(i18n.ErrPasscodeRequired), "passcode": true})
+					return
+				} else if user.InvalidPasscode(f.Passcode) {
+					AuthFailed(c)
+					c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidPasscode), "passcode": true})
+					return
+				}
+			}
+
 			data.User = *user
 		} else {
 			c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidPassword)})
 			return
 		}
 
-		if err := service.Session().Update(id, data); err != nil {
-			id = service.Session().Create(data)
+		if id == "" {
+			id = service.Session().Create(data, SessionClient(c))
+		} else if err := service.Session().Update(id, data); err != nil {
+			log.Errorf("session: %s", err)
+			AbortUnexpected(c)
+			return
 		}
 
 		AddSessionHeader(c, id)
 
 		if data.User.Anonymous() {
 			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.GuestConfig()})
+		} else if data.Guest() {
+			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": ShareConfig(data.Tokens)})
 		} else {
 			c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id, "data": data, "config": conf.UserConfigFull(f.Counterless)})
 		}
@@ -99,9 +166,27 @@ func DeleteSession(router *gin.RouterGroup) {
 	})
 }
 
-// Gets session id from HTTP header.
+// Gets session id from HTTP header, or a personal API token passed as bearer token.
 func SessionID(c *gin.Context) string {
-	return c.GetHeader("X-Session-ID")
+	if id := c.GetHeader("X-Session-ID"); id != "" {
+		return id
+	}
+
+	return BearerToken(c)
+}
+
+// SessionClient returns the user agent and IP address of the client for storing them with a new session.
+func SessionClient(c *gin.Context) session.Client {
+	return session.Client{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
+
+// BearerToken returns the bearer token from the authorization header, if any.
+func BearerToken(c *gin.Context) string {
+	if data := c.GetHeader("Authorization"); strings.HasPrefix(data, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(data, "Bearer "))
+	}
+
+	return ""
 }
 
 // Session returns the current session data.
@@ -111,15 +196,39 @@ func Session(id string) session.Data {
 		return session.Data{User: entity.Admin}
 	}
 
+	// Personal API token?
+	if entity.IsUserToken(id) {
+		return TokenSession(id)
+	}
+
 	// Check if session id is valid.
 	return service.Session().Get(id)
 }
 
+// TokenSession returns the session data for a personal API token.
+func TokenSession(secret string) session.Data {
+	token := entity.FindUserToken(secret)
+
+	if token == nil {
+		return session.Data{}
+	}
+
+	user := token.User()
+
+	if user == nil {
+		return session.Data{}
+	}
+
+	token.Used()
+
+	return session.Data{User: *user, Scope: token.Scope()}
+}
+
 // Auth returns the session if user is authorized for the current action.
 func Auth(id string, resource acl.Resource, action acl.Action) session.Data {
 	sess := Session(id)
 
-	if acl.Permissions.Deny(resource, sess.User.Role(), action) {
+	if acl.Permissions.Deny(resource, sess.User.Role(), action) || !sess.Allow(action) {
 		return session.Data{}
 	}
 
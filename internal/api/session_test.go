@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
@@ -101,3 +102,41 @@ func TestDeleteSession(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestCreateSession_ProtectedLink(t *testing.T) {
+	link := entity.NewLink("at9lxuqxpogaaba7", false, false)
+
+	if err := link.SetPassword("Secret123"); err != nil {
+		t.Fatal(err)
+	} else if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	t.Run("password missing", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		CreateSession(router)
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"token": "`+link.LinkToken+`"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "password").Bool())
+	})
+	t.Run("valid password", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		CreateSession(router)
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"token": "`+link.LinkToken+`", "password": "Secret123"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba7", gjson.Get(r.Body.String(), "data.shares.0").String())
+		assert.Equal(t, link.LinkToken, gjson.Get(r.Body.String(), "config.downloadToken").String())
+
+		if access, err := link.Access(10); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Len(t, access, 1)
+		}
+	})
+}
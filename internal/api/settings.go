@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/service"
@@ -46,6 +47,7 @@ func SaveSettings(router *gin.RouterGroup) {
 		}
 
 		settings := conf.Settings()
+		before := *settings
 
 		if err := c.BindJSON(settings); err != nil {
 			AbortBadRequest(c)
@@ -57,6 +59,10 @@ func SaveSettings(router *gin.RouterGroup) {
 			return
 		}
 
+		if diff := entity.AuditDiff(before, settings); diff != "" {
+			Audit(c, s, entity.AuditSettingsUpdate, diff, "settings")
+		}
+
 		UpdateClientConfig()
 
 		event.InfoMsg(i18n.MsgSettingsSaved)
@@ -6,36 +6,56 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
+// ShareConfig returns the client config for guests with the specified share tokens. A link token
+// replaces the regular download token, so that originals can only be downloaded if a link allows it.
+func ShareConfig(tokens []string) config.ClientConfig {
+	conf := service.Config()
+	result := conf.GuestConfig()
+
+	result.DownloadToken = ""
+	result.Settings.Features.Download = false
+
+	for _, token := range tokens {
+		for _, link := range entity.FindValidLinks(token, "") {
+			if link.Downloadable() {
+				result.DownloadToken = token
+				result.Settings.Features.Download = conf.Settings().Features.Download
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
 // GET /s/:token/...
 func Shares(router *gin.RouterGroup) {
 	router.GET("/:token", func(c *gin.Context) {
-		conf := service.Config()
-
 		token := sanitize.Token(c.Param("token"))
 
 		links := entity.FindValidLinks(token, "")
 
 		if len(links) == 0 {
 			log.Warn("share: invalid token")
+			AuthFailed(c)
 			c.Redirect(http.StatusTemporaryRedirect, "/")
 			return
 		}
 
-		clientConfig := conf.GuestConfig()
+		clientConfig := ShareConfig([]string{token})
 		clientConfig.SiteUrl = fmt.Sprintf("%ss/%s", clientConfig.SiteUrl, token)
 
 		c.HTML(http.StatusOK, "share.tmpl", gin.H{"config": clientConfig})
 	})
 
 	router.GET("/:token/:share", func(c *gin.Context) {
-		conf := service.Config()
-
 		token := sanitize.Token(c.Param("token"))
 		share := sanitize.Token(c.Param("share"))
 
@@ -43,12 +63,13 @@ func Shares(router *gin.RouterGroup) {
 
 		if len(links) < 1 {
 			log.Warn("share: invalid token or share")
+			AuthFailed(c)
 			c.Redirect(http.StatusTemporaryRedirect, "/")
 			return
 		}
 
 		uid := links[0].ShareUID
-		clientConfig := conf.GuestConfig()
+		clientConfig := ShareConfig([]string{token})
 
 		if uid != share {
 			c.Redirect(http.StatusPermanentRedirect, fmt.Sprintf("%ss/%s/%s", clientConfig.SiteUrl, token, uid))
@@ -36,6 +36,7 @@ func SharePreview(router *gin.RouterGroup) {
 
 		if len(links) != 1 {
 			log.Warn("share: invalid token (preview)")
+			AuthFailed(c)
 			c.Redirect(http.StatusTemporaryRedirect, conf.SitePreview())
 			return
 		}
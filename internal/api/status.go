@@ -6,9 +6,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// GetStatus reports whether the server is operational and the number of clients that are
+// currently locked out after too many failed login attempts.
+//
 // GET /api/v1/status
-func GetStatus(router *gin.RouterGroup) {
+func GetStatus(router *gin.RouterGroup, lockouts func() int) {
 	router.GET("/status", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "operational"})
+		result := gin.H{"status": "operational"}
+
+		if lockouts != nil {
+			result["lockouts"] = lockouts()
+		}
+
+		c.JSON(http.StatusOK, result)
 	})
 }
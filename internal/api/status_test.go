@@ -11,10 +11,11 @@ import (
 func TestGetStatus(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
-		GetStatus(router)
+		GetStatus(router, func() int { return 2 })
 		r := PerformRequest(app, "GET", "/api/v1/status")
 		val := gjson.Get(r.Body.String(), "status")
 		assert.Equal(t, "operational", val.String())
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "lockouts").Int())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path"
@@ -9,12 +10,18 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
+// Upload stores uploaded files in a subfolder of the import path. If the "album" query parameter
+// contains the UID of an album the user may contribute to, the files are imported into it right away.
+//
 // POST /api/v1/upload/:path
 func Upload(router *gin.RouterGroup) {
 	router.POST("/upload/:path", func(c *gin.Context) {
@@ -31,6 +38,21 @@ func Upload(router *gin.RouterGroup) {
 			return
 		}
 
+		// Upload straight into an album?
+		var album *entity.Album
+
+		if albumUID := sanitize.IdString(c.Query("album")); albumUID != "" {
+			if a, err := query.AlbumByUID(albumUID); err != nil {
+				Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+				return
+			} else if !CanContribute(s, a) {
+				AbortUnauthorized(c)
+				return
+			} else {
+				album = &a
+			}
+		}
+
 		start := time.Now()
 		subPath := sanitize.Path(c.Param("path"))
 
@@ -46,7 +68,6 @@ func Upload(router *gin.RouterGroup) {
 
 		files := f.File["files"]
 		uploaded := len(files)
-		var uploads []string
 
 		p := path.Join(conf.ImportPath(), "upload", subPath)
 
@@ -56,60 +77,91 @@ func Upload(router *gin.RouterGroup) {
 			return
 		}
 
-		for _, file := range files {
-			filename := path.Join(p, filepath.Base(file.Filename))
+		if _, ok := saveUploads(c, files, p); !ok {
+			return
+		}
 
-			log.Debugf("upload: saving file %s", sanitize.Log(file.Filename))
+		// Import uploaded files and add them to the album.
+		if album != nil {
+			opt := photoprism.ImportOptionsMove(p)
+			opt.Albums = []string{album.AlbumUID}
+			opt.OwnerUID = s.User.UserUID
 
-			if err := c.SaveUploadedFile(file, filename); err != nil {
-				log.Errorf("upload: failed saving file %s", sanitize.Log(filepath.Base(file.Filename)))
-				AbortBadRequest(c)
-				return
-			}
+			importUploads(c, opt)
+		}
+
+		elapsed := int(time.Since(start).Seconds())
+
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, uploaded, elapsed)
+
+		log.Info(msg)
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
+
+// saveUploads stores uploaded files in the specified folder. Files that might be offensive are removed
+// again unless this is allowed. The request is aborted with an error if saving fails.
+func saveUploads(c *gin.Context, files []*multipart.FileHeader, dir string) (uploads []string, ok bool) {
+	for _, file := range files {
+		filename := path.Join(dir, filepath.Base(file.Filename))
+
+		log.Debugf("upload: saving file %s", sanitize.Log(file.Filename))
 
-			uploads = append(uploads, filename)
+		if err := c.SaveUploadedFile(file, filename); err != nil {
+			log.Errorf("upload: failed saving file %s", sanitize.Log(filepath.Base(file.Filename)))
+			AbortBadRequest(c)
+			return uploads, false
 		}
 
-		if !conf.UploadNSFW() {
-			nd := service.NsfwDetector()
+		uploads = append(uploads, filename)
+	}
 
-			containsNSFW := false
+	if service.Config().UploadNSFW() {
+		return uploads, true
+	}
 
-			for _, filename := range uploads {
-				labels, err := nd.File(filename)
+	nd := service.NsfwDetector()
 
-				if err != nil {
-					log.Debug(err)
-					continue
-				}
+	containsNSFW := false
 
-				if labels.IsSafe() {
-					continue
-				}
+	for _, filename := range uploads {
+		labels, err := nd.File(filename)
 
-				log.Infof("nsfw: %s might be offensive", sanitize.Log(filename))
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
 
-				containsNSFW = true
-			}
+		if labels.IsSafe() {
+			continue
+		}
 
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", sanitize.Log(filename))
-					}
-				}
+		log.Infof("nsfw: %s might be offensive", sanitize.Log(filename))
 
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
+		containsNSFW = true
+	}
+
+	if containsNSFW {
+		for _, filename := range uploads {
+			if err := os.Remove(filename); err != nil {
+				log.Errorf("nsfw: could not delete %s", sanitize.Log(filename))
 			}
 		}
 
-		elapsed := int(time.Since(start).Seconds())
+		Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+		return uploads, false
+	}
 
-		msg := i18n.Msg(i18n.MsgFilesUploadedIn, uploaded, elapsed)
+	return uploads, true
+}
 
-		log.Info(msg)
+// importUploads imports uploaded files and notifies clients of album changes.
+func importUploads(c *gin.Context, opt photoprism.ImportOptions) {
+	service.Import().Start(opt)
 
-		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
-	})
+	for _, albumUID := range opt.Albums {
+		RemoveFromAlbumCoverCache(albumUID)
+		PublishAlbumEvent(EntityUpdated, albumUID, c)
+	}
 }
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// uploadLinkOverhead is the number of bytes allowed in addition to the remaining upload quota,
+// as multipart requests also contain boundaries and part headers.
+const uploadLinkOverhead = 64 * 1024
+
+// GetUploadLink returns the album title and remaining quota of an upload link, without granting
+// access to any photos.
+//
+// GET /api/v1/dropbox/:token
+func GetUploadLink(router *gin.RouterGroup) {
+	router.GET("/dropbox/:token", func(c *gin.Context) {
+		link := entity.FindUploadLink(sanitize.Token(c.Param("token")))
+
+		if link == nil {
+			AuthFailed(c)
+			Abort(c, http.StatusNotFound, i18n.ErrInvalidLink)
+			return
+		}
+
+		a, err := query.AlbumByUID(link.ShareUID)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"Title":         a.AlbumTitle,
+			"Description":   a.AlbumDescription,
+			"Uploads":       link.LinkUploads,
+			"MaxUploads":    link.MaxUploads,
+			"UploadSize":    link.UploadSize,
+			"MaxUploadSize": link.MaxUploadSize,
+			"UploadTypes":   link.UploadTypes,
+			"Moderate":      link.Moderate,
+		})
+	})
+}
+
+// UploadWithLink lets guests without an account upload files into the album of an upload link.
+// If the link requires moderation, the new photos remain in review until they are approved.
+//
+// POST /api/v1/dropbox/:token
+func UploadWithLink(router *gin.RouterGroup) {
+	router.POST("/dropbox/:token", func(c *gin.Context) {
+		conf := service.Config()
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		link := entity.FindUploadLink(sanitize.Token(c.Param("token")))
+
+		if link == nil {
+			AuthFailed(c)
+			Abort(c, http.StatusNotFound, i18n.ErrInvalidLink)
+			return
+		}
+
+		a, err := query.AlbumByUID(link.ShareUID)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		start := time.Now()
+
+		if link.UploadQuotaExceeded(1, 0) {
+			log.Infof("upload: quota of link %s exceeded", link.String())
+			Abort(c, http.StatusForbidden, i18n.ErrUploadQuota)
+			return
+		}
+
+		// Don't read more than the remaining upload quota, plus some room for the multipart headers.
+		if left := link.UploadSizeLeft(); left >= 0 {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, left+uploadLinkOverhead)
+		}
+
+		f, err := c.MultipartForm()
+
+		if tooLarge := new(http.MaxBytesError); errors.As(err, &tooLarge) {
+			log.Infof("upload: quota of link %s exceeded", link.String())
+			Abort(c, http.StatusRequestEntityTooLarge, i18n.ErrUploadQuota)
+			return
+		} else if err != nil {
+			log.Errorf("upload: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		files := f.File["files"]
+		uploaded := len(files)
+
+		if uploaded == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		var size int64
+
+		for _, file := range files {
+			if !link.AllowsUploadType(file.Filename) {
+				log.Infof("upload: %s has an unsupported type", sanitize.Log(filepath.Base(file.Filename)))
+				Abort(c, http.StatusUnsupportedMediaType, i18n.ErrUnsupportedType)
+				return
+			}
+
+			size += file.Size
+		}
+
+		// Reserve quota before saving the files, so that concurrent uploads cannot exceed it.
+		if !link.ReserveUpload(uploaded, size) {
+			log.Infof("upload: quota of link %s exceeded", link.String())
+			Abort(c, http.StatusForbidden, i18n.ErrUploadQuota)
+			return
+		}
+
+		event.Publish("upload.start", event.Data{"time": start})
+
+		p := path.Join(conf.ImportPath(), "upload", link.LinkUID, rnd.Token(8))
+
+		if err := os.MkdirAll(p, os.ModePerm); err != nil {
+			log.Errorf("upload: failed creating folder for link %s", link.String())
+			link.ReleaseUpload(uploaded, size)
+			AbortBadRequest(c)
+			return
+		}
+
+		if _, ok := saveUploads(c, files, p); !ok {
+			link.ReleaseUpload(uploaded, size)
+			return
+		}
+
+		for _, file := range files {
+			link.AddAccess(entity.LinkAccessUpload, filepath.Base(file.Filename), c.ClientIP(), c.Request.UserAgent())
+		}
+
+		// Import uploaded files, they are added to the album by the link, see entity.AddLinkUpload.
+		opt := photoprism.ImportOptionsMove(p)
+		opt.OwnerUID = a.OwnerUID
+		opt.LinkUID = link.LinkUID
+
+		importUploads(c, opt)
+
+		elapsed := int(time.Since(start).Seconds())
+
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, uploaded, elapsed)
+
+		log.Info(msg)
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// uploadFiles performs a multipart upload request with small test files of the specified names.
+func uploadFiles(r http.Handler, path string, names ...string) *httptest.ResponseRecorder {
+	return uploadData(r, path, []byte("test"), names...)
+}
+
+// uploadData performs a multipart upload request with files of the specified names and content.
+func uploadData(r http.Handler, path string, data []byte, names ...string) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	for _, name := range names {
+		if part, err := w.CreateFormFile("files", name); err == nil {
+			_, _ = part.Write(data)
+		}
+	}
+
+	_ = w.Close()
+
+	req, _ := http.NewRequest("POST", path, body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestGetUploadLink(t *testing.T) {
+	link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+	link.LinkType = entity.LinkTypeUpload
+	link.MaxUploads = 20
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	t.Run("success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUploadLink(router)
+		r := PerformRequest(app, "GET", "/api/v1/dropbox/"+link.LinkToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(20), gjson.Get(r.Body.String(), "MaxUploads").Int())
+		assert.True(t, gjson.Get(r.Body.String(), "Title").Exists())
+	})
+	t.Run("share link", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUploadLink(router)
+		r := PerformRequest(app, "GET", "/api/v1/dropbox/1jxf3jfn2k")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUploadWithLink(t *testing.T) {
+	link := entity.NewLink("at9lxuqxpogaaba8", false, false)
+	link.LinkType = entity.LinkTypeUpload
+	link.MaxUploads = 1
+	link.UploadTypes = "jpg"
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadWithLink(router)
+		r := uploadFiles(app, "/api/v1/dropbox/xxx", "party.jpg")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadWithLink(router)
+		r := uploadFiles(app, "/api/v1/dropbox/"+link.LinkToken, "party.png")
+		assert.Equal(t, http.StatusUnsupportedMediaType, r.Code)
+	})
+	t.Run("quota exceeded", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UploadWithLink(router)
+		r := uploadFiles(app, "/api/v1/dropbox/"+link.LinkToken, "party.jpg", "dance.jpg")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("size quota exceeded", func(t *testing.T) {
+		sized := entity.NewLink("at9lxuqxpogaaba8", false, false)
+		sized.LinkType = entity.LinkTypeUpload
+		sized.MaxUploadSize = 1000
+
+		if err := sized.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer sized.Delete()
+
+		app, router, _ := NewApiTest()
+		UploadWithLink(router)
+		r := uploadData(app, "/api/v1/dropbox/"+sized.LinkToken, make([]byte, 2*uploadLinkOverhead), "party.jpg")
+		assert.Equal(t, http.StatusRequestEntityTooLarge, r.Code)
+		r = uploadData(app, "/api/v1/dropbox/"+sized.LinkToken, make([]byte, 1001), "party.jpg")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+		assert.Equal(t, int64(0), entity.FindLink(sized.LinkUID).UploadSize)
+	})
+}
@@ -14,4 +14,10 @@ func TestUpload(t *testing.T) {
 		r := PerformRequest(app, "POST", "/api/v1/upload/xxx")
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		Upload(router)
+		r := PerformRequest(app, "POST", "/api/v1/upload/xxx?album=at9lxuqxpogaxxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
 }
@@ -60,6 +60,13 @@ func ChangePassword(router *gin.RouterGroup) {
 			return
 		}
 
+		// Log out all other devices.
+		if deleted, err := service.Session().RevokeUser(m.UserUID, SessionID(c)); err != nil {
+			log.Errorf("user: %s (revoke sessions)", err)
+		} else if deleted > 0 {
+			log.Infof("user: revoked %d sessions of %s", deleted, m.String())
+		}
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPasswordChanged))
 	})
 }
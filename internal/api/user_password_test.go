@@ -136,5 +136,23 @@ func TestChangeUserPasswords(t *testing.T) {
 			assert.Equal(t, http.StatusUnauthorized, r.Code)
 		}
 	})
+	t.Run("alice: restore password", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		ChangePassword(router)
+		sessId := AuthenticateUser(app, router, "alice", "aliceinwonderland")
 
+		f := form.ChangePassword{
+			OldPassword: "aliceinwonderland",
+			NewPassword: "Alice123!",
+		}
+		if pwStr, err := json.Marshal(f); err != nil {
+			log.Fatal(err)
+		} else {
+			r := AuthenticatedRequestWithBody(app, "PUT", "/api/v1/users/uqxetse3cy5eo9z2/password",
+				string(pwStr), sessId)
+			assert.Equal(t, http.StatusOK, r.Code)
+		}
+	})
 }
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// GetUserSessions returns the active sessions of a user as JSON, most recently used first.
+// The session used for the request is marked as current.
+//
+// GET /api/v1/users/:uid/sessions
+func GetUserSessions(router *gin.RouterGroup) {
+	router.GET("/users/:uid/sessions", func(c *gin.Context) {
+		m, _ := settingsUser(c)
+
+		if m == nil {
+			return
+		}
+
+		sessions, err := entity.FindUserSessions(m.UserUID)
+
+		if err != nil {
+			log.Errorf("user: %s (find sessions)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		current := entity.SessionHash(SessionID(c))
+
+		for i := range sessions {
+			sessions[i].Current = sessions[i].SessHash == current
+		}
+
+		c.JSON(http.StatusOK, sessions)
+	})
+}
+
+// RevokeUserSession logs out the device of a session, so that it can no longer be used.
+//
+// DELETE /api/v1/users/:uid/sessions/:sess
+func RevokeUserSession(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/sessions/:sess", func(c *gin.Context) {
+		m, _ := settingsUser(c)
+
+		if m == nil {
+			return
+		}
+
+		sessUID := sanitize.IdString(c.Param("sess"))
+
+		if err := service.Session().Revoke(m.UserUID, sessUID); err != nil {
+			log.Debugf("user: %s", err)
+			AbortEntityNotFound(c)
+			return
+		}
+
+		log.Infof("user: revoked session %s of %s", sanitize.Log(sessUID), m.String())
+
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "UID": sessUID})
+	})
+}
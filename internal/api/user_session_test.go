@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetUserSessions(t *testing.T) {
+	t.Run("public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserSessions(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/sessions")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("alice", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserSessions(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/sessions", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#(Current==true)#|#").Int())
+		assert.Equal(t, "uqxetse3cy5eo9z2", gjson.Get(r.Body.String(), "0.UserUID").String())
+	})
+	t.Run("bob: other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserSessions(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/sessions", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestRevokeUserSession(t *testing.T) {
+	t.Run("revoke other device", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserSessions(router)
+		RevokeUserSession(router)
+		otherId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxc08w3d0ej2283/sessions", otherId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		otherUID := gjson.Get(r.Body.String(), "#(Current==true).UID").String()
+		assert.NotEmpty(t, otherUID)
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxc08w3d0ej2283/sessions/"+otherUID, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, otherUID, gjson.Get(r.Body.String(), "UID").String())
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/users/uqxc08w3d0ej2283/sessions", otherId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/users/uqxc08w3d0ej2283/sessions", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		RevokeUserSession(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxc08w3d0ej2283/sessions/estr4jv2xvwrt9wx", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("bob: other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		RevokeUserSession(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxetse3cy5eo9z2/sessions/estr4jv2xvwrt9wx", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// settingsUser returns the user whose security settings, such as personal API tokens, may be managed in the current session.
+func settingsUser(c *gin.Context) (*entity.User, session.Data) {
+	conf := service.Config()
+
+	if conf.Public() || conf.DisableSettings() {
+		Abort(c, http.StatusForbidden, i18n.ErrPublic)
+		return nil, session.Data{}
+	}
+
+	s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionUpdateSelf)
+
+	if s.Invalid() {
+		AbortUnauthorized(c)
+		return nil, s
+	}
+
+	uid := sanitize.IdString(c.Param("uid"))
+	m := entity.FindUserByUID(uid)
+
+	if m == nil {
+		Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+		return nil, s
+	}
+
+	// Only admins may manage the settings of other users.
+	if s.User.UserUID != m.UserUID && !s.User.Admin() {
+		AbortUnauthorized(c)
+		return nil, s
+	}
+
+	return m, s
+}
+
+// GetUserTokens returns the personal API tokens of a user as JSON, without their secrets.
+//
+// GET /api/v1/users/:uid/tokens
+func GetUserTokens(router *gin.RouterGroup) {
+	router.GET("/users/:uid/tokens", func(c *gin.Context) {
+		m, _ := settingsUser(c)
+
+		if m == nil {
+			return
+		}
+
+		tokens, err := entity.FindUserTokens(m.UserUID)
+
+		if err != nil {
+			log.Errorf("user: %s (find tokens)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, tokens)
+	})
+}
+
+// CreateUserToken creates a new personal API token and returns it as JSON, including its secret.
+// The secret is not stored and can't be displayed again.
+//
+// POST /api/v1/users/:uid/tokens
+func CreateUserToken(router *gin.RouterGroup) {
+	router.POST("/users/:uid/tokens", func(c *gin.Context) {
+		m, _ := settingsUser(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.UserToken
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		scope := f.TokenScope()
+
+		if !scope.Valid() {
+			AbortBadRequest(c)
+			return
+		}
+
+		token := entity.NewUserToken(m.UserUID, f.Name, scope)
+
+		if token == nil {
+			AbortUnexpected(c)
+			return
+		} else if err := token.Create(); err != nil {
+			log.Errorf("user: %s (create token)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("user: created %s token %s for %s", token.TokenScope, sanitize.Log(token.TokenName), m.String())
+
+		c.JSON(http.StatusOK, token)
+	})
+}
+
+// RevokeUserToken revokes a personal API token, so that it can no longer be used.
+//
+// DELETE /api/v1/users/:uid/tokens/:token
+func RevokeUserToken(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/tokens/:token", func(c *gin.Context) {
+		m, _ := settingsUser(c)
+
+		if m == nil {
+			return
+		}
+
+		token := entity.FindUserTokenByUID(m.UserUID, sanitize.IdString(c.Param("token")))
+
+		if token == nil {
+			AbortEntityNotFound(c)
+			return
+		} else if err := token.Revoke(); err != nil {
+			log.Errorf("user: %s (revoke token)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		log.Infof("user: revoked token %s of %s", sanitize.Log(token.TokenName), m.String())
+
+		c.JSON(http.StatusOK, token)
+	})
+}
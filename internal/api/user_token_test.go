@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetUserTokens(t *testing.T) {
+	t.Run("public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserTokens(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/tokens")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("alice", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTokens(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/tokens", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(3), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "0.Secret").String())
+	})
+	t.Run("bob: other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTokens(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/tokens", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("bearer token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTokens(router)
+		r := TokenRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/tokens", entity.UserTokenFixtures.Get("alice_full").Secret)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("read-only token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTokens(router)
+		r := TokenRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/tokens", entity.UserTokenFixtures.Get("alice_read").Secret)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestCreateUserToken(t *testing.T) {
+	t.Run("create and revoke", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		CreateUserToken(router)
+		RevokeUserToken(router)
+		GetPhoto(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/tokens", `{"Name": "Photo Frame", "Scope": "read"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		secret := gjson.Get(r.Body.String(), "Secret").String()
+		assert.Equal(t, "read", gjson.Get(r.Body.String(), "Scope").String())
+		assert.True(t, entity.IsUserToken(secret))
+
+		r = TokenRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7", secret)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxetse3cy5eo9z2/tokens/"+uid, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = TokenRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7", secret)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("invalid scope", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		CreateUserToken(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/tokens", `{"Name": "Admin", "Scope": "admin"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRevokeUserToken(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		RevokeUserToken(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxetse3cy5eo9z2/tokens/kxxxxxxxxxxxxxxx", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
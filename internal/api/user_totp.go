@@ -0,0 +1,175 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// confirmTwoFactor tests if the request is confirmed with the password of the current user, or with a
+// passcode if two-factor authentication is already enabled for them, and aborts it otherwise.
+func confirmTwoFactor(c *gin.Context, s session.Data) bool {
+	var f form.Passcode
+
+	if err := c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return false
+	}
+
+	u := entity.FindUserByUID(s.User.UserUID)
+
+	if u == nil {
+		AbortUnauthorized(c)
+		return false
+	} else if f.Password != "" && !u.InvalidPassword(f.Password) {
+		return true
+	} else if f.Passcode != "" && u.TwoFactorEnabled() && !u.InvalidPasscode(f.Passcode) {
+		return true
+	}
+
+	Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
+
+	return false
+}
+
+// GetUserTwoFactor returns the two-factor authentication status of a user as JSON.
+//
+// GET /api/v1/users/:uid/2fa
+func GetUserTwoFactor(router *gin.RouterGroup) {
+	router.GET("/users/:uid/2fa", func(c *gin.Context) {
+		m, _ := settingsUser(c)
+
+		if m == nil {
+			return
+		}
+
+		codes, err := entity.FindRecoveryCodes(m.UserUID)
+
+		if err != nil {
+			log.Errorf("user: %s (find recovery codes)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"Enabled": m.TwoFactorEnabled(), "RecoveryCodes": len(codes)})
+	})
+}
+
+// EnrollUserTwoFactor creates a new TOTP secret and returns it as JSON along with a provisioning URI
+// that can be displayed as QR code. It must be verified with a passcode before it is used for logging in.
+// The request must be confirmed with the current password.
+//
+// POST /api/v1/users/:uid/2fa
+func EnrollUserTwoFactor(router *gin.RouterGroup) {
+	router.POST("/users/:uid/2fa", func(c *gin.Context) {
+		m, s := settingsUser(c)
+
+		if m == nil || !confirmTwoFactor(c, s) {
+			return
+		}
+
+		// Two-factor authentication must be disabled before a new secret can be created.
+		if m.TwoFactorEnabled() {
+			Abort(c, http.StatusConflict, i18n.ErrAlreadyExists, "Two-factor authentication")
+			return
+		}
+
+		t, err := entity.NewUserTotp(m.UserUID)
+
+		if err != nil {
+			log.Errorf("user: %s (create totp secret)", err)
+			AbortUnexpected(c)
+			return
+		} else if err = t.Save(); err != nil {
+			log.Errorf("user: %s (save totp secret)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("user: started two-factor authentication setup for %s", m.String())
+
+		c.JSON(http.StatusOK, gin.H{"Secret": t.Secret, "URI": t.URI(service.Config().SiteTitle(), m.UserName)})
+	})
+}
+
+// VerifyUserTwoFactor enables two-factor authentication if the passcode is valid and returns new
+// recovery codes as JSON. The recovery codes are only stored as hash and can't be displayed again.
+//
+// POST /api/v1/users/:uid/2fa/verify
+func VerifyUserTwoFactor(router *gin.RouterGroup) {
+	router.POST("/users/:uid/2fa/verify", func(c *gin.Context) {
+		m, s := settingsUser(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.Passcode
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		t := entity.FindUserTotp(m.UserUID)
+
+		if t == nil {
+			AbortEntityNotFound(c)
+			return
+		} else if t.Enabled() {
+			Abort(c, http.StatusConflict, i18n.ErrAlreadyExists, "Two-factor authentication")
+			return
+		} else if err := t.Verify(f.Passcode); err != nil {
+			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPasscode)
+			return
+		}
+
+		codes, err := entity.NewRecoveryCodes(m.UserUID)
+
+		if err != nil {
+			log.Errorf("user: %s (create recovery codes)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("user: enabled two-factor authentication for %s", m.String())
+
+		Audit(c, s, entity.AuditAuth2faEnable, entity.AuditDiff(gin.H{"Enabled": false}, gin.H{"Enabled": true}), m.UserUID)
+
+		c.JSON(http.StatusOK, gin.H{"Enabled": true, "RecoveryCodes": codes})
+	})
+}
+
+// DeleteUserTwoFactor disables two-factor authentication and removes the TOTP secret and recovery codes.
+// The request must be confirmed with the current password or a passcode.
+//
+// DELETE /api/v1/users/:uid/2fa
+func DeleteUserTwoFactor(router *gin.RouterGroup) {
+	router.DELETE("/users/:uid/2fa", func(c *gin.Context) {
+		m, s := settingsUser(c)
+
+		if m == nil || !confirmTwoFactor(c, s) {
+			return
+		}
+
+		enabled := m.TwoFactorEnabled()
+
+		if err := m.ResetTwoFactor(); err != nil {
+			log.Errorf("user: %s (reset two-factor authentication)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		log.Infof("user: disabled two-factor authentication for %s", m.String())
+
+		Audit(c, s, entity.AuditAuth2faDisable, entity.AuditDiff(gin.H{"Enabled": enabled}, gin.H{"Enabled": false}), m.UserUID)
+
+		c.JSON(http.StatusOK, gin.H{"Enabled": false, "RecoveryCodes": 0})
+	})
+}
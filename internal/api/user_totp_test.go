@@ -0,0 +1,136 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/pkg/totp"
+)
+
+func TestGetUserTwoFactor(t *testing.T) {
+	t.Run("public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetUserTwoFactor(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/2fa")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("alice", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTwoFactor(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/2fa", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "Enabled").Bool())
+	})
+	t.Run("bob: other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		GetUserTwoFactor(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/users/uqxetse3cy5eo9z2/2fa", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
+
+func TestEnrollUserTwoFactor(t *testing.T) {
+	t.Run("enroll, login, and reset", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		EnrollUserTwoFactor(router)
+		VerifyUserTwoFactor(router)
+		DeleteUserTwoFactor(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+
+		r := AuthenticatedRequest(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/2fa", sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/2fa", `{"Password": "wrong"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/2fa", `{"Password": "Bobbob123!"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		secret := gjson.Get(r.Body.String(), "Secret").String()
+		assert.Contains(t, gjson.Get(r.Body.String(), "URI").String(), "otpauth://totp/")
+		assert.Contains(t, gjson.Get(r.Body.String(), "URI").String(), "secret="+secret)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/2fa/verify", `{"Passcode": "123"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		code, err := totp.Code(secret, time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/2fa/verify", fmt.Sprintf(`{"Passcode": "%s"}`, code), sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Enabled").Bool())
+
+		recovery := gjson.Get(r.Body.String(), "RecoveryCodes.0").String()
+		assert.Len(t, recovery, 11)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/2fa", `{"Password": "Bobbob123!"}`, sessId)
+		assert.Equal(t, http.StatusConflict, r.Code)
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "bob", "password": "Bobbob123!"}`)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "passcode").Bool())
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "bob", "password": "Bobbob123!", "passcode": "000000x"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		// The passcode used for verification cannot be used again.
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", fmt.Sprintf(`{"username": "bob", "password": "Bobbob123!", "passcode": "%s"}`, code))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		// Use the passcode of the next time step, which is accepted due to the allowed clock skew.
+		code, err = totp.Code(secret, time.Now().Add(totp.Period*time.Second))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", fmt.Sprintf(`{"username": "bob", "password": "Bobbob123!", "passcode": "%s"}`, code))
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", fmt.Sprintf(`{"username": "bob", "password": "Bobbob123!", "passcode": "%s"}`, code))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", fmt.Sprintf(`{"username": "bob", "password": "Bobbob123!", "passcode": "%s"}`, recovery))
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", fmt.Sprintf(`{"username": "bob", "password": "Bobbob123!", "passcode": "%s"}`, recovery))
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/users/uqxc08w3d0ej2283/2fa", sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "DELETE", "/api/v1/users/uqxc08w3d0ej2283/2fa", `{"Passcode": "000000x"}`, sessId)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "DELETE", "/api/v1/users/uqxc08w3d0ej2283/2fa", `{"Password": "Bobbob123!"}`, sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "bob", "password": "Bobbob123!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("bob: other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		EnrollUserTwoFactor(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/2fa", `{"Password": "Bobbob123!"}`, sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
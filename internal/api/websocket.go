@@ -66,7 +66,7 @@ func wsReader(ws *websocket.Conn, writeMutex *sync.Mutex, connId string, conf *c
 				var clientConfig config.ClientConfig
 
 				if sess.User.Guest() {
-					clientConfig = conf.GuestConfig()
+					clientConfig = ShareConfig(sess.Tokens)
 				} else if sess.User.Registered() {
 					clientConfig = conf.UserConfig()
 				} else {
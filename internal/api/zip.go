@@ -14,11 +14,13 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
@@ -52,6 +54,15 @@ func CreateZip(router *gin.RouterGroup) {
 			return
 		}
 
+		// Guests may only download originals shared with links that allow it, and
+		// other restricted users only originals of pictures they may view.
+		if s.Guest() {
+			DownloadableSelection(s, &f)
+		} else if err := ViewableSelection(s, &f); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrZipFailed)
+			return
+		}
+
 		if f.Empty() {
 			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
 			return
@@ -140,7 +151,9 @@ func CreateZip(router *gin.RouterGroup) {
 // GET /api/v1/zip/:filename
 func DownloadZip(router *gin.RouterGroup) {
 	router.GET("/zip/:filename", func(c *gin.Context) {
-		if InvalidDownloadToken(c) {
+		link, ok := DownloadLink(c, "")
+
+		if !ok {
 			c.Data(http.StatusForbidden, "image/svg+xml", brokenIconSvg)
 			return
 		}
@@ -158,12 +171,43 @@ func DownloadZip(router *gin.RouterGroup) {
 
 		c.FileAttachment(zipFileName, zipBaseName)
 
+		RedeemDownload(c, link, zipBaseName)
+
 		if err := os.Remove(zipFileName); err != nil {
 			log.Errorf("download: failed removing %s (%s)", sanitize.Log(zipFileName), err.Error())
 		}
 	})
 }
 
+// DownloadableSelection removes items from the selection of a guest that are not shared with a link
+// that allows downloading originals.
+func DownloadableSelection(s session.Data, f *form.Selection) {
+	var links entity.Links
+
+	for _, token := range s.Tokens {
+		for _, link := range entity.FindValidLinks(token, "") {
+			if link.Downloadable() {
+				links = append(links, link)
+			}
+		}
+	}
+
+	shared := func(uids []string) (result []string) {
+		for _, uid := range uids {
+			for _, link := range links {
+				if link.Shares(uid) {
+					result = append(result, uid)
+					break
+				}
+			}
+		}
+
+		return result
+	}
+
+	*f = form.Selection{Photos: shared(f.Photos), Albums: shared(f.Albums)}
+}
+
 // addFileToZip adds a file to a zip archive.
 func addFileToZip(zipWriter *zip.Writer, fileName, fileAlias string) error {
 	fileToZip, err := os.Open(fileName)
@@ -25,6 +25,16 @@ func TestCreateZip(t *testing.T) {
 		assert.Equal(t, "No items selected", val.String())
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("private photo of other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		CreateZip(router)
+		sessId := AuthenticateUser(app, router, "friend", "!Friend321")
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/zip", `{"photos": ["pt9jtdre2lvl0y12"]}`, sessId)
+		assert.Equal(t, "No items selected", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 	t.Run("invalid request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		CreateZip(router)
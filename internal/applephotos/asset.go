@@ -0,0 +1,85 @@
+package applephotos
+
+import (
+	"path/filepath"
+)
+
+// AlbumKindUser is the kind of albums created by users.
+const AlbumKindUser = 2
+
+// Asset represents an image or video in an Apple Photos library.
+type Asset struct {
+	ID           int64
+	UUID         string
+	Directory    string
+	FileName     string
+	OriginalName string
+	Title        string
+	Description  string
+	Favorite     bool
+	Hidden       bool
+	Trashed      bool
+	Edited       bool
+	Width        int
+	Height       int
+	Keywords     []string
+	Albums       []*Album
+	Faces        []Face
+}
+
+// Name returns the original file name as imported into the library, if known.
+func (a *Asset) Name() string {
+	if name := filepath.Base(a.OriginalName); a.OriginalName != "" && name != "." && name != "/" {
+		return name
+	}
+
+	return a.FileName
+}
+
+// Album represents an album created by the user.
+type Album struct {
+	ID    int64
+	UUID  string
+	Title string
+}
+
+// Face represents the face of a named person, the position and size are relative to the image dimensions.
+type Face struct {
+	Name string
+	X    float64
+	Y    float64
+	Size float64
+}
+
+// Rect returns the relative position and size of the face area with the origin at the top left.
+// The size is stored relative to the longer side, and the vertical center from the bottom.
+func (f Face) Rect(width, height int) (x, y, w, h float64) {
+	if width <= 0 || height <= 0 || f.Size <= 0 {
+		return 0, 0, 0, 0
+	}
+
+	longSide := float64(width)
+
+	if height > width {
+		longSide = float64(height)
+	}
+
+	w = f.Size * longSide / float64(width)
+	h = f.Size * longSide / float64(height)
+	x = f.X - w/2
+	y = 1 - f.Y - h/2
+
+	return clip(x), clip(y), clip(w), clip(h)
+}
+
+// clip limits the value to the range from 0 to 1.
+func clip(v float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 1:
+		return 1
+	default:
+		return v
+	}
+}
@@ -0,0 +1,320 @@
+/*
+Package applephotos reads the Photos.sqlite database of Apple Photos libraries.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package applephotos
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Global log instance.
+var log = event.Log
+
+// DatabaseName is the name of the library database relative to the library path.
+const DatabaseName = "database/Photos.sqlite"
+
+// Library represents the contents of an Apple Photos library.
+type Library struct {
+	Path   string
+	Assets []*Asset
+	Albums []*Album
+}
+
+// Open reads the assets and albums of an Apple Photos library, e.g. "Photos Library.photoslibrary".
+// The library database is opened in read-only mode and requires a registered "sqlite3" driver.
+func Open(libraryPath string) (*Library, error) {
+	libraryPath, err := filepath.Abs(libraryPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dbName := filepath.Join(libraryPath, DatabaseName)
+
+	if !fs.FileExists(dbName) {
+		return nil, fmt.Errorf("%s not found", sanitize.Log(DatabaseName))
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+dbName+"?mode=ro")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer db.Close()
+
+	s, err := readSchema(db)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lib := &Library{Path: libraryPath}
+
+	if err = lib.read(db, s); err != nil {
+		return nil, err
+	}
+
+	return lib, nil
+}
+
+// OriginalsPath returns the path of the original media files.
+func (lib *Library) OriginalsPath() string {
+	return filepath.Join(lib.Path, "originals")
+}
+
+// RendersPath returns the path of edited images and videos.
+func (lib *Library) RendersPath() string {
+	return filepath.Join(lib.Path, "resources", "renders")
+}
+
+// OriginalName returns the absolute file name of the original.
+func (lib *Library) OriginalName(a *Asset) string {
+	return filepath.Join(lib.OriginalsPath(), a.Directory, a.FileName)
+}
+
+// RenderName returns the absolute file name of the edited version, or an empty string if none exists.
+func (lib *Library) RenderName(a *Asset) string {
+	if !a.Edited {
+		return ""
+	}
+
+	matches, err := filepath.Glob(filepath.Join(lib.RendersPath(), a.Directory, a.UUID+"_1_201_a.*"))
+
+	if err != nil || len(matches) == 0 {
+		return ""
+	}
+
+	return matches[0]
+}
+
+// read reads the library contents from the database.
+func (lib *Library) read(db *sql.DB, s schema) error {
+	assets := make(map[int64]*Asset)
+	attributes := make(map[int64]*Asset)
+
+	rows, err := db.Query(fmt.Sprintf(`SELECT a.Z_PK, a.ZUUID, COALESCE(a.ZDIRECTORY, ''), COALESCE(a.ZFILENAME, ''),
+		COALESCE(a.ZFAVORITE, 0), COALESCE(a.ZHIDDEN, 0), COALESCE(a.ZTRASHEDSTATE, 0), COALESCE(a.ZHASADJUSTMENTS, 0),
+		COALESCE(a.ZWIDTH, 0), COALESCE(a.ZHEIGHT, 0), COALESCE(attr.Z_PK, 0), COALESCE(attr.ZORIGINALFILENAME, ''), COALESCE(attr.ZTITLE, '')
+		FROM %s a LEFT JOIN ZADDITIONALASSETATTRIBUTES attr ON attr.ZASSET = a.Z_PK ORDER BY a.Z_PK`, s.assets))
+
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		a := &Asset{}
+
+		var attrID int64
+
+		if err = rows.Scan(&a.ID, &a.UUID, &a.Directory, &a.FileName, &a.Favorite, &a.Hidden, &a.Trashed, &a.Edited,
+			&a.Width, &a.Height, &attrID, &a.OriginalName, &a.Title); err != nil {
+			rows.Close()
+			return err
+		}
+
+		a.Title = strings.TrimSpace(a.Title)
+
+		lib.Assets = append(lib.Assets, a)
+		assets[a.ID] = a
+
+		if attrID > 0 {
+			attributes[attrID] = a
+		}
+	}
+
+	rows.Close()
+
+	// Descriptions are stored in a separate table.
+	if s.has("ZASSETDESCRIPTION", "ZLONGDESCRIPTION") {
+		err = query(db, `SELECT ZASSETATTRIBUTES, ZLONGDESCRIPTION FROM ZASSETDESCRIPTION WHERE ZLONGDESCRIPTION <> ''`, func(id int64, desc string) {
+			if a, ok := attributes[id]; ok {
+				a.Description = strings.TrimSpace(desc)
+			}
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Keywords are assigned to the additional asset attributes.
+	if s.keywords.table != "" {
+		err = query(db, fmt.Sprintf(`SELECT j.%s, k.ZTITLE FROM %s j JOIN ZKEYWORD k ON k.Z_PK = j.%s WHERE k.ZTITLE <> ''`,
+			s.keywords.owner, s.keywords.table, s.keywords.target), func(id int64, keyword string) {
+			if a, ok := attributes[id]; ok {
+				a.Keywords = append(a.Keywords, strings.TrimSpace(keyword))
+			}
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = lib.readAlbums(db, s, assets); err != nil {
+		return err
+	}
+
+	return lib.readFaces(db, s, assets)
+}
+
+// readAlbums reads the user albums and their assets.
+func (lib *Library) readAlbums(db *sql.DB, s schema, assets map[int64]*Asset) error {
+	if !s.has("ZGENERICALBUM", "ZKIND") {
+		return nil
+	}
+
+	albums := make(map[int64]*Album)
+
+	rows, err := db.Query(`SELECT Z_PK, COALESCE(ZUUID, ''), ZTITLE FROM ZGENERICALBUM
+		WHERE ZKIND = ? AND COALESCE(ZTRASHEDSTATE, 0) = 0 AND ZTITLE <> '' ORDER BY Z_PK`, AlbumKindUser)
+
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		a := &Album{}
+
+		if err = rows.Scan(&a.ID, &a.UUID, &a.Title); err != nil {
+			rows.Close()
+			return err
+		}
+
+		a.Title = strings.TrimSpace(a.Title)
+		lib.Albums = append(lib.Albums, a)
+		albums[a.ID] = a
+	}
+
+	rows.Close()
+
+	if s.albums.table == "" {
+		return nil
+	}
+
+	rows, err = db.Query(fmt.Sprintf(`SELECT %s, %s FROM %s`, s.albums.owner, s.albums.target, s.albums.table))
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var albumID, assetID int64
+
+		if err = rows.Scan(&albumID, &assetID); err != nil {
+			return err
+		}
+
+		album, ok := albums[albumID]
+
+		if !ok {
+			continue
+		}
+
+		if a, ok := assets[assetID]; ok {
+			a.Albums = append(a.Albums, album)
+		}
+	}
+
+	return rows.Err()
+}
+
+// readFaces reads the faces of named people.
+func (lib *Library) readFaces(db *sql.DB, s schema, assets map[int64]*Asset) error {
+	if !s.has("ZDETECTEDFACE", "ZASSET") || !s.has("ZPERSON", "ZFULLNAME") {
+		return nil
+	}
+
+	// Newer versions link faces and people with a different column.
+	person := "ZPERSON"
+
+	if s.has("ZDETECTEDFACE", "ZPERSONFORFACE") {
+		person = "ZPERSONFORFACE"
+	}
+
+	rows, err := db.Query(fmt.Sprintf(`SELECT f.ZASSET, COALESCE(NULLIF(p.ZFULLNAME, ''), p.ZDISPLAYNAME, ''),
+		COALESCE(f.ZCENTERX, 0), COALESCE(f.ZCENTERY, 0), COALESCE(f.ZSIZE, 0)
+		FROM ZDETECTEDFACE f JOIN ZPERSON p ON p.Z_PK = f.%s ORDER BY f.Z_PK`, person))
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var assetID int64
+		f := Face{}
+
+		if err = rows.Scan(&assetID, &f.Name, &f.X, &f.Y, &f.Size); err != nil {
+			return err
+		}
+
+		if f.Name = strings.TrimSpace(f.Name); f.Name == "" {
+			continue
+		}
+
+		if a, ok := assets[assetID]; ok {
+			a.Faces = append(a.Faces, f)
+		} else {
+			log.Debugf("apple: found no asset for face of %s", sanitize.Log(f.Name))
+		}
+	}
+
+	return rows.Err()
+}
+
+// query runs a query that returns an id and a string, and calls fn for each row.
+func query(db *sql.DB, stmt string, fn func(id int64, s string)) error {
+	rows, err := db.Query(stmt)
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var s string
+
+		if err = rows.Scan(&id, &s); err != nil {
+			return err
+		}
+
+		fn(id, s)
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,184 @@
+package applephotos
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSchema creates a library database similar to the one used by Photos 7.
+var testSchema = []string{
+	`CREATE TABLE ZASSET (Z_PK INTEGER PRIMARY KEY, ZUUID VARCHAR, ZDIRECTORY VARCHAR, ZFILENAME VARCHAR, ZFAVORITE INTEGER, ZHIDDEN INTEGER, ZTRASHEDSTATE INTEGER, ZHASADJUSTMENTS INTEGER, ZWIDTH INTEGER, ZHEIGHT INTEGER)`,
+	`CREATE TABLE ZADDITIONALASSETATTRIBUTES (Z_PK INTEGER PRIMARY KEY, ZASSET INTEGER, ZORIGINALFILENAME VARCHAR, ZTITLE VARCHAR)`,
+	`CREATE TABLE ZASSETDESCRIPTION (Z_PK INTEGER PRIMARY KEY, ZASSETATTRIBUTES INTEGER, ZLONGDESCRIPTION VARCHAR)`,
+	`CREATE TABLE ZKEYWORD (Z_PK INTEGER PRIMARY KEY, ZTITLE VARCHAR)`,
+	`CREATE TABLE Z_1KEYWORDS (Z_1ASSETATTRIBUTES INTEGER, Z_38KEYWORDS INTEGER)`,
+	`CREATE TABLE ZGENERICALBUM (Z_PK INTEGER PRIMARY KEY, ZUUID VARCHAR, ZTITLE VARCHAR, ZKIND INTEGER, ZTRASHEDSTATE INTEGER)`,
+	`CREATE TABLE Z_28ASSETS (Z_28ALBUMS INTEGER, Z_3ASSETS INTEGER, Z_FOK_3ASSETS INTEGER)`,
+	`CREATE TABLE ZPERSON (Z_PK INTEGER PRIMARY KEY, ZFULLNAME VARCHAR, ZDISPLAYNAME VARCHAR)`,
+	`CREATE TABLE ZDETECTEDFACE (Z_PK INTEGER PRIMARY KEY, ZASSET INTEGER, ZPERSONFORFACE INTEGER, ZCENTERX FLOAT, ZCENTERY FLOAT, ZSIZE FLOAT)`,
+	`INSERT INTO ZASSET VALUES (1, 'A1', '0', 'A1.heic', 1, 0, 0, 1, 4032, 3024)`,
+	`INSERT INTO ZASSET VALUES (2, 'A2', 'B', 'A2.jpeg', 0, 0, 0, 0, 1000, 2000)`,
+	`INSERT INTO ZASSET VALUES (3, 'A3', 'C', 'A3.mov', 0, 1, 1, 0, 1920, 1080)`,
+	`INSERT INTO ZADDITIONALASSETATTRIBUTES VALUES (11, 1, 'IMG_0001.HEIC', ' Beach ')`,
+	`INSERT INTO ZADDITIONALASSETATTRIBUTES VALUES (12, 2, 'IMG_0002.JPG', NULL)`,
+	`INSERT INTO ZASSETDESCRIPTION VALUES (1, 11, 'Sunset at the beach')`,
+	`INSERT INTO ZKEYWORD VALUES (1, 'Holiday')`,
+	`INSERT INTO ZKEYWORD VALUES (2, 'Sea')`,
+	`INSERT INTO Z_1KEYWORDS VALUES (11, 1)`,
+	`INSERT INTO Z_1KEYWORDS VALUES (11, 2)`,
+	`INSERT INTO ZGENERICALBUM VALUES (1, 'R1', NULL, 3, 0)`,
+	`INSERT INTO ZGENERICALBUM VALUES (2, 'L1', 'Holidays', 2, 0)`,
+	`INSERT INTO ZGENERICALBUM VALUES (3, 'L2', 'Deleted', 2, 1)`,
+	`INSERT INTO Z_28ASSETS VALUES (2, 1, 1)`,
+	`INSERT INTO Z_28ASSETS VALUES (2, 2, 2)`,
+	`INSERT INTO Z_28ASSETS VALUES (3, 2, 1)`,
+	`INSERT INTO ZPERSON VALUES (1, 'Jane Doe', 'Jane')`,
+	`INSERT INTO ZPERSON VALUES (2, '', 'John')`,
+	`INSERT INTO ZPERSON VALUES (3, NULL, NULL)`,
+	`INSERT INTO ZDETECTEDFACE VALUES (1, 1, 1, 0.5, 0.75, 0.1)`,
+	`INSERT INTO ZDETECTEDFACE VALUES (2, 2, 2, 0.25, 0.25, 0.2)`,
+	`INSERT INTO ZDETECTEDFACE VALUES (3, 2, 3, 0.25, 0.25, 0.2)`,
+}
+
+// createLibrary creates a library with a test database and media files.
+func createLibrary(t *testing.T, stmts []string) string {
+	libraryPath := filepath.Join(t.TempDir(), "Photos Library.photoslibrary")
+	dbName := filepath.Join(libraryPath, DatabaseName)
+
+	if err := os.MkdirAll(filepath.Dir(dbName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite3", dbName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	for _, stmt := range stmts {
+		if _, err = db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"originals/0/A1.heic", "originals/B/A2.jpeg", "resources/renders/0/A1_1_201_a.jpeg"} {
+		fileName := filepath.Join(libraryPath, name)
+
+		if err = os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return libraryPath
+}
+
+func TestOpen(t *testing.T) {
+	t.Run("Library", func(t *testing.T) {
+		lib, err := Open(createLibrary(t, testSchema))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !assert.Len(t, lib.Assets, 3) || !assert.Len(t, lib.Albums, 1) {
+			return
+		}
+
+		assert.Equal(t, "Holidays", lib.Albums[0].Title)
+		assert.Equal(t, "L1", lib.Albums[0].UUID)
+
+		a := lib.Assets[0]
+
+		assert.Equal(t, "A1", a.UUID)
+		assert.Equal(t, "IMG_0001.HEIC", a.Name())
+		assert.Equal(t, "Beach", a.Title)
+		assert.Equal(t, "Sunset at the beach", a.Description)
+		assert.True(t, a.Favorite)
+		assert.True(t, a.Edited)
+		assert.Equal(t, 4032, a.Width)
+		assert.Equal(t, []string{"Holiday", "Sea"}, a.Keywords)
+		assert.Equal(t, []*Album{lib.Albums[0]}, a.Albums)
+		assert.Equal(t, []Face{{Name: "Jane Doe", X: 0.5, Y: 0.75, Size: 0.1}}, a.Faces)
+		assert.Equal(t, filepath.Join(lib.Path, "originals", "0", "A1.heic"), lib.OriginalName(a))
+		assert.Equal(t, filepath.Join(lib.Path, "resources", "renders", "0", "A1_1_201_a.jpeg"), lib.RenderName(a))
+
+		a = lib.Assets[1]
+
+		assert.Equal(t, "IMG_0002.JPG", a.Name())
+		assert.Equal(t, "", a.Title)
+		assert.Empty(t, a.Keywords)
+		assert.Len(t, a.Albums, 1)
+		assert.Equal(t, []Face{{Name: "John", X: 0.25, Y: 0.25, Size: 0.2}}, a.Faces)
+		assert.Equal(t, "", lib.RenderName(a))
+
+		a = lib.Assets[2]
+
+		assert.Equal(t, "A3.mov", a.Name())
+		assert.True(t, a.Hidden)
+		assert.True(t, a.Trashed)
+		assert.Empty(t, a.Albums)
+	})
+	t.Run("Photos5", func(t *testing.T) {
+		lib, err := Open(createLibrary(t, []string{
+			`CREATE TABLE ZGENERICASSET (Z_PK INTEGER PRIMARY KEY, ZUUID VARCHAR, ZDIRECTORY VARCHAR, ZFILENAME VARCHAR, ZFAVORITE INTEGER, ZHIDDEN INTEGER, ZTRASHEDSTATE INTEGER, ZHASADJUSTMENTS INTEGER, ZWIDTH INTEGER, ZHEIGHT INTEGER)`,
+			`CREATE TABLE ZADDITIONALASSETATTRIBUTES (Z_PK INTEGER PRIMARY KEY, ZASSET INTEGER, ZORIGINALFILENAME VARCHAR, ZTITLE VARCHAR)`,
+			`CREATE TABLE ZPERSON (Z_PK INTEGER PRIMARY KEY, ZFULLNAME VARCHAR, ZDISPLAYNAME VARCHAR)`,
+			`CREATE TABLE ZDETECTEDFACE (Z_PK INTEGER PRIMARY KEY, ZASSET INTEGER, ZPERSON INTEGER, ZCENTERX FLOAT, ZCENTERY FLOAT, ZSIZE FLOAT)`,
+			`INSERT INTO ZGENERICASSET VALUES (1, 'A1', '0', 'A1.heic', 0, 0, 0, 0, 100, 100)`,
+			`INSERT INTO ZPERSON VALUES (1, 'Jane Doe', 'Jane')`,
+			`INSERT INTO ZDETECTEDFACE VALUES (1, 1, 1, 0.5, 0.5, 0.2)`,
+		}))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, lib.Assets, 1) {
+			assert.Equal(t, "A1.heic", lib.Assets[0].Name())
+			assert.Len(t, lib.Assets[0].Faces, 1)
+		}
+
+		assert.Empty(t, lib.Albums)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Open(createLibrary(t, []string{`CREATE TABLE ZOTHER (Z_PK INTEGER PRIMARY KEY)`}))
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Open(t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestFace_Rect(t *testing.T) {
+	t.Run("Landscape", func(t *testing.T) {
+		x, y, w, h := Face{X: 0.5, Y: 0.75, Size: 0.1}.Rect(2000, 1000)
+
+		assert.InDelta(t, 0.45, x, 0.0001)
+		assert.InDelta(t, 0.15, y, 0.0001)
+		assert.InDelta(t, 0.1, w, 0.0001)
+		assert.InDelta(t, 0.2, h, 0.0001)
+	})
+	t.Run("Clipped", func(t *testing.T) {
+		x, y, w, h := Face{X: 0, Y: 0, Size: 0.2}.Rect(1000, 1000)
+
+		assert.Equal(t, 0.0, x)
+		assert.InDelta(t, 0.9, y, 0.0001)
+		assert.InDelta(t, 0.2, w, 0.0001)
+		assert.InDelta(t, 0.2, h, 0.0001)
+	})
+	t.Run("NoSize", func(t *testing.T) {
+		x, y, w, h := Face{X: 0.5, Y: 0.5}.Rect(1000, 1000)
+
+		assert.Equal(t, []float64{0, 0, 0, 0}, []float64{x, y, w, h})
+	})
+}
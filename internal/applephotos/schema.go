@@ -0,0 +1,124 @@
+package applephotos
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// joinName matches the names of relationship tables and their foreign key columns, e.g. "Z_26ASSETS" or "Z_3ASSETS".
+var joinName = regexp.MustCompile(`^Z_\d+[A-Z]+$`)
+
+// schema contains the tables and columns of a library database, as they differ between versions.
+type schema struct {
+	tables   map[string]map[string]bool
+	assets   string
+	albums   joinTable
+	keywords joinTable
+}
+
+// joinTable represents a many-to-many relationship table.
+type joinTable struct {
+	table  string
+	owner  string
+	target string
+}
+
+// has tests if the table and column exist.
+func (s schema) has(table, column string) bool {
+	columns, ok := s.tables[table]
+	return ok && columns[column]
+}
+
+// join finds the relationship table with foreign key columns ending with the owner and target suffix.
+func (s schema) join(ownerSuffix, targetSuffix string) (result joinTable) {
+	for table, columns := range s.tables {
+		if !joinName.MatchString(table) || !strings.HasSuffix(table, targetSuffix) {
+			continue
+		}
+
+		result = joinTable{table: table}
+
+		for column := range columns {
+			if !joinName.MatchString(column) {
+				continue
+			} else if strings.HasSuffix(column, ownerSuffix) {
+				result.owner = column
+			} else if strings.HasSuffix(column, targetSuffix) {
+				result.target = column
+			}
+		}
+
+		if result.owner != "" && result.target != "" {
+			return result
+		}
+	}
+
+	return joinTable{}
+}
+
+// readSchema reads the tables and columns of a library database.
+func readSchema(db *sql.DB) (s schema, err error) {
+	s.tables = make(map[string]map[string]bool)
+
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name LIKE 'Z%'`)
+
+	if err != nil {
+		return s, err
+	}
+
+	var names []string
+
+	for rows.Next() {
+		var name string
+
+		if err = rows.Scan(&name); err != nil {
+			rows.Close()
+			return s, err
+		}
+
+		names = append(names, name)
+	}
+
+	rows.Close()
+
+	for _, name := range names {
+		// Table names are read from the database and contain only letters, digits, and underscores.
+		columns, err := db.Query(fmt.Sprintf(`SELECT name FROM pragma_table_info('%s')`, strings.ReplaceAll(name, "'", "")))
+
+		if err != nil {
+			return s, err
+		}
+
+		s.tables[name] = make(map[string]bool)
+
+		for columns.Next() {
+			var column string
+
+			if err = columns.Scan(&column); err != nil {
+				columns.Close()
+				return s, err
+			}
+
+			s.tables[name][column] = true
+		}
+
+		columns.Close()
+	}
+
+	// Photos 5 uses "ZGENERICASSET", later versions use "ZASSET".
+	switch {
+	case s.has("ZASSET", "ZUUID"):
+		s.assets = "ZASSET"
+	case s.has("ZGENERICASSET", "ZUUID"):
+		s.assets = "ZGENERICASSET"
+	default:
+		return s, fmt.Errorf("unsupported library database")
+	}
+
+	s.albums = s.join("ALBUMS", "ASSETS")
+	s.keywords = s.join("ASSETATTRIBUTES", "KEYWORDS")
+
+	return s, nil
+}
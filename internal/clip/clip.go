@@ -0,0 +1,75 @@
+/*
+Package clip provides pluggable text-to-image embedding models for semantic search.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package clip
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Supported engine names.
+const (
+	EngineHttp = "http"
+	EngineExec = "exec"
+	EngineNone = "none"
+)
+
+// Model maps images and search queries to vectors in the same embedding space, like CLIP.
+type Model interface {
+	// Name returns the engine name.
+	Name() string
+	// Image returns the embedding of an image file.
+	Image(fileName string) (Result, error)
+	// Text returns the embedding of a search query.
+	Text(query string) (Result, error)
+}
+
+// Options represents model settings.
+type Options struct {
+	Engine  string
+	Model   string
+	Url     string
+	Bin     string
+	Timeout time.Duration
+}
+
+// New returns the model matching the options, or the no-op model if the engine name is unknown.
+func New(opt Options) Model {
+	switch strings.ToLower(strings.TrimSpace(opt.Engine)) {
+	case EngineHttp:
+		return NewHttp(opt.Url, opt.Model, opt.Timeout)
+	case EngineExec:
+		return NewExec(opt.Bin, opt.Model, opt.Timeout)
+	case EngineNone, "":
+		return NewNone()
+	default:
+		log.Warnf("clip: unknown engine %s, semantic search disabled", opt.Engine)
+		return NewNone()
+	}
+}
@@ -0,0 +1,59 @@
+package clip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("http", func(t *testing.T) {
+		m := New(Options{Engine: "HTTP"})
+		assert.Equal(t, EngineHttp, m.Name())
+	})
+	t.Run("exec", func(t *testing.T) {
+		m := New(Options{Engine: EngineExec})
+		assert.Equal(t, EngineExec, m.Name())
+	})
+	t.Run("none", func(t *testing.T) {
+		m := New(Options{})
+		assert.Equal(t, EngineNone, m.Name())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		m := New(Options{Engine: "foo"})
+		assert.Equal(t, EngineNone, m.Name())
+	})
+}
+
+func TestNone(t *testing.T) {
+	_, err := NewNone().Image("foo.jpg")
+	assert.Error(t, err)
+
+	_, err = NewNone().Text("dog")
+	assert.Error(t, err)
+}
+
+func TestParseResult(t *testing.T) {
+	t.Run("object", func(t *testing.T) {
+		r, err := ParseResult([]byte(`{"model":"ViT-B-32","embedding":[3,4]}`), "http")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ViT-B-32", r.Model)
+		assert.Equal(t, Embedding{0.6, 0.8}, r.Embedding)
+	})
+	t.Run("array", func(t *testing.T) {
+		r, err := ParseResult([]byte(" [0, 2]\n"), "exec")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "exec", r.Model)
+		assert.Equal(t, Embedding{0, 1}, r.Embedding)
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := ParseResult([]byte(`{"embedding":[]}`), "http")
+		assert.Error(t, err)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseResult([]byte(`foo`), "http")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+package clip
+
+import (
+	"math"
+)
+
+// Embedding represents a vector in the embedding space of a model.
+type Embedding []float32
+
+// Norm returns the euclidean length of the vector.
+func (v Embedding) Norm() float64 {
+	var sum float64
+
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Normalize returns the vector scaled to unit length, so that the dot product of two
+// normalized vectors is their cosine similarity.
+func (v Embedding) Normalize() Embedding {
+	n := v.Norm()
+
+	if n == 0 {
+		return v
+	}
+
+	result := make(Embedding, len(v))
+
+	for i, x := range v {
+		result[i] = float32(float64(x) / n)
+	}
+
+	return result
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 if their dimensions differ.
+func (v Embedding) Cosine(other Embedding) float64 {
+	if len(v) != len(other) || len(v) == 0 {
+		return 0
+	}
+
+	var dot float64
+
+	for i := range v {
+		dot += float64(v[i]) * float64(other[i])
+	}
+
+	n := v.Norm() * other.Norm()
+
+	if n == 0 {
+		return 0
+	}
+
+	return dot / n
+}
+
+// Quantize returns the normalized vector with components scaled to signed bytes, which reduces
+// the memory needed to compare large numbers of vectors by 75%.
+func (v Embedding) Quantize() []int8 {
+	n := v.Normalize()
+	result := make([]int8, len(n))
+
+	for i, x := range n {
+		result[i] = int8(math.Round(math.Max(-1, math.Min(1, float64(x))) * 127))
+	}
+
+	return result
+}
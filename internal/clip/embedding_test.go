@@ -0,0 +1,24 @@
+package clip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedding_Normalize(t *testing.T) {
+	assert.Equal(t, Embedding{0.6, 0.8}, Embedding{3, 4}.Normalize())
+	assert.Equal(t, Embedding{0, 0}, Embedding{0, 0}.Normalize())
+}
+
+func TestEmbedding_Cosine(t *testing.T) {
+	assert.InDelta(t, 1.0, Embedding{1, 2}.Cosine(Embedding{2, 4}), 0.0001)
+	assert.InDelta(t, 0.0, Embedding{1, 0}.Cosine(Embedding{0, 1}), 0.0001)
+	assert.InDelta(t, -1.0, Embedding{1, 0}.Cosine(Embedding{-3, 0}), 0.0001)
+	assert.Equal(t, 0.0, Embedding{1, 0}.Cosine(Embedding{1, 0, 0}))
+}
+
+func TestEmbedding_Quantize(t *testing.T) {
+	assert.Equal(t, []int8{76, 102}, Embedding{3, 4}.Quantize())
+	assert.Equal(t, []int8{-127, 0}, Embedding{-2, 0}.Quantize())
+}
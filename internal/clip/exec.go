@@ -0,0 +1,78 @@
+package clip
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultBin is the default command-line embedding tool.
+const DefaultBin = "clip-embed"
+
+// Exec runs a local command-line tool, e.g. a script that runs an ONNX or TensorFlow Lite model,
+// as "<bin> image <file>" or "<bin> text <query>" and reads the result from stdout.
+type Exec struct {
+	bin     string
+	model   string
+	timeout time.Duration
+}
+
+// NewExec returns a new exec model instance. The model name is used for results that
+// do not contain one, and defaults to the engine name.
+func NewExec(bin, model string, timeout time.Duration) *Exec {
+	if bin == "" {
+		bin = DefaultBin
+	}
+
+	if model == "" {
+		model = EngineExec
+	}
+
+	return &Exec{bin: bin, model: model, timeout: timeout}
+}
+
+// Name returns the engine name.
+func (m *Exec) Name() string {
+	return EngineExec
+}
+
+// Image returns the embedding of an image file.
+func (m *Exec) Image(fileName string) (Result, error) {
+	return m.run("image", fileName)
+}
+
+// Text returns the embedding of a search query.
+func (m *Exec) Text(query string) (Result, error) {
+	return m.run("text", query)
+}
+
+// run executes the command and parses its output.
+func (m *Exec) run(args ...string) (Result, error) {
+	ctx := context.Background()
+
+	if m.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+	}
+
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, m.bin, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return Result{}, fmt.Errorf("%s, %s", err, msg)
+		}
+
+		return Result{}, err
+	}
+
+	return ParseResult(out.Bytes(), m.model)
+}
@@ -0,0 +1,32 @@
+package clip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExec(t *testing.T) {
+	m := NewExec("testdata/clip-embed.sh", "", time.Second)
+
+	t.Run("Image", func(t *testing.T) {
+		r, err := m.Image("foo.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test", r.Model)
+		assert.Equal(t, Embedding{0.6, 0.8}, r.Embedding)
+	})
+	t.Run("Text", func(t *testing.T) {
+		r, err := m.Text("dog")
+
+		assert.NoError(t, err)
+		assert.Equal(t, EngineExec, r.Model)
+		assert.Equal(t, Embedding{0, 1}, r.Embedding)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewExec("/path/to/missing-clip-bin", "", time.Second).Text("dog")
+
+		assert.Error(t, err)
+	})
+}
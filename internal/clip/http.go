@@ -0,0 +1,73 @@
+package clip
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultUrl is the default embedding service endpoint.
+const DefaultUrl = "http://localhost:8010"
+
+// Http requests embeddings from a remote service, e.g. a sidecar container. Images are passed
+// by file name as "GET <url>/image?f=<name>" and search queries as "GET <url>/text?q=<query>".
+type Http struct {
+	url    string
+	model  string
+	client *http.Client
+}
+
+// NewHttp returns a new HTTP model instance. The model name is used for results that
+// do not contain one, and defaults to the engine name.
+func NewHttp(serviceUrl, model string, timeout time.Duration) *Http {
+	if serviceUrl == "" {
+		serviceUrl = DefaultUrl
+	}
+
+	if model == "" {
+		model = EngineHttp
+	}
+
+	return &Http{url: strings.TrimRight(serviceUrl, "/"), model: model, client: &http.Client{Timeout: timeout}}
+}
+
+// Name returns the engine name.
+func (m *Http) Name() string {
+	return EngineHttp
+}
+
+// Image returns the embedding of an image file.
+func (m *Http) Image(fileName string) (Result, error) {
+	return m.request("/image?f=" + url.QueryEscape(fileName))
+}
+
+// Text returns the embedding of a search query.
+func (m *Http) Text(query string) (Result, error) {
+	return m.request("/text?q=" + url.QueryEscape(query))
+}
+
+// request performs a single embedding request.
+func (m *Http) request(path string) (Result, error) {
+	resp, err := m.client.Get(m.url + path)
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Result{}, fmt.Errorf("service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	return ParseResult(body, m.model)
+}
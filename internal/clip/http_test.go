@@ -0,0 +1,47 @@
+package clip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image":
+			assert.Equal(t, "/tmp/a b.jpg", r.URL.Query().Get("f"))
+			_, _ = w.Write([]byte(`{"model":"ViT-B-32","embedding":[1,0]}`))
+		case "/text":
+			assert.Equal(t, "dog on a beach", r.URL.Query().Get("q"))
+			_, _ = w.Write([]byte(`{"model":"ViT-B-32","embedding":[0,1]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	m := NewHttp(srv.URL+"/", "", time.Second)
+
+	t.Run("Image", func(t *testing.T) {
+		r, err := m.Image("/tmp/a b.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ViT-B-32", r.Model)
+		assert.Equal(t, Embedding{1, 0}, r.Embedding)
+	})
+	t.Run("Text", func(t *testing.T) {
+		r, err := m.Text("dog on a beach")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Embedding{0, 1}, r.Embedding)
+	})
+	t.Run("Status", func(t *testing.T) {
+		_, err := NewHttp(srv.URL+"/foo", "", time.Second).Text("dog")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,26 @@
+package clip
+
+import "fmt"
+
+// None is a no-op model used when semantic search is disabled.
+type None struct{}
+
+// NewNone returns a new no-op model instance.
+func NewNone() *None {
+	return &None{}
+}
+
+// Name returns the engine name.
+func (m *None) Name() string {
+	return EngineNone
+}
+
+// Image always returns an error.
+func (m *None) Image(fileName string) (Result, error) {
+	return Result{}, fmt.Errorf("semantic search disabled")
+}
+
+// Text always returns an error.
+func (m *None) Text(query string) (Result, error) {
+	return Result{}, fmt.Errorf("semantic search disabled")
+}
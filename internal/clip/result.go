@@ -0,0 +1,40 @@
+package clip
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Result represents an embedding and the name of the model that computed it. Embeddings
+// of different models cannot be compared.
+type Result struct {
+	Model     string    `json:"model"`
+	Embedding Embedding `json:"embedding"`
+}
+
+// ParseResult parses a JSON result, which may also be a plain array of numbers. The default
+// model name is used if the result does not contain one.
+func ParseResult(data []byte, defaultModel string) (result Result, err error) {
+	data = []byte(strings.TrimSpace(string(data)))
+
+	if len(data) > 0 && data[0] == '[' {
+		err = json.Unmarshal(data, &result.Embedding)
+	} else {
+		err = json.Unmarshal(data, &result)
+	}
+
+	if err != nil {
+		return Result{}, err
+	} else if len(result.Embedding) == 0 {
+		return Result{}, fmt.Errorf("empty embedding")
+	}
+
+	if result.Model == "" {
+		result.Model = defaultModel
+	}
+
+	result.Embedding = result.Embedding.Normalize()
+
+	return result, nil
+}
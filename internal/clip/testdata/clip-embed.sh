@@ -0,0 +1,7 @@
+#!/bin/sh
+# Returns a fixed embedding for testing, see exec_test.go.
+if [ "$1" = "image" ]; then
+  echo '{"model":"test","embedding":[3,4]}'
+else
+  echo '[0,2]'
+fi
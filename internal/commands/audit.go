@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// AuditCommand registers the audit log command.
+var AuditCommand = cli.Command{
+	Name:   "audit",
+	Usage:  "Displays the audit log of destructive and sharing actions",
+	Action: auditAction,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "user, u",
+			Usage: "only show actions of the user with this `NAME` or uid",
+		},
+		cli.StringFlag{
+			Name:  "action, a",
+			Usage: "only show this `ACTION`, e.g. photos.delete, or all actions of a type, e.g. photos",
+		},
+		cli.StringFlag{
+			Name:  "uid",
+			Usage: "only show actions on the entity with this `UID`",
+		},
+		cli.StringFlag{
+			Name:  "after",
+			Usage: "only show actions on or after this `DATE`, e.g. 2022-01-31",
+		},
+		cli.StringFlag{
+			Name:  "before",
+			Usage: "only show actions before this `DATE`, e.g. 2022-02-28",
+		},
+		cli.IntFlag{
+			Name:  "count, n",
+			Usage: "maximum `NUMBER` of entries",
+			Value: 100,
+		},
+		cli.IntFlag{
+			Name:  "offset",
+			Usage: "skip this `NUMBER` of entries",
+		},
+	},
+}
+
+// auditAction displays the audit log.
+func auditAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		f := form.SearchAudit{
+			User:   sanitize.Username(ctx.String("user")),
+			Action: strings.ToLower(strings.TrimSpace(ctx.String("action"))),
+			UID:    sanitize.IdString(ctx.String("uid")),
+			Count:  ctx.Int("count"),
+			Offset: ctx.Int("offset"),
+		}
+
+		var err error
+
+		if s := ctx.String("after"); s != "" {
+			if f.After, err = time.Parse("2006-01-02", s); err != nil {
+				return fmt.Errorf("invalid date %s", sanitize.Log(s))
+			}
+		}
+
+		if s := ctx.String("before"); s != "" {
+			if f.Before, err = time.Parse("2006-01-02", s); err != nil {
+				return fmt.Errorf("invalid date %s", sanitize.Log(s))
+			}
+		}
+
+		results, err := search.AuditLog(f)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("found %s", english.Plural(len(results), "entry", "entries"))
+
+		fmt.Printf("%-20s %-16s %-16s %-16s %-18s %s\n", "TIME", "USER", "SESSION", "ACTION", "UID", "DIFF")
+
+		for _, m := range results {
+			user := m.UserName
+
+			if user == "" {
+				user = m.UserUID
+			}
+
+			fmt.Printf("%-20s %-16s %-16s %-16s %-18s %s\n", m.CreatedAt.Format("2006-01-02 15:04:05"), user, m.SessUID, m.Action, m.EntityUID, m.Diff)
+		}
+
+		return nil
+	})
+}
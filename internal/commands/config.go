@@ -35,7 +35,6 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %t\n", "experimental", conf.Experimental())
 
 	// Config.
-	fmt.Printf("%-25s %s\n", "export-command", conf.ExportCommand())
 	fmt.Printf("%-25s %s\n", "config-file", conf.ConfigFile())
 	fmt.Printf("%-25s %s\n", "config-path", conf.ConfigPath())
 	fmt.Printf("%-25s %s\n", "settings-file", conf.SettingsFile())
@@ -91,6 +90,21 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "tensorflow-version", conf.TensorFlowVersion())
 	fmt.Printf("%-25s %s\n", "tensorflow-model-path", conf.TensorFlowModelPath())
 
+	// Text Recognition.
+	fmt.Printf("%-25s %s\n", "ocr-engine", conf.OcrEngine())
+	fmt.Printf("%-25s %s\n", "ocr-url", conf.OcrUrl())
+	fmt.Printf("%-25s %d\n", "ocr-timeout", conf.OcrTimeout()/time.Second)
+	fmt.Printf("%-25s %d\n", "ocr-retries", conf.OcrRetries())
+	fmt.Printf("%-25s %s\n", "ocr-bin", conf.OcrBin())
+	fmt.Printf("%-25s %s\n", "ocr-language", conf.OcrLanguage())
+
+	// Semantic Search.
+	fmt.Printf("%-25s %s\n", "clip-engine", conf.ClipEngine())
+	fmt.Printf("%-25s %s\n", "clip-model", conf.ClipModel())
+	fmt.Printf("%-25s %s\n", "clip-url", conf.ClipUrl())
+	fmt.Printf("%-25s %s\n", "clip-bin", conf.ClipBin())
+	fmt.Printf("%-25s %d\n", "clip-timeout", conf.ClipTimeout()/time.Second)
+
 	// UI Defaults.
 	fmt.Printf("%-25s %s\n", "default-locale", conf.DefaultLocale())
 
@@ -112,6 +126,14 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "imprint", conf.Imprint())
 	fmt.Printf("%-25s %s\n", "imprint-url", conf.ImprintUrl())
 
+	// Single Sign-On.
+	fmt.Printf("%-25s %s\n", "oidc-uri", conf.OidcUri())
+	fmt.Printf("%-25s %s\n", "oidc-client", conf.OidcClient())
+	fmt.Printf("%-25s %s\n", "oidc-scopes", strings.Join(conf.OidcScopes(), " "))
+	fmt.Printf("%-25s %t\n", "oidc-register", conf.OidcRegister())
+	fmt.Printf("%-25s %t\n", "oidc-link", conf.OidcLink())
+	fmt.Printf("%-25s %s\n", "oidc-redirect-url", conf.OidcRedirectUrl())
+
 	// URIs.
 	fmt.Printf("%-25s %s\n", "content-uri", conf.ContentUri())
 	fmt.Printf("%-25s %s\n", "static-uri", conf.StaticUri())
@@ -162,6 +184,9 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %d\n", "jpeg-quality", conf.JpegQuality())
 
 	// Facial Recognition.
+	fmt.Printf("%-25s %s\n", "face-detector", conf.FaceDetector())
+	fmt.Printf("%-25s %s\n", "face-detector-url", conf.FaceDetectorUrl())
+	fmt.Printf("%-25s %s\n", "face-detector-timeout", conf.FaceDetectorTimeout())
 	fmt.Printf("%-25s %d\n", "face-size", conf.FaceSize())
 	fmt.Printf("%-25s %f\n", "face-score", conf.FaceScore())
 	fmt.Printf("%-25s %d\n", "face-overlap", conf.FaceOverlap())
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// DuplicatesCommand registers the duplicates command.
+var DuplicatesCommand = cli.Command{
+	Name:   "duplicates",
+	Usage:  "Reports near-duplicates and burst shots with the recommended photo to keep",
+	Flags:  duplicatesFlags,
+	Action: duplicatesAction,
+}
+
+var duplicatesFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "dist, d",
+		Usage: "maximum perceptual hash `DISTANCE` of near-duplicates",
+		Value: photoprism.DuplicatesOptionsDefault().Distance,
+	},
+	cli.IntFlag{
+		Name:  "burst-dist",
+		Usage: "maximum perceptual hash `DISTANCE` of burst shots",
+		Value: photoprism.DuplicatesOptionsDefault().BurstDistance,
+	},
+	cli.DurationFlag{
+		Name:  "burst-interval",
+		Usage: "maximum `TIME` between burst shots, 0 to disable burst detection",
+		Value: photoprism.DuplicatesOptionsDefault().BurstInterval,
+	},
+}
+
+// duplicatesAction reports groups of near-duplicates and burst shots.
+func duplicatesAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	opt := photoprism.DuplicatesOptions{
+		Distance:      ctx.Int("dist"),
+		BurstDistance: ctx.Int("burst-dist"),
+		BurstInterval: ctx.Duration("burst-interval"),
+	}
+
+	groups, err := service.Duplicates().Find(opt)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-10s %-16s %-20s %-8s %-10s %-8s\n", "TYPE", "UID", "TAKEN", "QUALITY", "RESOLUTION", "DISTANCE")
+
+	for _, g := range groups {
+		p := g.Keeper
+		fmt.Printf("%-10s %-16s %-20s %-8d %-10d %-8s\n", g.Type, p.UID, p.TakenAt.Format("2006-01-02 15:04:05"), p.Quality, p.Resolution, "keep")
+
+		for _, p = range g.Photos {
+			fmt.Printf("%-10s %-16s %-20s %-8d %-10d %-8d\n", "", p.UID, p.TakenAt.Format("2006-01-02 15:04:05"), p.Quality, p.Resolution, p.Distance)
+		}
+	}
+
+	log.Infof("found %s in %s", english.Plural(len(groups), "group", "groups"), time.Since(start))
+
+	return nil
+}
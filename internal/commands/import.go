@@ -20,7 +20,11 @@ var ImportCommand = cli.Command{
 	Aliases:   []string{"import"},
 	Usage:     "Moves media files to originals",
 	ArgsUsage: "[PATH]",
-	Action:    importAction,
+	Subcommands: []cli.Command{
+		ImportTakeoutCommand,
+		ImportAppleCommand,
+	},
+	Action: importAction,
 }
 
 // importAction moves photos to originals path. Default import path is used if no path argument provided
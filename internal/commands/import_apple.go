@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/applephotos"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// ImportAppleCommand registers the Apple Photos import cli command.
+var ImportAppleCommand = cli.Command{
+	Name:      "apple",
+	Usage:     "Imports an Apple Photos library including albums, favorites, keywords, and people",
+	ArgsUsage: "[LIBRARY]...",
+	Action:    importAppleAction,
+}
+
+// importAppleAction imports media files and metadata from Apple Photos libraries.
+func importAppleAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	if !ctx.Args().Present() {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if conf.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	for _, arg := range ctx.Args() {
+		libraryPath, err := filepath.Abs(strings.TrimSpace(arg))
+
+		if err != nil {
+			return err
+		}
+
+		lib, err := applephotos.Open(libraryPath)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("apple: found %d assets and %d albums in %s", len(lib.Assets), len(lib.Albums), sanitize.Log(filepath.Base(libraryPath)))
+
+		// Files are staged in a temporary folder that is removed after importing.
+		tempPath, err := os.MkdirTemp(conf.TempPath(), "apple-")
+
+		if err != nil {
+			return err
+		}
+
+		res, err := photoprism.ImportApplePhotos(service.Import(), lib, photoprism.ImportOptionsMove(tempPath))
+
+		if err := os.RemoveAll(tempPath); err != nil {
+			log.Warnf("apple: %s (remove temporary files)", err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("apple: updated %d photos, %d albums, %d favorites, and %d faces", res.Photos, res.Albums, res.Favorites, res.Faces)
+
+		for _, fileName := range res.Missing {
+			log.Warnf("apple: %s is missing in the library", sanitize.Log(fileName))
+		}
+
+		for _, fileName := range res.NotFound {
+			log.Warnf("apple: %s has not been imported", sanitize.Log(fileName))
+		}
+	}
+
+	log.Infof("apple: completed in %s", time.Since(start))
+
+	return nil
+}
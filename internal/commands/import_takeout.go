@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/takeout"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// ImportTakeoutCommand registers the takeout import cli command.
+var ImportTakeoutCommand = cli.Command{
+	Name:      "takeout",
+	Usage:     "Imports a Google Takeout export including albums, favorites, and descriptions",
+	ArgsUsage: "[ZIP-OR-DIR]...",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "move, m",
+			Usage: "remove imported files from export folders",
+		},
+	},
+	Action: importTakeoutAction,
+}
+
+// importTakeoutAction imports media files and metadata from Google Takeout exports.
+func importTakeoutAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	if !ctx.Args().Present() {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if conf.ReadOnly() {
+		return config.ErrReadOnly
+	}
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	var dirs, zips []string
+
+	for _, arg := range ctx.Args() {
+		sourcePath, err := filepath.Abs(strings.TrimSpace(arg))
+
+		if err != nil {
+			return err
+		} else if !fs.PathExists(sourcePath) {
+			return fmt.Errorf("%s not found", sanitize.Log(arg))
+		} else if sourcePath == conf.OriginalsPath() {
+			return fmt.Errorf("import path is identical with originals")
+		}
+
+		if strings.EqualFold(filepath.Ext(sourcePath), ".zip") {
+			zips = append(zips, sourcePath)
+		} else {
+			dirs = append(dirs, sourcePath)
+		}
+	}
+
+	var unmatched, notFound int
+
+	// Large exports are split into multiple archives, so albums and sidecar files may be in a
+	// different part than the media files they belong to. All archives are therefore extracted
+	// to the same temporary folder, which is removed after importing.
+	if len(zips) > 0 {
+		tempPath, err := os.MkdirTemp(conf.TempPath(), "takeout-")
+
+		if err != nil {
+			return err
+		}
+
+		defer os.RemoveAll(tempPath)
+
+		names := make([]string, len(zips))
+
+		for i, zipName := range zips {
+			names[i] = filepath.Base(zipName)
+
+			log.Infof("takeout: extracting %s", sanitize.Log(names[i]))
+
+			if _, err = fs.Unzip(zipName, tempPath); err != nil {
+				return err
+			}
+		}
+
+		u, n, err := importTakeout(tempPath, photoprism.ImportOptionsMove(tempPath), strings.Join(names, ", "))
+
+		if err != nil {
+			return err
+		}
+
+		unmatched += u
+		notFound += n
+	}
+
+	for _, sourcePath := range dirs {
+		opt := photoprism.ImportOptionsCopy(sourcePath)
+
+		if ctx.Bool("move") {
+			opt = photoprism.ImportOptionsMove(sourcePath)
+		}
+
+		u, n, err := importTakeout(sourcePath, opt, sourcePath)
+
+		if err != nil {
+			return err
+		}
+
+		unmatched += u
+		notFound += n
+	}
+
+	log.Infof("takeout: completed in %s, %d files without metadata, %d files not imported", time.Since(start), unmatched, notFound)
+
+	return nil
+}
+
+// importTakeout imports the Google Takeout export in the source path and returns the number of
+// files without metadata and files that have not been imported.
+func importTakeout(sourcePath string, opt photoprism.ImportOptions, name string) (unmatched, notFound int, err error) {
+	archive, err := takeout.Scan(sourcePath)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	log.Infof("takeout: found %d media files and %d albums in %s", len(archive.Photos), len(archive.Albums), sanitize.Log(name))
+
+	res, err := photoprism.ImportTakeout(service.Import(), archive, opt)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	log.Infof("takeout: updated %d photos, %d albums, and %d favorites", res.Photos, res.Albums, res.Favorites)
+
+	for _, fileName := range res.Unmatched {
+		log.Warnf("takeout: found no metadata for %s", sanitize.Log(fs.RelName(fileName, archive.Root)))
+	}
+
+	for _, fileName := range res.NotFound {
+		log.Warnf("takeout: %s has not been imported", sanitize.Log(fs.RelName(fileName, archive.Root)))
+	}
+
+	return len(res.Unmatched), len(res.NotFound), nil
+}
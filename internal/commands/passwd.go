@@ -59,6 +59,12 @@ func passwdAction(ctx *cli.Context) error {
 
 	log.Infof("changed password for %s\n", sanitize.Log(user.Username()))
 
+	if deleted, err := entity.DeleteUserSessions(user.UserUID, ""); err != nil {
+		log.Errorf("passwd: %s (revoke sessions)", err)
+	} else if deleted > 0 {
+		log.Infof("revoked %d sessions of %s", deleted, sanitize.Log(user.Username()))
+	}
+
 	conf.Shutdown()
 
 	return nil
@@ -15,6 +15,7 @@ import (
 	"github.com/photoprism/photoprism/internal/auto"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/server"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/workers"
@@ -73,6 +74,9 @@ func startAction(ctx *cli.Context) error {
 	// initialize the database
 	conf.InitDb()
 
+	// enable semantic search if an embedding model is configured
+	search.SetSemanticModel(service.Clip())
+
 	// check if daemon is running, if not initialize the daemon
 	dctx := new(daemon.Context)
 	dctx.LogFileName = conf.LogFilename()
@@ -60,5 +60,9 @@ func statusAction(ctx *cli.Context) error {
 		fmt.Println("unknown")
 	}
 
+	if lockouts := gjson.Get(status, "lockouts").Int(); lockouts > 0 {
+		fmt.Printf("%d clients locked out after failed login attempts\n", lockouts)
+	}
+
 	return nil
 }
@@ -75,6 +75,8 @@ var UsersCommand = cli.Command{
 			Action:    usersDeleteAction,
 			ArgsUsage: "[USERNAME]",
 		},
+		UsersTokensCommand,
+		UsersTwoFactorCommand,
 	},
 }
 
@@ -243,6 +245,10 @@ func usersUpdateAction(ctx *cli.Context) error {
 				return err
 			}
 			fmt.Printf("password successfully changed: %s\n", sanitize.Log(u.Username()))
+
+			if _, err := entity.DeleteUserSessions(u.UserUID, ""); err != nil {
+				return err
+			}
 		}
 
 		if ctx.IsSet("fullname") {
@@ -287,3 +293,18 @@ func callWithDependencies(ctx *cli.Context, f func(conf *config.Config) error) e
 
 	return nil
 }
+
+// userArg returns the user specified as first command argument.
+func userArg(ctx *cli.Context) (*entity.User, error) {
+	userName := strings.TrimSpace(ctx.Args().First())
+
+	if userName == "" {
+		return nil, errors.New("please provide a username")
+	}
+
+	if m := entity.FindUserByName(userName); m == nil {
+		return nil, errors.New("user not found")
+	} else {
+		return m, nil
+	}
+}
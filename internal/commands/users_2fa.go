@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// UsersTwoFactorCommand registers the two-factor authentication subcommands.
+var UsersTwoFactorCommand = cli.Command{
+	Name:  "2fa",
+	Usage: "Two-factor authentication subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "status",
+			Usage:     "Shows if two-factor authentication is enabled for a user",
+			ArgsUsage: "[USERNAME]",
+			Action:    usersTwoFactorStatusAction,
+		},
+		{
+			Name:      "reset",
+			Usage:     "Disables two-factor authentication, e.g. if a user lost their device and recovery codes",
+			ArgsUsage: "[USERNAME]",
+			Action:    usersTwoFactorResetAction,
+		},
+	},
+}
+
+// usersTwoFactorStatusAction shows the two-factor authentication status of a user.
+func usersTwoFactorStatusAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		m, err := userArg(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		codes, err := entity.FindRecoveryCodes(m.UserUID)
+
+		if err != nil {
+			return err
+		}
+
+		if m.TwoFactorEnabled() {
+			fmt.Printf("two-factor authentication is enabled for %s, %d recovery codes left\n", m.UserName, len(codes))
+		} else {
+			fmt.Printf("two-factor authentication is disabled for %s\n", m.UserName)
+		}
+
+		return nil
+	})
+}
+
+// usersTwoFactorResetAction disables two-factor authentication for a user.
+func usersTwoFactorResetAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		m, err := userArg(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if err := m.ResetTwoFactor(); err != nil {
+			return err
+		}
+
+		log.Infof("disabled two-factor authentication for %s", m.String())
+
+		return nil
+	})
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// UsersTokensCommand registers the personal API token subcommands.
+var UsersTokensCommand = cli.Command{
+	Name:  "tokens",
+	Usage: "Personal API token subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "list",
+			Usage:     "Lists the API tokens of a user",
+			ArgsUsage: "[USERNAME]",
+			Action:    usersTokensListAction,
+		},
+		{
+			Name:      "add",
+			Usage:     "Creates a new API token and displays its secret",
+			ArgsUsage: "[USERNAME]",
+			Action:    usersTokensAddAction,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "name, n",
+					Usage: "token `NAME`, e.g. the script or device using it",
+				},
+				cli.StringFlag{
+					Name:  "scope, s",
+					Usage: "token `SCOPE`: read, upload, or full",
+					Value: acl.ScopeRead.String(),
+				},
+			},
+		},
+		{
+			Name:      "revoke",
+			Usage:     "Revokes an API token so that it can no longer be used",
+			ArgsUsage: "[USERNAME] [TOKEN UID]",
+			Action:    usersTokensRevokeAction,
+		},
+	},
+}
+
+// usersTokensListAction lists the API tokens of a user.
+func usersTokensListAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		m, err := userArg(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		tokens, err := entity.FindUserTokens(m.UserUID)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("found %s", english.Plural(len(tokens), "token", "tokens"))
+
+		fmt.Printf("%-16s %-24s %-8s %-20s %-20s\n", "UID", "NAME", "SCOPE", "CREATED", "LAST USED")
+
+		for _, t := range tokens {
+			usedAt := "never"
+
+			if t.UsedAt != nil {
+				usedAt = t.UsedAt.Format("2006-01-02 15:04:05")
+			}
+
+			fmt.Printf("%-16s %-24s %-8s %-20s %-20s\n", t.TokenUID, t.TokenName, t.TokenScope, t.CreatedAt.Format("2006-01-02 15:04:05"), usedAt)
+		}
+
+		return nil
+	})
+}
+
+// usersTokensAddAction creates a new API token.
+func usersTokensAddAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		m, err := userArg(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		scope := acl.ParseScope(ctx.String("scope"))
+
+		if !scope.Valid() {
+			return fmt.Errorf("invalid scope %s, must be read, upload, or full", sanitize.Log(ctx.String("scope")))
+		}
+
+		token := entity.NewUserToken(m.UserUID, ctx.String("name"), scope)
+
+		if token == nil {
+			return errors.New("failed creating token")
+		} else if err := token.Create(); err != nil {
+			return err
+		}
+
+		log.Infof("created %s token %s for %s", token.TokenScope, sanitize.Log(token.TokenName), m.String())
+
+		fmt.Printf("uid:    %s\nsecret: %s\n", token.TokenUID, token.Secret)
+		fmt.Println("please store the secret in a safe place, it cannot be displayed again")
+
+		return nil
+	})
+}
+
+// usersTokensRevokeAction revokes an API token.
+func usersTokensRevokeAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		m, err := userArg(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		tokenUID := sanitize.IdString(ctx.Args().Get(1))
+
+		if tokenUID == "" {
+			return errors.New("please provide a token uid")
+		}
+
+		token := entity.FindUserTokenByUID(m.UserUID, tokenUID)
+
+		if token == nil {
+			return errors.New("token not found")
+		} else if err := token.Revoke(); err != nil {
+			return err
+		}
+
+		log.Infof("revoked token %s of %s", sanitize.Log(token.TokenName), m.String())
+
+		return nil
+	})
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"golang.org/x/crypto/bcrypt"
@@ -58,3 +59,32 @@ func (c *Config) PreviewToken() string {
 
 	return c.options.PreviewToken
 }
+
+// AuthAttempts returns the number of failed login attempts per client before backoff applies, -1 if disabled.
+func (c *Config) AuthAttempts() int {
+	if c.options.AuthAttempts < 0 {
+		return -1
+	} else if c.options.AuthAttempts == 0 {
+		return 5
+	}
+
+	return c.options.AuthAttempts
+}
+
+// AuthBackoff returns the initial backoff duration after too many failed login attempts.
+func (c *Config) AuthBackoff() time.Duration {
+	if c.options.AuthBackoff <= 0 {
+		return 2 * time.Second
+	}
+
+	return time.Duration(c.options.AuthBackoff) * time.Second
+}
+
+// AuthLockout returns the maximum lockout duration after repeated failed login attempts.
+func (c *Config) AuthLockout() time.Duration {
+	if c.options.AuthLockout <= 0 {
+		return 15 * time.Minute
+	}
+
+	return time.Duration(c.options.AuthLockout) * time.Second
+}
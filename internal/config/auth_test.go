@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -53,3 +54,32 @@ func TestConfig_InvalidPreviewToken(t *testing.T) {
 
 	assert.True(t, c.InvalidPreviewToken("xxx"))
 }
+
+func TestConfig_AuthAttempts(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 5, c.AuthAttempts())
+	c.options.AuthAttempts = 10
+	assert.Equal(t, 10, c.AuthAttempts())
+	c.options.AuthAttempts = -1
+	assert.Equal(t, -1, c.AuthAttempts())
+	c.options.AuthAttempts = 0
+}
+
+func TestConfig_AuthBackoff(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 2*time.Second, c.AuthBackoff())
+	c.options.AuthBackoff = 10
+	assert.Equal(t, 10*time.Second, c.AuthBackoff())
+	c.options.AuthBackoff = 0
+}
+
+func TestConfig_AuthLockout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 15*time.Minute, c.AuthLockout())
+	c.options.AuthLockout = 60
+	assert.Equal(t, time.Minute, c.AuthLockout())
+	c.options.AuthLockout = 0
+}
@@ -244,7 +244,8 @@ func (c *Config) PublicConfig() ClientConfig {
 	return result
 }
 
-// GuestConfig returns client config options for the sharing with guests.
+// GuestConfig returns client config options for the sharing with guests. The download token is empty,
+// as guests may only download originals with a share link token that allows it.
 func (c *Config) GuestConfig() ClientConfig {
 	assets := c.ClientAssets()
 	settings := c.Settings()
@@ -307,7 +308,7 @@ func (c *Config) GuestConfig() ClientConfig {
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
 		MapKey:          c.Hub().MapKey(),
-		DownloadToken:   c.DownloadToken(),
+		DownloadToken:   "",
 		PreviewToken:    c.PreviewToken(),
 		ManifestUri:     c.ClientManifestUri(),
 		Clip:            txt.ClipDefault,
@@ -418,12 +419,12 @@ func (c *Config) UserConfigFull(counterless bool) ClientConfig {
 		Select("MAX(photo_count) AS label_max_photos, COUNT(*) AS labels").
 		Where("photo_count > 0").
 		Where("deleted_at IS NULL").
-		Where("(label_priority >= 0 OR label_favorite = 1)").
+		Where("(label_priority >= 0 OR label_favorite = TRUE)").
 		Take(&result.Count)
 
 	c.Db().
 		Table("albums").
-		Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS months, SUM(album_type = ?) AS states, SUM(album_type = ?) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+		Select("SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS albums, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS moments, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS months, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS states, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
 		Where("deleted_at IS NULL AND (albums.album_type <> 'folder'").
 		Take(&result.Count)
 
@@ -439,7 +440,7 @@ func (c *Config) UserConfigFull(counterless bool) ClientConfig {
 
 	c.Db().
 		Table("places").
-		Select("SUM(photo_count > 0) AS places").
+		Select("SUM(CASE WHEN photo_count > 0 THEN 1 ELSE 0 END) AS places").
 		Where("id <> 'zz'").
 		Take(&result.Count)
 
@@ -462,7 +463,7 @@ func (c *Config) UserConfigFull(counterless bool) ClientConfig {
 		Find(&result.Lenses)
 
 	c.Db().
-		Where("deleted_at IS NULL AND album_favorite = 1").
+		Where("deleted_at IS NULL AND album_favorite = TRUE").
 		Limit(20).Order("album_title").
 		Find(&result.Albums)
 
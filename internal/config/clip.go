@@ -0,0 +1,66 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/clip"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ClipEngine returns the semantic search embedding engine name.
+func (c *Config) ClipEngine() string {
+	switch s := strings.ToLower(strings.TrimSpace(c.options.ClipEngine)); s {
+	case clip.EngineHttp, clip.EngineExec:
+		return s
+	default:
+		return clip.EngineNone
+	}
+}
+
+// ClipModel returns the name of the semantic search model, which defaults to the engine name.
+// It should match the model name reported by the embedding service, if any.
+func (c *Config) ClipModel() string {
+	if s := txt.Clip(strings.TrimSpace(c.options.ClipModel), 64); s != "" {
+		return s
+	}
+
+	return c.ClipEngine()
+}
+
+// ClipUrl returns the semantic search embedding service URL.
+func (c *Config) ClipUrl() string {
+	if c.options.ClipUrl == "" {
+		return clip.DefaultUrl
+	}
+
+	return c.options.ClipUrl
+}
+
+// ClipBin returns the semantic search embedding command-line tool.
+func (c *Config) ClipBin() string {
+	return findExecutable(c.options.ClipBin, clip.DefaultBin)
+}
+
+// ClipTimeout returns the semantic search embedding timeout.
+func (c *Config) ClipTimeout() time.Duration {
+	switch {
+	case c.options.ClipTimeout <= 0:
+		return 30 * time.Second
+	case c.options.ClipTimeout > 3600:
+		return 3600 * time.Second
+	default:
+		return time.Duration(c.options.ClipTimeout) * time.Second
+	}
+}
+
+// ClipOptions returns the semantic search embedding model options.
+func (c *Config) ClipOptions() clip.Options {
+	return clip.Options{
+		Engine:  c.ClipEngine(),
+		Model:   c.ClipModel(),
+		Url:     c.ClipUrl(),
+		Bin:     c.ClipBin(),
+		Timeout: c.ClipTimeout(),
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/clip"
+)
+
+func TestConfig_ClipEngine(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, clip.EngineNone, c.ClipEngine())
+
+	c.options.ClipEngine = "HTTP"
+	assert.Equal(t, clip.EngineHttp, c.ClipEngine())
+
+	c.options.ClipEngine = "foo"
+	assert.Equal(t, clip.EngineNone, c.ClipEngine())
+}
+
+func TestConfig_ClipModel(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, clip.EngineNone, c.ClipModel())
+
+	c.options.ClipEngine = "exec"
+	assert.Equal(t, clip.EngineExec, c.ClipModel())
+
+	c.options.ClipModel = " ViT-B-32 "
+	assert.Equal(t, "ViT-B-32", c.ClipModel())
+	assert.Equal(t, "ViT-B-32", c.ClipOptions().Model)
+}
+
+func TestConfig_ClipUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, clip.DefaultUrl, c.ClipUrl())
+
+	c.options.ClipUrl = "http://clip:8010"
+	assert.Equal(t, "http://clip:8010", c.ClipUrl())
+}
+
+func TestConfig_ClipTimeout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 30*time.Second, c.ClipTimeout())
+
+	c.options.ClipTimeout = 5
+	assert.Equal(t, 5*time.Second, c.ClipTimeout())
+
+	c.options.ClipTimeout = 5000
+	assert.Equal(t, 3600*time.Second, c.ClipTimeout())
+}
+
+func TestConfig_ClipOptions(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.ClipEngine = clip.EngineExec
+
+	opt := c.ClipOptions()
+
+	assert.Equal(t, clip.EngineExec, opt.Engine)
+	assert.Equal(t, clip.DefaultUrl, opt.Url)
+	assert.Equal(t, c.ClipBin(), opt.Bin)
+}
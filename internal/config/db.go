@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	"github.com/mattn/go-sqlite3"
 	"github.com/photoprism/photoprism/internal/entity"
@@ -45,6 +47,8 @@ func (c *Config) DatabaseDriver() string {
 	switch strings.ToLower(c.options.DatabaseDriver) {
 	case MySQL, MariaDB:
 		c.options.DatabaseDriver = MySQL
+	case Postgres, "postgresql", "pgsql", "pg":
+		c.options.DatabaseDriver = Postgres
 	case SQLite3, "sqlite", "sqllite", "test", "file", "":
 		c.options.DatabaseDriver = SQLite3
 	case "tidb":
@@ -108,6 +112,11 @@ func (c *Config) ParseDatabaseDsn() {
 		return
 	}
 
+	if c.DatabaseDriver() == Postgres {
+		c.parsePostgresDsn()
+		return
+	}
+
 	matches := dsnPattern.FindStringSubmatch(c.options.DatabaseDsn)
 	names := dsnPattern.SubexpNames()
 
@@ -125,6 +134,61 @@ func (c *Config) ParseDatabaseDsn() {
 	}
 }
 
+// parsePostgresDsn parses a PostgreSQL connection URL or keyword/value connection string.
+func (c *Config) parsePostgresDsn() {
+	dsn := c.options.DatabaseDsn
+
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+
+		if err != nil {
+			log.Warnf("config: %s (parse database dsn)", err)
+			return
+		}
+
+		c.options.DatabaseServer = u.Host
+		c.options.DatabaseName = strings.TrimPrefix(u.Path, "/")
+
+		if u.User != nil {
+			c.options.DatabaseUser = u.User.Username()
+			c.options.DatabasePassword, _ = u.User.Password()
+		}
+
+		return
+	}
+
+	var host, port string
+
+	for _, field := range strings.Fields(dsn) {
+		kv := strings.SplitN(field, "=", 2)
+
+		if len(kv) != 2 {
+			continue
+		}
+
+		v := strings.Trim(kv[1], "'")
+
+		switch kv[0] {
+		case "host":
+			host = v
+		case "port":
+			port = v
+		case "user":
+			c.options.DatabaseUser = v
+		case "password":
+			c.options.DatabasePassword = v
+		case "dbname":
+			c.options.DatabaseName = v
+		}
+	}
+
+	if host != "" && port != "" {
+		c.options.DatabaseServer = host + ":" + port
+	} else {
+		c.options.DatabaseServer = host
+	}
+}
+
 // DatabaseServer the database server.
 func (c *Config) DatabaseServer() string {
 	c.ParseDatabaseDsn()
@@ -147,7 +211,11 @@ func (c *Config) DatabaseHost() string {
 
 // DatabasePort the database server port.
 func (c *Config) DatabasePort() int {
-	const defaultPort = 3306
+	defaultPort := 3306
+
+	if c.DatabaseDriver() == Postgres {
+		defaultPort = 5432
+	}
 
 	if s := strings.Split(c.DatabaseServer(), ":"); len(s) != 2 {
 		return defaultPort
@@ -252,7 +320,7 @@ func (c *Config) SetDbOptions() {
 	case MySQL, MariaDB:
 		c.Db().Set("gorm:table_options", "ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci")
 	case Postgres:
-		// Ignore for now.
+		// Not required as unicode is default.
 	case SQLite3:
 		// Not required as unicode is default.
 		c.Db().Unscoped().Raw("PRAGMA mmap_size=1073741824")
@@ -1,6 +1,60 @@
 package config
 
-import "github.com/photoprism/photoprism/internal/face"
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+// FaceDetector returns the face detection backend name.
+func (c *Config) FaceDetector() string {
+	switch s := strings.ToLower(strings.TrimSpace(c.options.FaceDetector)); s {
+	case face.DetectorBuiltin, face.DetectorInsightFace, face.DetectorGrpc, face.DetectorNone:
+		return s
+	case "":
+		return face.DefaultDetector
+	default:
+		return face.DetectorNone
+	}
+}
+
+// FaceDetectorUrl returns the face detection service URL.
+func (c *Config) FaceDetectorUrl() string {
+	if c.options.FaceDetectorUrl != "" {
+		return c.options.FaceDetectorUrl
+	}
+
+	switch c.FaceDetector() {
+	case face.DetectorInsightFace:
+		return face.DefaultInsightFaceUrl
+	case face.DetectorGrpc:
+		return face.DefaultGrpcUrl
+	default:
+		return ""
+	}
+}
+
+// FaceDetectorTimeout returns the face detection service timeout.
+func (c *Config) FaceDetectorTimeout() time.Duration {
+	switch {
+	case c.options.FaceDetectorTimeout <= 0:
+		return 30 * time.Second
+	case c.options.FaceDetectorTimeout > 3600:
+		return 3600 * time.Second
+	default:
+		return time.Duration(c.options.FaceDetectorTimeout) * time.Second
+	}
+}
+
+// FaceDetectorOptions returns the face detector options.
+func (c *Config) FaceDetectorOptions() face.DetectorOptions {
+	return face.DetectorOptions{
+		Name:    c.FaceDetector(),
+		Url:     c.FaceDetectorUrl(),
+		Timeout: c.FaceDetectorTimeout(),
+	}
+}
 
 // FaceSize returns the face size threshold in pixels.
 func (c *Config) FaceSize() int {
@@ -2,8 +2,11 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/face"
 )
 
 func TestConfig_FaceSize(t *testing.T) {
@@ -77,3 +80,41 @@ func TestConfig_FaceMatchDist(t *testing.T) {
 	c.options.FaceMatchDist = 0.01
 	assert.Equal(t, 0.46, c.FaceMatchDist())
 }
+
+func TestConfig_FaceDetector(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, face.DetectorInsightFace, c.FaceDetector())
+	c.options.FaceDetector = " GRPC "
+	assert.Equal(t, face.DetectorGrpc, c.FaceDetector())
+	c.options.FaceDetector = "foo"
+	assert.Equal(t, face.DetectorNone, c.FaceDetector())
+	c.options.FaceDetector = ""
+	assert.Equal(t, face.DefaultDetector, c.FaceDetector())
+}
+
+func TestConfig_FaceDetectorUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, face.DefaultInsightFaceUrl, c.FaceDetectorUrl())
+	c.options.FaceDetector = face.DetectorGrpc
+	assert.Equal(t, face.DefaultGrpcUrl, c.FaceDetectorUrl())
+	c.options.FaceDetectorUrl = "http://faces:9000"
+	assert.Equal(t, "http://faces:9000", c.FaceDetectorUrl())
+}
+
+func TestConfig_FaceDetectorTimeout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 30*time.Second, c.FaceDetectorTimeout())
+	c.options.FaceDetectorTimeout = 5
+	assert.Equal(t, 5*time.Second, c.FaceDetectorTimeout())
+	c.options.FaceDetectorTimeout = 100000
+	assert.Equal(t, 3600*time.Second, c.FaceDetectorTimeout())
+}
+
+func TestConfig_FaceDetectorOptions(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.FaceDetector = face.DetectorBuiltin
+	opt := c.FaceDetectorOptions()
+	assert.Equal(t, face.DetectorBuiltin, opt.Name)
+	assert.Equal(t, "", opt.Url)
+	assert.Equal(t, 30*time.Second, opt.Timeout)
+}
@@ -4,8 +4,10 @@ import (
 	"github.com/klauspost/cpuid/v2"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/clip"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/ocr"
 	"github.com/photoprism/photoprism/internal/thumb"
 )
 
@@ -321,6 +323,60 @@ var GlobalFlags = []cli.Flag{
 		Value:  "",
 		EnvVar: "PHOTOPRISM_IMPRINT_URL",
 	},
+	cli.StringFlag{
+		Name:   "oidc-uri",
+		Usage:  "OpenID Connect issuer `URL` for single sign-on (leave empty to disable)",
+		EnvVar: "PHOTOPRISM_OIDC_URI",
+	},
+	cli.StringFlag{
+		Name:   "oidc-client",
+		Usage:  "OpenID Connect client `ID`",
+		EnvVar: "PHOTOPRISM_OIDC_CLIENT",
+	},
+	cli.StringFlag{
+		Name:   "oidc-secret",
+		Usage:  "OpenID Connect client `SECRET` (optional)",
+		EnvVar: "PHOTOPRISM_OIDC_SECRET",
+	},
+	cli.StringFlag{
+		Name:   "oidc-scopes",
+		Usage:  "OpenID Connect `SCOPES`, separated by spaces",
+		Value:  "openid email profile",
+		EnvVar: "PHOTOPRISM_OIDC_SCOPES",
+	},
+	cli.BoolFlag{
+		Name:   "oidc-register",
+		Usage:  "create user accounts for unknown OpenID Connect users",
+		EnvVar: "PHOTOPRISM_OIDC_REGISTER",
+	},
+	cli.BoolFlag{
+		Name:   "oidc-link",
+		Usage:  "link existing user accounts to OpenID Connect users with the same verified email address",
+		EnvVar: "PHOTOPRISM_OIDC_LINK",
+	},
+	cli.StringFlag{
+		Name:   "oidc-roles",
+		Usage:  "maps identity provider groups to user roles, e.g. `admins=admin,family=family,friends=friend`",
+		EnvVar: "PHOTOPRISM_OIDC_ROLES",
+	},
+	cli.IntFlag{
+		Name:   "auth-attempts",
+		Value:  5,
+		Usage:  "failed login `ATTEMPTS` per client before backoff applies (-1 to disable)",
+		EnvVar: "PHOTOPRISM_AUTH_ATTEMPTS",
+	},
+	cli.IntFlag{
+		Name:   "auth-backoff",
+		Value:  2,
+		Usage:  "initial backoff in `SECONDS`, doubles with each further failed attempt",
+		EnvVar: "PHOTOPRISM_AUTH_BACKOFF",
+	},
+	cli.IntFlag{
+		Name:   "auth-lockout",
+		Value:  900,
+		Usage:  "maximum lockout in `SECONDS` after repeated failed attempts",
+		EnvVar: "PHOTOPRISM_AUTH_LOCKOUT",
+	},
 	cli.IntFlag{
 		Name:   "http-port",
 		Value:  2342,
@@ -502,6 +558,23 @@ var GlobalFlags = []cli.Flag{
 		Value:  thumb.JpegQuality.String(),
 		EnvVar: "PHOTOPRISM_JPEG_QUALITY",
 	},
+	cli.StringFlag{
+		Name:   "face-detector",
+		Usage:  "face detection `BACKEND` (builtin, insightface, grpc, none)",
+		Value:  face.DefaultDetector,
+		EnvVar: "PHOTOPRISM_FACE_DETECTOR",
+	},
+	cli.StringFlag{
+		Name:   "face-detector-url",
+		Usage:  "face detection service `URL` (insightface, grpc)",
+		EnvVar: "PHOTOPRISM_FACE_DETECTOR_URL",
+	},
+	cli.IntFlag{
+		Name:   "face-detector-timeout",
+		Usage:  "face detection service timeout in `SECONDS` (1-3600)",
+		Value:  30,
+		EnvVar: "PHOTOPRISM_FACE_DETECTOR_TIMEOUT",
+	},
 	cli.IntFlag{
 		Name:   "face-size",
 		Usage:  "minimum face size in `PIXELS` (20-10000)",
@@ -556,6 +629,70 @@ var GlobalFlags = []cli.Flag{
 		Value:  face.MatchDist,
 		EnvVar: "PHOTOPRISM_FACE_MATCH_DIST",
 	},
+	cli.StringFlag{
+		Name:   "ocr-engine",
+		Usage:  "text recognition `ENGINE` (http, exec, none)",
+		Value:  ocr.EngineHttp,
+		EnvVar: "PHOTOPRISM_OCR_ENGINE",
+	},
+	cli.StringFlag{
+		Name:   "ocr-url",
+		Usage:  "text recognition service `URL` (http engine only)",
+		Value:  ocr.DefaultUrl,
+		EnvVar: "PHOTOPRISM_OCR_URL",
+	},
+	cli.IntFlag{
+		Name:   "ocr-timeout",
+		Usage:  "text recognition timeout in `SECONDS` (1-3600)",
+		Value:  30,
+		EnvVar: "PHOTOPRISM_OCR_TIMEOUT",
+	},
+	cli.IntFlag{
+		Name:   "ocr-retries",
+		Usage:  "`NUMBER` of text recognition retries (http engine only, 0-10)",
+		Value:  2,
+		EnvVar: "PHOTOPRISM_OCR_RETRIES",
+	},
+	cli.StringFlag{
+		Name:   "ocr-bin",
+		Usage:  "text recognition command-line tool `COMMAND` (exec engine only)",
+		Value:  ocr.DefaultBin,
+		EnvVar: "PHOTOPRISM_OCR_BIN",
+	},
+	cli.StringFlag{
+		Name:   "ocr-language",
+		Usage:  "text recognition language `CODES` e.g. eng+deu (exec engine only)",
+		EnvVar: "PHOTOPRISM_OCR_LANGUAGE",
+	},
+	cli.StringFlag{
+		Name:   "clip-engine",
+		Usage:  "semantic search embedding `ENGINE` (http, exec, none)",
+		Value:  clip.EngineNone,
+		EnvVar: "PHOTOPRISM_CLIP_ENGINE",
+	},
+	cli.StringFlag{
+		Name:   "clip-model",
+		Usage:  "semantic search model `NAME`, embeddings of other models are recomputed when indexing (default: engine name)",
+		EnvVar: "PHOTOPRISM_CLIP_MODEL",
+	},
+	cli.StringFlag{
+		Name:   "clip-url",
+		Usage:  "semantic search embedding service `URL` (http engine only)",
+		Value:  clip.DefaultUrl,
+		EnvVar: "PHOTOPRISM_CLIP_URL",
+	},
+	cli.StringFlag{
+		Name:   "clip-bin",
+		Usage:  "semantic search embedding command-line tool `COMMAND` (exec engine only)",
+		Value:  clip.DefaultBin,
+		EnvVar: "PHOTOPRISM_CLIP_BIN",
+	},
+	cli.IntFlag{
+		Name:   "clip-timeout",
+		Usage:  "semantic search embedding timeout in `SECONDS` (1-3600)",
+		Value:  30,
+		EnvVar: "PHOTOPRISM_CLIP_TIMEOUT",
+	},
 	cli.StringFlag{
 		Name:   "pid-filename",
 		Usage:  "process id `FILENAME` (daemon mode only)",
@@ -567,9 +704,4 @@ var GlobalFlags = []cli.Flag{
 		EnvVar: "PHOTOPRISM_LOG_FILENAME",
 		Value:  "",
 	},
-	cli.StringFlag{
-		Name:   "export-command",
-		Usage:  "export command for sync",
-		EnvVar: "PHOTOPRISM_EXPORT_COMMAND",
-	},
 }
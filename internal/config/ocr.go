@@ -0,0 +1,75 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/ocr"
+)
+
+// OcrEngine returns the text recognition engine name.
+func (c *Config) OcrEngine() string {
+	switch s := strings.ToLower(strings.TrimSpace(c.options.OcrEngine)); s {
+	case ocr.EngineHttp, ocr.EngineExec, ocr.EngineNone:
+		return s
+	case "":
+		return ocr.EngineHttp
+	default:
+		return ocr.EngineNone
+	}
+}
+
+// OcrUrl returns the text recognition service URL.
+func (c *Config) OcrUrl() string {
+	if c.options.OcrUrl == "" {
+		return ocr.DefaultUrl
+	}
+
+	return c.options.OcrUrl
+}
+
+// OcrTimeout returns the text recognition timeout.
+func (c *Config) OcrTimeout() time.Duration {
+	switch {
+	case c.options.OcrTimeout <= 0:
+		return 30 * time.Second
+	case c.options.OcrTimeout > 3600:
+		return 3600 * time.Second
+	default:
+		return time.Duration(c.options.OcrTimeout) * time.Second
+	}
+}
+
+// OcrRetries returns the number of times failed text recognition requests are retried.
+func (c *Config) OcrRetries() int {
+	switch {
+	case c.options.OcrRetries < 0:
+		return 0
+	case c.options.OcrRetries > 10:
+		return 10
+	default:
+		return c.options.OcrRetries
+	}
+}
+
+// OcrBin returns the text recognition command-line tool.
+func (c *Config) OcrBin() string {
+	return findExecutable(c.options.OcrBin, ocr.DefaultBin)
+}
+
+// OcrLanguage returns the text recognition language codes, e.g. "eng+deu".
+func (c *Config) OcrLanguage() string {
+	return strings.TrimSpace(c.options.OcrLanguage)
+}
+
+// OcrOptions returns the text recognition engine options.
+func (c *Config) OcrOptions() ocr.Options {
+	return ocr.Options{
+		Engine:   c.OcrEngine(),
+		Url:      c.OcrUrl(),
+		Timeout:  c.OcrTimeout(),
+		Retries:  c.OcrRetries(),
+		Bin:      c.OcrBin(),
+		Language: c.OcrLanguage(),
+	}
+}
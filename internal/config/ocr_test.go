@@ -0,0 +1,61 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ocr"
+)
+
+func TestConfig_OcrEngine(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ocr.EngineHttp, c.OcrEngine())
+
+	c.options.OcrEngine = "Exec"
+	assert.Equal(t, ocr.EngineExec, c.OcrEngine())
+
+	c.options.OcrEngine = "foo"
+	assert.Equal(t, ocr.EngineNone, c.OcrEngine())
+}
+
+func TestConfig_OcrUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, ocr.DefaultUrl, c.OcrUrl())
+
+	c.options.OcrUrl = "http://ocr:8009/ocr"
+	assert.Equal(t, "http://ocr:8009/ocr", c.OcrUrl())
+}
+
+func TestConfig_OcrTimeout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 30*time.Second, c.OcrTimeout())
+
+	c.options.OcrTimeout = 5
+	assert.Equal(t, 5*time.Second, c.OcrTimeout())
+
+	c.options.OcrTimeout = 5000
+	assert.Equal(t, 3600*time.Second, c.OcrTimeout())
+}
+
+func TestConfig_OcrRetries(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 0, c.OcrRetries())
+
+	c.options.OcrRetries = 3
+	assert.Equal(t, 3, c.OcrRetries())
+
+	c.options.OcrRetries = 100
+	assert.Equal(t, 10, c.OcrRetries())
+}
+
+func TestConfig_OcrOptions(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.OcrLanguage = " eng+deu "
+
+	opt := c.OcrOptions()
+
+	assert.Equal(t, ocr.EngineHttp, opt.Engine)
+	assert.Equal(t, "eng+deu", opt.Language)
+}
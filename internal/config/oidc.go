@@ -0,0 +1,114 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+// OidcRolePriority lists the roles that can be assigned based on groups, starting with the highest priority.
+var OidcRolePriority = []acl.Role{acl.RoleAdmin, acl.RoleFamily, acl.RoleChild, acl.RoleFriend, acl.RoleGuest}
+
+// OidcEnabled tests if single sign-on with an OpenID Connect identity provider is configured.
+func (c *Config) OidcEnabled() bool {
+	return !c.Public() && c.OidcUri() != "" && c.OidcClient() != ""
+}
+
+// OidcUri returns the OpenID Connect issuer URL.
+func (c *Config) OidcUri() string {
+	return strings.TrimRight(strings.TrimSpace(c.options.OidcUri), "/")
+}
+
+// OidcClient returns the OpenID Connect client ID.
+func (c *Config) OidcClient() string {
+	return strings.TrimSpace(c.options.OidcClient)
+}
+
+// OidcSecret returns the OpenID Connect client secret.
+func (c *Config) OidcSecret() string {
+	return strings.TrimSpace(c.options.OidcSecret)
+}
+
+// OidcScopes returns the requested OpenID Connect scopes.
+func (c *Config) OidcScopes() []string {
+	if scopes := strings.Fields(strings.ReplaceAll(c.options.OidcScopes, ",", " ")); len(scopes) > 0 {
+		return scopes
+	}
+
+	return oidc.DefaultScopes
+}
+
+// OidcRegister tests if user accounts should be created for unknown OpenID Connect users.
+func (c *Config) OidcRegister() bool {
+	return c.options.OidcRegister
+}
+
+// OidcLink tests if existing user accounts may be linked to OpenID Connect users by verified email address.
+func (c *Config) OidcLink() bool {
+	return c.options.OidcLink
+}
+
+// OidcRedirectUrl returns the URL the identity provider redirects to after authentication.
+func (c *Config) OidcRedirectUrl() string {
+	return c.SiteUrl() + strings.TrimLeft(ApiUri, "/") + "/oidc/redirect"
+}
+
+// OidcRoles returns the user roles by identity provider group name in lowercase.
+func (c *Config) OidcRoles() map[string]acl.Role {
+	result := make(map[string]acl.Role)
+
+	for _, s := range strings.Split(c.options.OidcRoles, ",") {
+		group, role, found := strings.Cut(s, "=")
+
+		if !found {
+			continue
+		}
+
+		group = strings.ToLower(strings.TrimSpace(group))
+		role = strings.ToLower(strings.TrimSpace(role))
+
+		if group == "" {
+			continue
+		}
+
+		for _, r := range OidcRolePriority {
+			if string(r) == role {
+				result[group] = r
+			}
+		}
+	}
+
+	return result
+}
+
+// OidcRole returns the user role for the identity provider groups, and false if none of them is mapped to a role.
+func (c *Config) OidcRole(groups []string) (acl.Role, bool) {
+	roles := c.OidcRoles()
+	found := make(map[acl.Role]bool)
+
+	for _, g := range groups {
+		if r, ok := roles[strings.ToLower(g)]; ok {
+			found[r] = true
+		}
+	}
+
+	for _, r := range OidcRolePriority {
+		if found[r] {
+			return r, true
+		}
+	}
+
+	return acl.RoleDefault, false
+}
+
+// OidcOptions returns the OpenID Connect client options.
+func (c *Config) OidcOptions() oidc.Options {
+	return oidc.Options{
+		Issuer:       c.OidcUri(),
+		ClientID:     c.OidcClient(),
+		ClientSecret: c.OidcSecret(),
+		RedirectUrl:  c.OidcRedirectUrl(),
+		Scopes:       c.OidcScopes(),
+	}
+}
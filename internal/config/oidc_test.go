@@ -0,0 +1,75 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+func TestConfig_OidcEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.OidcEnabled())
+
+	c.options.OidcUri = "https://accounts.example.com/"
+	assert.False(t, c.OidcEnabled())
+
+	c.options.OidcClient = "photoprism"
+	assert.True(t, c.OidcEnabled())
+	assert.Equal(t, "https://accounts.example.com", c.OidcUri())
+}
+
+func TestConfig_OidcScopes(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, oidc.DefaultScopes, c.OidcScopes())
+
+	c.options.OidcScopes = "openid, email groups"
+	assert.Equal(t, []string{"openid", "email", "groups"}, c.OidcScopes())
+}
+
+func TestConfig_OidcLink(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.OidcLink())
+
+	c.options.OidcLink = true
+	assert.True(t, c.OidcLink())
+}
+
+func TestConfig_OidcRedirectUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, "http://localhost:2342/api/v1/oidc/redirect", c.OidcRedirectUrl())
+
+	c.options.SiteUrl = "https://photos.example.com/"
+	assert.Equal(t, "https://photos.example.com/api/v1/oidc/redirect", c.OidcRedirectUrl())
+}
+
+func TestConfig_OidcRoles(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Len(t, c.OidcRoles(), 0)
+
+	c.options.OidcRoles = "Admins=admin, family = Family,friends=friend,foo=bar,=guest,invalid"
+	assert.Equal(t, map[string]acl.Role{"admins": acl.RoleAdmin, "family": acl.RoleFamily, "friends": acl.RoleFriend}, c.OidcRoles())
+}
+
+func TestConfig_OidcRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	c.options.OidcRoles = "admins=admin,family=family,friends=friend"
+
+	t.Run("Admin", func(t *testing.T) {
+		role, ok := c.OidcRole([]string{"friends", "Admins"})
+		assert.True(t, ok)
+		assert.Equal(t, acl.RoleAdmin, role)
+	})
+	t.Run("Friend", func(t *testing.T) {
+		role, ok := c.OidcRole([]string{"friends", "staff"})
+		assert.True(t, ok)
+		assert.Equal(t, acl.RoleFriend, role)
+	})
+	t.Run("None", func(t *testing.T) {
+		role, ok := c.OidcRole([]string{"staff"})
+		assert.False(t, ok)
+		assert.Equal(t, acl.RoleDefault, role)
+	})
+}
@@ -19,7 +19,7 @@ import (
 const (
 	MySQL           = "mysql"
 	MariaDB         = "mariadb"
-	Postgres        = "postgres" // TODO: Requires GORM 2.0 for generic column data types
+	Postgres        = "postgres"
 	SQLite3         = "sqlite3"
 	SQLiteTestDB    = ".test.db"
 	SQLiteMemoryDSN = ":memory:"
@@ -95,6 +95,16 @@ type Options struct {
 	SitePreview           string  `yaml:"SitePreview" json:"SitePreview" flag:"site-preview"`
 	Imprint               string  `yaml:"Imprint" json:"Imprint" flag:"imprint"`
 	ImprintUrl            string  `yaml:"ImprintUrl" json:"ImprintUrl" flag:"imprint-url"`
+	OidcUri               string  `yaml:"OidcUri" json:"-" flag:"oidc-uri"`
+	OidcClient            string  `yaml:"OidcClient" json:"-" flag:"oidc-client"`
+	OidcSecret            string  `yaml:"OidcSecret" json:"-" flag:"oidc-secret"`
+	OidcScopes            string  `yaml:"OidcScopes" json:"-" flag:"oidc-scopes"`
+	OidcRegister          bool    `yaml:"OidcRegister" json:"-" flag:"oidc-register"`
+	OidcLink              bool    `yaml:"OidcLink" json:"-" flag:"oidc-link"`
+	OidcRoles             string  `yaml:"OidcRoles" json:"-" flag:"oidc-roles"`
+	AuthAttempts          int     `yaml:"AuthAttempts" json:"-" flag:"auth-attempts"`
+	AuthBackoff           int     `yaml:"AuthBackoff" json:"-" flag:"auth-backoff"`
+	AuthLockout           int     `yaml:"AuthLockout" json:"-" flag:"auth-lockout"`
 	DatabaseDriver        string  `yaml:"DatabaseDriver" json:"-" flag:"database-driver"`
 	DatabaseDsn           string  `yaml:"DatabaseDsn" json:"-" flag:"database-dsn"`
 	DatabaseServer        string  `yaml:"DatabaseServer" json:"-" flag:"database-server"`
@@ -128,6 +138,9 @@ type Options struct {
 	ThumbSizeUncached     int     `yaml:"ThumbSizeUncached" json:"ThumbSizeUncached" flag:"thumb-size-uncached"`
 	JpegSize              int     `yaml:"JpegSize" json:"JpegSize" flag:"jpeg-size"`
 	JpegQuality           string  `yaml:"JpegQuality" json:"JpegQuality" flag:"jpeg-quality"`
+	FaceDetector          string  `yaml:"FaceDetector" json:"-" flag:"face-detector"`
+	FaceDetectorUrl       string  `yaml:"FaceDetectorUrl" json:"-" flag:"face-detector-url"`
+	FaceDetectorTimeout   int     `yaml:"FaceDetectorTimeout" json:"-" flag:"face-detector-timeout"`
 	FaceSize              int     `yaml:"-" json:"-" flag:"face-size"`
 	FaceScore             float64 `yaml:"-" json:"-" flag:"face-score"`
 	FaceOverlap           int     `yaml:"-" json:"-" flag:"face-overlap"`
@@ -137,9 +150,19 @@ type Options struct {
 	FaceClusterSample     int     `yaml:"-" json:"-" flag:"face-cluster-sample"`
 	FaceClusterDist       float64 `yaml:"-" json:"-" flag:"face-cluster-dist"`
 	FaceMatchDist         float64 `yaml:"-" json:"-" flag:"face-match-dist"`
+	OcrEngine             string  `yaml:"OcrEngine" json:"-" flag:"ocr-engine"`
+	OcrUrl                string  `yaml:"OcrUrl" json:"-" flag:"ocr-url"`
+	OcrTimeout            int     `yaml:"OcrTimeout" json:"-" flag:"ocr-timeout"`
+	OcrRetries            int     `yaml:"OcrRetries" json:"-" flag:"ocr-retries"`
+	OcrBin                string  `yaml:"OcrBin" json:"-" flag:"ocr-bin"`
+	OcrLanguage           string  `yaml:"OcrLanguage" json:"-" flag:"ocr-language"`
+	ClipEngine            string  `yaml:"ClipEngine" json:"-" flag:"clip-engine"`
+	ClipModel             string  `yaml:"ClipModel" json:"-" flag:"clip-model"`
+	ClipUrl               string  `yaml:"ClipUrl" json:"-" flag:"clip-url"`
+	ClipBin               string  `yaml:"ClipBin" json:"-" flag:"clip-bin"`
+	ClipTimeout           int     `yaml:"ClipTimeout" json:"-" flag:"clip-timeout"`
 	PIDFilename           string  `yaml:"PIDFilename" json:"-" flag:"pid-filename"`
 	LogFilename           string  `yaml:"LogFilename" json:"-" flag:"log-filename"`
-	ExportCommand         string  `yaml:"ExportCommand" json:"-" flag:"export-command"`
 }
 
 // NewOptions creates a new configuration entity by using two methods:
@@ -27,10 +27,13 @@ type Accounts []Account
 // Account represents a remote service account for uploading, downloading or syncing media files.
 //
 // Field Descriptions:
+// - AccKey holds the region of S3 accounts, it is derived from the service URL if empty, or the private key file of SFTP accounts.
+// - AccHostKey holds the pinned host key fingerprint of SFTP accounts, it is set on service discovery.
 // - AccTimeout configures the timeout for requests, options: "", high, medium, low, none.
 // - AccErrors holds the number of connection errors since the last reset.
 // - AccShare enables manual upload, see SharePath, ShareSize, and ShareExpires.
 // - AccSync enables automatic file synchronization, see SyncDownload and SyncUpload.
+// - SyncDownload and SyncUpload together enable two-way sync, which requires SyncFilenames.
 // - RetryLimit specifies the number of retry attempts, a negative value disables the limit.
 type Account struct {
 	ID            uint   `gorm:"primary_key"`
@@ -41,6 +44,7 @@ type Account struct {
 	AccKey        string `gorm:"type:VARBINARY(255);"`
 	AccUser       string `gorm:"type:VARBINARY(255);"`
 	AccPass       string `gorm:"type:VARBINARY(255);"`
+	AccHostKey    string `gorm:"type:VARBINARY(255);"`
 	AccTimeout    string `gorm:"type:VARBINARY(16);"`
 	AccError      string `gorm:"type:VARBINARY(512);"`
 	AccErrors     int
@@ -139,14 +143,18 @@ func (m *Account) SaveForm(form form.Account) error {
 		return err
 	}
 
-	// TODO: Support for other remote services in addition to WebDAV.
-	if m.AccType != remote.ServiceWebDAV {
+	// TODO: Support for other remote services in addition to WebDAV, S3, SFTP, and local folders.
+	switch m.AccType {
+	case remote.ServiceWebDAV:
+	case remote.ServiceS3, remote.ServiceSFTP, remote.ServiceLocal:
+		m.AccShare = false // Manual upload is not supported yet.
+	default:
 		m.AccShare = false // Disable manual upload.
 		m.AccSync = false  // Disable background sync.
 	}
 
-	// Prevent two-way sync, see https://github.com/photoprism/photoprism/issues/1785
-	if m.SyncUpload && m.SyncDownload {
+	// Two-way sync requires original file names, see https://github.com/photoprism/photoprism/issues/1785
+	if m.SyncUpload && m.SyncDownload && !(m.AccSync && m.SyncFilenames) {
 		m.SyncUpload = false
 	}
 
@@ -193,9 +201,19 @@ func (m *Account) Delete() error {
 
 // Directories returns a list of directories or albums in an account.
 func (m *Account) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == remote.ServiceWebDAV {
-		client := webdav.New(m.AccURL, m.AccUser, m.AccPass, webdav.Timeout(m.AccTimeout))
+	// Other services have no folders.
+	if !remote.Supported(m.AccType) {
+		return result, nil
+	}
+
+	var client remote.Client
+
+	if client, err = m.Client(); err == nil {
 		result, err = client.Directories("/", true, 0)
+
+		if closeErr := remote.CloseClient(client); closeErr != nil {
+			log.Debugf("account: %s", closeErr)
+		}
 	}
 
 	// Sort directory list.
@@ -209,6 +227,24 @@ func (m *Account) Directories() (result fs.FileInfos, err error) {
 	return result, err
 }
 
+// Client returns a client for the remote service.
+func (m *Account) Client() (remote.Client, error) {
+	return remote.NewClient(remote.Account{
+		AccName:    m.AccName,
+		AccURL:     m.AccURL,
+		AccType:    m.AccType,
+		AccKey:     m.AccKey,
+		AccUser:    m.AccUser,
+		AccPass:    m.AccPass,
+		AccHostKey: m.AccHostKey,
+	}, webdav.Timeout(m.AccTimeout))
+}
+
+// TwoWay tests if files are synchronized in both directions.
+func (m *Account) TwoWay() bool {
+	return m.AccSync && m.SyncUpload && m.SyncDownload && m.SyncFilenames
+}
+
 // Updates multiple columns in the database.
 func (m *Account) Updates(values interface{}) error {
 	return UnscopedDb().Model(m).UpdateColumns(values).Error
@@ -91,6 +91,36 @@ func TestAccount_SaveForm(t *testing.T) {
 		assert.Equal(t, "NewOwner", model.AccOwner)
 		assert.Equal(t, "new.com", model.AccURL)
 	})
+	t.Run("TwoWay", func(t *testing.T) {
+		account := Account{AccName: "TwoWay", AccURL: "http://dummy-webdav/", AccType: "webdav", AccSync: true,
+			SyncUpload: true, SyncDownload: true, SyncFilenames: true}
+
+		accountForm, err := form.NewAccount(account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := CreateAccount(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, model.SyncDownload)
+		assert.True(t, model.SyncUpload)
+		assert.True(t, model.TwoWay())
+
+		accountForm.SyncFilenames = false
+
+		if err = model.SaveForm(accountForm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, model.SyncDownload)
+		assert.False(t, model.SyncUpload)
+		assert.False(t, model.TwoWay())
+	})
 }
 
 func TestAccount_Delete(t *testing.T) {
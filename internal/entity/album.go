@@ -33,6 +33,7 @@ type Album struct {
 	ID               uint        `gorm:"primary_key" json:"ID" yaml:"-"`
 	AlbumUID         string      `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
 	ParentUID        string      `gorm:"type:VARBINARY(42);default:'';" json:"ParentUID,omitempty" yaml:"ParentUID,omitempty"`
+	OwnerUID         string      `gorm:"type:VARBINARY(42);index;default:'';" json:"OwnerUID,omitempty" yaml:"OwnerUID,omitempty"`
 	AlbumSlug        string      `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug"`
 	AlbumPath        string      `gorm:"type:VARBINARY(500);index;" json:"Path,omitempty" yaml:"Path,omitempty"`
 	AlbumType        string      `gorm:"type:VARBINARY(8);default:'album';" json:"Type" yaml:"Type,omitempty"`
@@ -369,6 +370,18 @@ func (m *Album) IsDefault() bool {
 	return m.AlbumType == AlbumDefault
 }
 
+// SetOwner changes the user who owns the album, the UID must be valid or empty.
+func (m *Album) SetOwner(userUID string) {
+	if userUID == "" || rnd.IsPPID(userUID, 'u') {
+		m.OwnerUID = userUID
+	}
+}
+
+// OwnedBy tests if the album is owned by the specified user.
+func (m *Album) OwnedBy(userUID string) bool {
+	return userUID != "" && m.OwnerUID == userUID
+}
+
 // SetTitle changes the album name.
 func (m *Album) SetTitle(title string) {
 	title = strings.Trim(title, "_&|{}<>: \n\r\t\\")
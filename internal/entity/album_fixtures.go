@@ -76,6 +76,7 @@ var AlbumFixtures = AlbumMap{
 	"berlin-2019": {
 		ID:               1000002,
 		AlbumUID:         "at9lxuqxpogaaba9",
+		OwnerUID:         "uqxc08w3d0ej2283",
 		AlbumSlug:        "berlin-2019",
 		AlbumPath:        "",
 		AlbumType:        AlbumDefault,
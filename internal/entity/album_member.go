@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	MemberRoleViewer      = "viewer"
+	MemberRoleContributor = "contributor"
+)
+
+type AlbumMembers []AlbumMember
+
+// AlbumMember represents a registered user an album is shared with.
+type AlbumMember struct {
+	AlbumUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"AlbumUID" yaml:"-"`
+	UserUID    string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"UserUID" yaml:"UserUID"`
+	MemberRole string    `gorm:"type:VARBINARY(16);default:'viewer';" json:"Role" yaml:"Role"`
+	CreatedAt  time.Time `json:"CreatedAt" yaml:"CreatedAt,omitempty"`
+	UpdatedAt  time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (AlbumMember) TableName() string {
+	return "album_members"
+}
+
+// ValidMemberRole tests if the string is a supported album member role.
+func ValidMemberRole(role string) bool {
+	return role == MemberRoleViewer || role == MemberRoleContributor
+}
+
+// NewAlbumMember returns a new album member, unknown roles default to viewer.
+func NewAlbumMember(albumUID, userUID, role string) *AlbumMember {
+	if !ValidMemberRole(role) {
+		role = MemberRoleViewer
+	}
+
+	return &AlbumMember{
+		AlbumUID:   albumUID,
+		UserUID:    userUID,
+		MemberRole: role,
+	}
+}
+
+// Contributor tests if the member may add pictures to the album.
+func (m *AlbumMember) Contributor() bool {
+	return m.MemberRole == MemberRoleContributor
+}
+
+// Save updates or inserts a row.
+func (m *AlbumMember) Save() error {
+	if m.AlbumUID == "" || m.UserUID == "" {
+		return fmt.Errorf("album and user uid must not be empty")
+	} else if !ValidMemberRole(m.MemberRole) {
+		return fmt.Errorf("invalid member role %s", m.MemberRole)
+	}
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the member from the album.
+func (m *AlbumMember) Delete() error {
+	return Db().Where("album_uid = ? AND user_uid = ?", m.AlbumUID, m.UserUID).Delete(&AlbumMember{}).Error
+}
+
+// FindAlbumMember returns the membership of a user in an album, or nil if the album wasn't shared with them.
+func FindAlbumMember(albumUID, userUID string) *AlbumMember {
+	if albumUID == "" || userUID == "" {
+		return nil
+	}
+
+	result := AlbumMember{}
+
+	if err := Db().Where("album_uid = ? AND user_uid = ?", albumUID, userUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindAlbumMembers returns all users an album is shared with.
+func FindAlbumMembers(albumUID string) (result AlbumMembers, err error) {
+	err = Db().Where("album_uid = ?", albumUID).Order("created_at").Find(&result).Error
+
+	return result, err
+}
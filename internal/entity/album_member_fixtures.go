@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+type AlbumMemberMap map[string]AlbumMember
+
+func (m AlbumMemberMap) Get(name string) AlbumMember {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return AlbumMember{}
+}
+
+func (m AlbumMemberMap) Pointer(name string) *AlbumMember {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &AlbumMember{}
+}
+
+var AlbumMemberFixtures = AlbumMemberMap{
+	"christmas2030_bob": {
+		AlbumUID:   "at9lxuqxpogaaba7",
+		UserUID:    "uqxc08w3d0ej2283",
+		MemberRole: MemberRoleViewer,
+		CreatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"holiday2030_bob": {
+		AlbumUID:   "at9lxuqxpogaaba8",
+		UserUID:    "uqxc08w3d0ej2283",
+		MemberRole: MemberRoleContributor,
+		CreatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+}
+
+// CreateAlbumMemberFixtures inserts known entities into the database for testing.
+func CreateAlbumMemberFixtures() {
+	for _, entity := range AlbumMemberFixtures {
+		Db().Create(&entity)
+	}
+}
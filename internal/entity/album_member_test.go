@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAlbumMember(t *testing.T) {
+	t.Run("Contributor", func(t *testing.T) {
+		m := NewAlbumMember("at9lxuqxpogaaba9", "uqxetse3cy5eo9z2", MemberRoleContributor)
+		assert.Equal(t, MemberRoleContributor, m.MemberRole)
+		assert.True(t, m.Contributor())
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		m := NewAlbumMember("at9lxuqxpogaaba9", "uqxetse3cy5eo9z2", "admin")
+		assert.Equal(t, MemberRoleViewer, m.MemberRole)
+		assert.False(t, m.Contributor())
+	})
+}
+
+func TestAlbumMember_Save(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewAlbumMember("at9lxuqxpogaaba9", "uqxetse3cy5eo9z2", MemberRoleViewer)
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		m.MemberRole = MemberRoleContributor
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindAlbumMember("at9lxuqxpogaaba9", "uqxetse3cy5eo9z2")
+
+		if found == nil {
+			t.Fatal("member should not be nil")
+		}
+
+		assert.True(t, found.Contributor())
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindAlbumMember("at9lxuqxpogaaba9", "uqxetse3cy5eo9z2"))
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		m := NewAlbumMember("", "uqxetse3cy5eo9z2", MemberRoleViewer)
+		assert.Error(t, m.Save())
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		m := AlbumMember{AlbumUID: "at9lxuqxpogaaba9", UserUID: "uqxetse3cy5eo9z2", MemberRole: "owner"}
+		assert.Error(t, m.Save())
+	})
+}
+
+func TestFindAlbumMember(t *testing.T) {
+	t.Run("Viewer", func(t *testing.T) {
+		m := FindAlbumMember("at9lxuqxpogaaba7", "uqxc08w3d0ej2283")
+
+		if m == nil {
+			t.Fatal("member should not be nil")
+		}
+
+		assert.False(t, m.Contributor())
+	})
+	t.Run("Contributor", func(t *testing.T) {
+		m := FindAlbumMember("at9lxuqxpogaaba8", "uqxc08w3d0ej2283")
+
+		if m == nil {
+			t.Fatal("member should not be nil")
+		}
+
+		assert.True(t, m.Contributor())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindAlbumMember("at9lxuqxpogaaba9", "uqxc08w3d0ej2283"))
+		assert.Nil(t, FindAlbumMember("", ""))
+	})
+}
+
+func TestFindAlbumMembers(t *testing.T) {
+	members, err := FindAlbumMembers("at9lxuqxpogaaba8")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, members, 1)
+	assert.Equal(t, "uqxc08w3d0ej2283", members[0].UserUID)
+}
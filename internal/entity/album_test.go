@@ -572,3 +572,12 @@ func TestFindFolderAlbum(t *testing.T) {
 		}
 	})
 }
+
+func TestAlbum_SetOwner(t *testing.T) {
+	m := NewAlbum("Owned", AlbumDefault)
+	assert.False(t, m.OwnedBy("uqxc08w3d0ej2283"))
+	m.SetOwner("uqxc08w3d0ej2283")
+	assert.True(t, m.OwnedBy("uqxc08w3d0ej2283"))
+	m.SetOwner("invalid")
+	assert.Equal(t, "uqxc08w3d0ej2283", m.OwnerUID)
+}
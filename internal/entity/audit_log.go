@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Audit log actions.
+const (
+	AuditPhotosArchive  = "photos.archive"
+	AuditPhotosRestore  = "photos.restore"
+	AuditPhotosPrivate  = "photos.private"
+	AuditPhotosDelete   = "photos.delete"
+	AuditFilesDelete    = "files.delete"
+	AuditAlbumsUpdate   = "albums.update"
+	AuditAlbumsDelete   = "albums.delete"
+	AuditAlbumsShare    = "albums.share"
+	AuditAlbumsUnshare  = "albums.unshare"
+	AuditLinksCreate    = "links.create"
+	AuditLinksUpdate    = "links.update"
+	AuditLinksDelete    = "links.delete"
+	AuditSettingsUpdate = "settings.update"
+	AuditConfigUpdate   = "config.update"
+	AuditAuthLockout    = "auth.lockout"
+	AuditAuth2faEnable  = "auth.2fa.enable"
+	AuditAuth2faDisable = "auth.2fa.disable"
+	AuditSyncResolve    = "sync.resolve"
+)
+
+// AuditMask replaces values of secret fields in audit log diffs.
+const AuditMask = "********"
+
+type AuditLogs []AuditLog
+
+// AuditLog represents a destructive or sharing action performed by a user.
+type AuditLog struct {
+	ID        uint      `gorm:"primary_key" json:"ID" yaml:"ID"`
+	UserUID   string    `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID,omitempty"`
+	UserName  string    `gorm:"size:64;" json:"UserName" yaml:"UserName,omitempty"`
+	SessUID   string    `gorm:"type:VARBINARY(42);" json:"SessUID" yaml:"SessUID,omitempty"`
+	ClientIP  string    `gorm:"type:VARBINARY(64);" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	Action    string    `gorm:"type:VARBINARY(32);index;" json:"Action" yaml:"Action"`
+	EntityUID string    `gorm:"type:VARBINARY(255);index;" json:"EntityUID" yaml:"EntityUID,omitempty"`
+	Diff      string    `gorm:"type:TEXT;" json:"Diff" yaml:"Diff,omitempty"`
+	CreatedAt time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
+
+// NewAuditLog returns a new audit log entry for the user.
+func NewAuditLog(u *User, action, entityUID, diff string) *AuditLog {
+	m := &AuditLog{
+		Action:    action,
+		EntityUID: entityUID,
+		Diff:      diff,
+		CreatedAt: TimeStamp(),
+	}
+
+	if u != nil {
+		m.UserUID = u.UserUID
+		m.UserName = u.UserName
+	}
+
+	return m
+}
+
+// SetClient sets the session uid and ip address of the client that performed the action.
+func (m *AuditLog) SetClient(sessUID, ip string) *AuditLog {
+	m.SessUID = sessUID
+	m.ClientIP = ip
+
+	return m
+}
+
+// Create inserts a new row to the database.
+func (m *AuditLog) Create() error {
+	return Db().Create(m).Error
+}
+
+// AddAuditLog records the action for each of the specified entity uids.
+func AddAuditLog(u *User, sessUID, ip, action string, uids []string, diff string) {
+	for _, uid := range uids {
+		if err := NewAuditLog(u, action, uid, diff).SetClient(sessUID, ip).Create(); err != nil {
+			log.Errorf("audit: %s (add %s)", err, action)
+		}
+	}
+}
+
+// AuditDiff returns the changed values as JSON, with nested values flattened to dot-separated keys.
+// Values of keys that look like passwords, secrets, or tokens are masked.
+func AuditDiff(before, after interface{}) string {
+	from, to := auditValues(before), auditValues(after)
+
+	changes := make(map[string][2]interface{})
+
+	for k, v := range to {
+		if old, ok := from[k]; !ok || !reflect.DeepEqual(old, v) {
+			changes[k] = [2]interface{}{old, v}
+		}
+	}
+
+	for k, old := range from {
+		if _, ok := to[k]; !ok {
+			changes[k] = [2]interface{}{old, nil}
+		}
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	for k := range changes {
+		if auditSecret(k) {
+			changes[k] = [2]interface{}{AuditMask, AuditMask}
+		}
+	}
+
+	if result, err := json.Marshal(changes); err != nil {
+		log.Warnf("audit: %s", err)
+		return ""
+	} else {
+		return string(result)
+	}
+}
+
+// auditValues returns a flat map of the values in s.
+func auditValues(s interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	if s == nil {
+		return result
+	}
+
+	data, err := json.Marshal(s)
+
+	if err != nil {
+		log.Warnf("audit: %s", err)
+		return result
+	}
+
+	var values map[string]interface{}
+
+	if err = json.Unmarshal(data, &values); err != nil {
+		log.Warnf("audit: %s", err)
+		return result
+	}
+
+	auditFlatten("", values, result)
+
+	return result
+}
+
+// auditFlatten adds the values to result, using dot-separated keys for nested values.
+func auditFlatten(prefix string, values, result map[string]interface{}) {
+	for k, v := range values {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+
+		if nested, ok := v.(map[string]interface{}); ok {
+			auditFlatten(k, nested, result)
+		} else {
+			result[k] = v
+		}
+	}
+}
+
+// auditSecret tests if the key refers to a secret value that must not be logged.
+func auditSecret(key string) bool {
+	key = strings.ToLower(key)
+
+	return strings.Contains(key, "password") || strings.Contains(key, "secret") || strings.Contains(key, "token")
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditDiff(t *testing.T) {
+	t.Run("Changed", func(t *testing.T) {
+		before := map[string]interface{}{"Title": "Berlin", "Private": false, "UI": map[string]interface{}{"Theme": "default"}}
+		after := map[string]interface{}{"Title": "Berlin", "Private": true, "UI": map[string]interface{}{"Theme": "lavender"}}
+
+		assert.JSONEq(t, `{"Private":[false,true],"UI.Theme":["default","lavender"]}`, AuditDiff(before, after))
+	})
+	t.Run("Created", func(t *testing.T) {
+		assert.JSONEq(t, `{"Title":[null,"Berlin"]}`, AuditDiff(nil, map[string]string{"Title": "Berlin"}))
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		assert.JSONEq(t, `{"Title":["Berlin",null]}`, AuditDiff(map[string]string{"Title": "Berlin"}, nil))
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		assert.Equal(t, "", AuditDiff(map[string]string{"Title": "Berlin"}, map[string]string{"Title": "Berlin"}))
+	})
+	t.Run("Secret", func(t *testing.T) {
+		diff := AuditDiff(map[string]string{"AdminPassword": "insecure"}, map[string]string{"AdminPassword": "photoprism"})
+
+		assert.JSONEq(t, `{"AdminPassword":["********","********"]}`, diff)
+		assert.NotContains(t, diff, "photoprism")
+	})
+}
+
+func TestAddAuditLog(t *testing.T) {
+	alice := UserFixtures.Pointer("alice")
+
+	AddAuditLog(alice, "estr4jv2xvwrt9wx", "127.0.0.1", AuditPhotosArchive, []string{"pt9jtdre2lvl0yh7", "pt9jtdre2lvl0yh8"}, "")
+
+	var result AuditLogs
+
+	if err := Db().Where("action = ? AND user_uid = ?", AuditPhotosArchive, alice.UserUID).Order("id").Find(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.GreaterOrEqual(t, len(result), 2) {
+		m := result[len(result)-1]
+		assert.Equal(t, "alice", m.UserName)
+		assert.Equal(t, "estr4jv2xvwrt9wx", m.SessUID)
+		assert.Equal(t, "127.0.0.1", m.ClientIP)
+		assert.Equal(t, "pt9jtdre2lvl0yh8", m.EntityUID)
+	}
+}
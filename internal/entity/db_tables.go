@@ -33,16 +33,27 @@ var Entities = Tables{
 	"countries":                     &Country{},
 	"albums":                        &Album{},
 	"photos_albums":                 &PhotoAlbum{},
+	AlbumMember{}.TableName():       &AlbumMember{},
 	"labels":                        &Label{},
 	"categories":                    &Category{},
 	"photos_labels":                 &PhotoLabel{},
 	"keywords":                      &Keyword{},
 	"photos_keywords":               &PhotoKeyword{},
+	PhotoText{}.TableName():         &PhotoText{},
+	PhotoEmbedding{}.TableName():    &PhotoEmbedding{},
 	"passwords":                     &Password{},
+	UserToken{}.TableName():         &UserToken{},
+	UserTotp{}.TableName():          &UserTotp{},
+	UserSession{}.TableName():       &UserSession{},
+	AuditLog{}.TableName():          &AuditLog{},
+	"users_recovery_codes":          &UserRecoveryCode{},
 	"links":                         &Link{},
+	LinkAccess{}.TableName():        &LinkAccess{},
+	LinkUpload{}.TableName():        &LinkUpload{},
 	Subject{}.TableName():           &Subject{},
 	Face{}.TableName():              &Face{},
 	Marker{}.TableName():            &Marker{},
+	Tombstone{}.TableName():         &Tombstone{},
 }
 
 // WaitForMigration waits for the database migration to be successful.
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+)
+
+// postgresTypes maps the MySQL column types used in struct tags to PostgreSQL types.
+var postgresTypes = []struct {
+	pattern *regexp.Regexp
+	replace string
+}{
+	{regexp.MustCompile(`(?i)^VARBINARY\s*\(`), "VARCHAR("},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)?BLOB\b`), "BYTEA"},
+	{regexp.MustCompile(`(?i)^(TINY|MEDIUM|LONG)TEXT\b`), "TEXT"},
+	{regexp.MustCompile(`(?i)^DATETIME\b`), "TIMESTAMP"},
+	{regexp.MustCompile(`(?i)^TINYINT\b(\s*\(\d+\))?`), "SMALLINT"},
+	{regexp.MustCompile(`(?i)^FLOAT\b`), "REAL"},
+}
+
+// postgresBase is the dialect registered by GORM for PostgreSQL.
+var postgresBase = gorm.Dialect(nil)
+
+func init() {
+	if d, ok := gorm.GetDialect(Postgres); ok {
+		postgresBase = d
+		gorm.RegisterDialect(Postgres, &postgresDialect{})
+	}
+}
+
+// postgresDialect extends the GORM PostgreSQL dialect so that the MySQL column types
+// in struct tags can be used with PostgreSQL as well.
+type postgresDialect struct {
+	gorm.Dialect
+}
+
+// SetDB creates a new instance of the base dialect and sets the database connection.
+func (d *postgresDialect) SetDB(db gorm.SQLCommon) {
+	d.Dialect = reflect.New(reflect.TypeOf(postgresBase).Elem()).Interface().(gorm.Dialect)
+	d.Dialect.SetDB(db)
+}
+
+// DataTypeOf returns the PostgreSQL column type of a struct field.
+func (d *postgresDialect) DataTypeOf(field *gorm.StructField) string {
+	return PostgresType(d.Dialect.DataTypeOf(field))
+}
+
+// PostgresType converts a MySQL column type to the matching PostgreSQL type.
+func PostgresType(sqlType string) string {
+	sqlType = strings.TrimSpace(sqlType)
+
+	for _, t := range postgresTypes {
+		if t.pattern.MatchString(sqlType) {
+			return t.pattern.ReplaceAllString(sqlType, t.replace)
+		}
+	}
+
+	return sqlType
+}
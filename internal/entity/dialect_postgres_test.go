@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresType(t *testing.T) {
+	t.Run("Varbinary", func(t *testing.T) {
+		assert.Equal(t, "VARCHAR(42)", PostgresType("VARBINARY(42)"))
+		assert.Equal(t, "VARCHAR(42) NOT NULL", PostgresType("VARBINARY(42) NOT NULL"))
+	})
+	t.Run("Blob", func(t *testing.T) {
+		assert.Equal(t, "BYTEA", PostgresType("MEDIUMBLOB"))
+	})
+	t.Run("Text", func(t *testing.T) {
+		assert.Equal(t, "TEXT", PostgresType("LONGTEXT"))
+	})
+	t.Run("Datetime", func(t *testing.T) {
+		assert.Equal(t, "TIMESTAMP", PostgresType("DATETIME"))
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		assert.Equal(t, "VARCHAR(160)", PostgresType("VARCHAR(160)"))
+		assert.Equal(t, "boolean", PostgresType("boolean"))
+	})
+}
@@ -232,7 +232,7 @@ func (m *Face) ReviseMatches() (revised Markers, err error) {
 func (m *Face) MatchMarkers(faceIds []string) error {
 	var markers Markers
 
-	stmt := Db().Where("marker_invalid = 0 AND marker_type = ?", MarkerFace)
+	stmt := Db().Where("marker_invalid = FALSE AND marker_type = ?", MarkerFace)
 	if len(faceIds) == 1 && faceIds[0] == "" {
 		stmt = stmt.Where("subj_uid =''")
 	} else {
@@ -290,7 +290,7 @@ func (m *Face) SetSubjectUID(subjUID string) (err error) {
 		Where("face_id = ?", m.ID).
 		Where("subj_src = ?", SrcAuto).
 		Where("subj_uid <> ?", m.SubjUID).
-		Where("marker_invalid = 0").
+		Where("marker_invalid = FALSE").
 		UpdateColumns(Values{"subj_uid": m.SubjUID, "marker_review": false}).Error; err != nil {
 		return err
 	}
@@ -440,7 +440,7 @@ func ValidFaceCount(fileUID string) (c int) {
 
 	if err := Db().Model(Marker{}).
 		Where("file_uid = ? AND marker_type = ?", fileUID, MarkerFace).
-		Where("marker_invalid = 0").
+		Where("marker_invalid = FALSE").
 		Count(&c).Error; err != nil {
 		log.Errorf("file: %s (count faces)", err)
 		return 0
@@ -17,6 +17,7 @@ import (
 
 	"github.com/photoprism/photoprism/pkg/colors"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/phash"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
@@ -71,6 +72,8 @@ type File struct {
 	FileLuminance    string        `gorm:"type:VARBINARY(9);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff         uint32        `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma       uint8         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FilePHash        string        `gorm:"column:file_phash;type:VARBINARY(16);index;" json:"PHash,omitempty" yaml:"PHash,omitempty"`
+	FileDHash        string        `gorm:"column:file_dhash;type:VARBINARY(16);index;" json:"DHash,omitempty" yaml:"DHash,omitempty"`
 	FileError        string        `gorm:"type:VARBINARY(512)" json:"Error" yaml:"Error,omitempty"`
 	ModTime          int64         `json:"ModTime" yaml:"-"`
 	CreatedAt        time.Time     `json:"CreatedAt" yaml:"-"`
@@ -114,7 +117,7 @@ func FirstFileByHash(fileHash string) (File, error) {
 func PrimaryFile(photoUID string) (*File, error) {
 	file := File{}
 
-	res := Db().Unscoped().First(&file, "file_primary = 1 AND photo_uid = ?", photoUID)
+	res := Db().Unscoped().First(&file, "file_primary = TRUE AND photo_uid = ?", photoUID)
 
 	return &file, res.Error
 }
@@ -300,14 +303,14 @@ func (m *File) Purge() error {
 	m.FileMissing = true
 	m.FilePrimary = false
 	m.DeletedAt = &deletedAt
-	return UnscopedDb().Exec("UPDATE files SET file_missing = 1, file_primary = 0, deleted_at = ? WHERE id = ?", &deletedAt, m.ID).Error
+	return UnscopedDb().Exec("UPDATE files SET file_missing = TRUE, file_primary = FALSE, deleted_at = ? WHERE id = ?", &deletedAt, m.ID).Error
 }
 
 // Found restores a previously purged file.
 func (m *File) Found() error {
 	m.FileMissing = false
 	m.DeletedAt = nil
-	return UnscopedDb().Exec("UPDATE files SET file_missing = 0, deleted_at = NULL WHERE id = ?", m.ID).Error
+	return UnscopedDb().Exec("UPDATE files SET file_missing = FALSE, deleted_at = NULL WHERE id = ?", m.ID).Error
 }
 
 // AllFilesMissing returns true, if all files for the photo of this file are missing.
@@ -315,7 +318,7 @@ func (m *File) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND file_missing = 0", m.PhotoID).
+		Where("photo_id = ? AND file_missing = FALSE", m.PhotoID).
 		Count(&count).Error; err != nil {
 		log.Errorf("file: %s", err.Error())
 	}
@@ -384,7 +387,7 @@ func (m *File) UpdateVideoInfos() error {
 		return err
 	}
 
-	return Db().Model(File{}).Where("photo_id = ? AND file_video = 1", m.PhotoID).Updates(values).Error
+	return Db().Model(File{}).Where("photo_id = ? AND file_video = TRUE", m.PhotoID).Updates(values).Error
 }
 
 // Update updates a column in the database.
@@ -536,6 +539,23 @@ func (m *File) ResetColorProfile() {
 	m.FileColorProfile = ""
 }
 
+// HasPerceptualHash tests if the perceptual image hashes have been computed.
+func (m *File) HasPerceptualHash() bool {
+	return m.FilePHash != "" && m.FileDHash != ""
+}
+
+// SetPerceptualHash sets the perceptual image hashes used to find visually similar photos.
+func (m *File) SetPerceptualHash(pHash, dHash phash.Hash) {
+	m.FilePHash = pHash.String()
+	m.FileDHash = dHash.String()
+}
+
+// ResetPerceptualHash removes the perceptual image hashes.
+func (m *File) ResetPerceptualHash() {
+	m.FilePHash = ""
+	m.FileDHash = ""
+}
+
 // AddFaces adds face markers to the file.
 func (m *File) AddFaces(faces face.Faces) {
 	sort.Slice(faces, func(i, j int) bool {
@@ -54,6 +54,8 @@ var FileFixtures = FileMap{
 		FileLuminance:   "8836BD496",
 		FileDiff:        968,
 		FileChroma:      25,
+		FilePHash:       "c3a5e1f08c3b1d2e",
+		FileDHash:       "9a5b3c7d1e2f4a68",
 		FileError:       "",
 		ModTime:         time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC).Unix(),
 		Share: []FileShare{
@@ -180,6 +182,8 @@ var FileFixtures = FileMap{
 		FileLuminance:   "DC42844C8",
 		FileDiff:        986,
 		FileChroma:      32,
+		FilePHash:       "c3a5e1f08c3b1d29",
+		FileDHash:       "9a5b3c7d1e2f4a6b",
 		FileError:       "",
 		Share:           []FileShare{},
 		Sync:            []FileSync{},
@@ -1,7 +1,10 @@
 package entity
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
 const (
@@ -10,13 +13,23 @@ const (
 	FileSyncExists     = "exists"
 	FileSyncDownloaded = "downloaded"
 	FileSyncUploaded   = "uploaded"
+	FileSyncChanged    = "changed"
+	FileSyncConflict   = "conflict"
+)
+
+// Conflict resolution options.
+const (
+	FileSyncKeepLocal  = "local"
+	FileSyncKeepRemote = "remote"
 )
 
 // FileSync represents a one-to-many relation between File and Account for syncing with remote services.
+// FileHash contains the hash of the local file when it was last synced, so that changes can be detected.
 type FileSync struct {
 	RemoteName string `gorm:"primary_key;auto_increment:false;type:VARBINARY(255)"`
 	AccountID  uint   `gorm:"primary_key;auto_increment:false"`
 	FileID     uint   `gorm:"index;"`
+	FileHash   string `gorm:"type:VARBINARY(128);"`
 	RemoteDate time.Time
 	RemoteSize int64
 	Status     string `gorm:"type:VARBINARY(16);"`
@@ -64,6 +77,17 @@ func (m *FileSync) Create() error {
 	return Db().Create(m).Error
 }
 
+// FindFileSync returns the entity for the remote file name including the local file, or nil if it does not exist.
+func FindFileSync(accountID uint, remoteName string) *FileSync {
+	result := FileSync{}
+
+	if err := Db().Preload("File").Where("account_id = ? AND remote_name = ?", accountID, remoteName).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
 // FirstOrCreateFileSync returns the existing row, inserts a new row or nil in case of errors.
 func FirstOrCreateFileSync(m *FileSync) *FileSync {
 	result := FileSync{}
@@ -77,3 +101,54 @@ func FirstOrCreateFileSync(m *FileSync) *FileSync {
 
 	return m
 }
+
+// RemoteChanged tests if the remote file has been modified since the last sync.
+func (m *FileSync) RemoteChanged(date time.Time, size int64) bool {
+	if m.RemoteSize != size {
+		return true
+	}
+
+	// The upload time is stored for uploaded files, servers may use an earlier modification time.
+	if m.Status == FileSyncUploaded {
+		return date.After(m.RemoteDate)
+	}
+
+	return !m.RemoteDate.Equal(date)
+}
+
+// LocalChanged tests if the local file has been modified since the last sync.
+func (m *FileSync) LocalChanged() bool {
+	if m.FileID == 0 || m.FileHash == "" {
+		return false
+	}
+
+	file := File{}
+
+	if err := Db().Select("file_hash").Where("id = ?", m.FileID).First(&file).Error; err != nil {
+		return false
+	}
+
+	return file.FileHash != m.FileHash
+}
+
+// Resolve resolves a conflict by keeping either the local or the remote file,
+// which is then uploaded or downloaded by the sync worker.
+func (m *FileSync) Resolve(keep string) error {
+	if m.Status != FileSyncConflict {
+		return fmt.Errorf("file-sync: %s is not in conflict", sanitize.Log(m.RemoteName))
+	}
+
+	switch keep {
+	case FileSyncKeepLocal:
+		m.Status = FileSyncChanged
+	case FileSyncKeepRemote:
+		m.Status = FileSyncNew
+	default:
+		return fmt.Errorf("file-sync: invalid resolution %s", sanitize.Log(keep))
+	}
+
+	m.Error = ""
+	m.Errors = 0
+
+	return m.Updates(Values{"Status": m.Status, "Error": m.Error, "Errors": m.Errors})
+}
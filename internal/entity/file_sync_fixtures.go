@@ -65,6 +65,21 @@ var FileSyncFixtures = FileSyncMap{
 		CreatedAt:  time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
 		UpdatedAt:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 	},
+	"FileSync4": {
+		FileID:     1000000,
+		FileHash:   "0d2e6a2b4b3c5a17f9e8d7c6b5a4f3e2d1c0b9a8",
+		AccountID:  1000001,
+		RemoteName: "/20200706-092527-Landscape-Changed-2020.jpg",
+		Status:     "uploaded",
+		Error:      "",
+		Errors:     0,
+		File:       &FileFixturesExampleJPG,
+		Account:    &AccountFixtureWebdavDummy2,
+		RemoteDate: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		RemoteSize: int64(500),
+		CreatedAt:  time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
 }
 
 // CreateFileSyncFixtures inserts known entities into the database for testing.
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -104,3 +105,90 @@ func TestFileSync_Save(t *testing.T) {
 		assert.True(t, afterDate.After(initialDate))
 	})
 }
+
+func TestFindFileSync(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m := FindFileSync(1000000, "/20200706-092527-Landscape-München-2020.jpg")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, FileSyncUploaded, m.Status)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindFileSync(1000000, "/missing.jpg"))
+	})
+}
+
+func TestFileSync_RemoteChanged(t *testing.T) {
+	date := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Downloaded", func(t *testing.T) {
+		m := FileSync{Status: FileSyncDownloaded, RemoteDate: date, RemoteSize: 100}
+
+		assert.False(t, m.RemoteChanged(date, 100))
+		assert.True(t, m.RemoteChanged(date, 200))
+		assert.True(t, m.RemoteChanged(date.Add(-time.Hour), 100))
+	})
+	t.Run("Uploaded", func(t *testing.T) {
+		m := FileSync{Status: FileSyncUploaded, RemoteDate: date, RemoteSize: 100}
+
+		assert.False(t, m.RemoteChanged(date, 100))
+		assert.False(t, m.RemoteChanged(date.Add(-time.Second), 100))
+		assert.True(t, m.RemoteChanged(date.Add(time.Hour), 100))
+		assert.True(t, m.RemoteChanged(date, 200))
+	})
+}
+
+func TestFileSync_LocalChanged(t *testing.T) {
+	t.Run("Unchanged", func(t *testing.T) {
+		m := FileSync{FileID: 1000000, FileHash: "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}
+		assert.False(t, m.LocalChanged())
+	})
+	t.Run("Changed", func(t *testing.T) {
+		m := FileSync{FileID: 1000000, FileHash: "dd05ab1e1f7d9a2d43a4f0e2c85e1e6b3b3f4f11"}
+		assert.True(t, m.LocalChanged())
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		m := FileSync{FileID: 1000000}
+		assert.False(t, m.LocalChanged())
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		m := FileSync{FileHash: "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818"}
+		assert.False(t, m.LocalChanged())
+	})
+}
+
+func TestFileSync_Resolve(t *testing.T) {
+	t.Run("KeepLocal", func(t *testing.T) {
+		m := NewFileSync(123, "/conflict-local.jpg")
+		m.Status = FileSyncConflict
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, m.Resolve(FileSyncKeepLocal))
+		assert.Equal(t, FileSyncChanged, FindFileSync(123, "/conflict-local.jpg").Status)
+	})
+	t.Run("KeepRemote", func(t *testing.T) {
+		m := NewFileSync(123, "/conflict-remote.jpg")
+		m.Status = FileSyncConflict
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, m.Resolve(FileSyncKeepRemote))
+		assert.Equal(t, FileSyncNew, FindFileSync(123, "/conflict-remote.jpg").Status)
+	})
+	t.Run("InvalidOption", func(t *testing.T) {
+		m := FileSync{AccountID: 123, RemoteName: "/conflict.jpg", Status: FileSyncConflict}
+		assert.Error(t, m.Resolve("both"))
+	})
+	t.Run("NoConflict", func(t *testing.T) {
+		m := FileSync{AccountID: 123, RemoteName: "/synced.jpg", Status: FileSyncUploaded}
+		assert.Error(t, m.Resolve(FileSyncKeepLocal))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/pkg/colors"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/phash"
 )
 
 func TestFirstFileByHash(t *testing.T) {
@@ -642,6 +643,20 @@ func TestFile_SetHDR(t *testing.T) {
 	})
 }
 
+func TestFile_SetPerceptualHash(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := FileFixtures.Get("exampleDNGFile.dng")
+
+		assert.False(t, m.HasPerceptualHash())
+		m.SetPerceptualHash(phash.Hash(0xc3a5e1f08c3b1d2e), phash.Hash(255))
+		assert.True(t, m.HasPerceptualHash())
+		assert.Equal(t, "c3a5e1f08c3b1d2e", m.FilePHash)
+		assert.Equal(t, "00000000000000ff", m.FileDHash)
+		m.ResetPerceptualHash()
+		assert.False(t, m.HasPerceptualHash())
+	})
+}
+
 func TestFile_SetColorProfile(t *testing.T) {
 	t.Run("DisplayP3", func(t *testing.T) {
 		m := FileFixtures.Get("exampleFileName.jpg")
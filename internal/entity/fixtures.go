@@ -18,6 +18,8 @@ func CreateTestFixtures() {
 	CreateFileFixtures()
 	CreateKeywordFixtures()
 	CreatePhotoKeywordFixtures()
+	CreatePhotoTextFixtures()
+	CreatePhotoEmbeddingFixtures()
 	CreateCategoryFixtures()
 	CreateCellFixtures()
 	CreatePlaceFixtures()
@@ -29,4 +31,6 @@ func CreateTestFixtures() {
 	CreateFaceFixtures()
 	CreateUserFixtures()
 	CreatePasswordFixtures()
+	CreateUserTokenFixtures()
+	CreateAlbumMemberFixtures()
 }
@@ -22,6 +22,7 @@ type Labels []Label
 type Label struct {
 	ID               uint       `gorm:"primary_key" json:"ID" yaml:"-"`
 	LabelUID         string     `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	OwnerUID         string     `gorm:"type:VARBINARY(42);index;default:'';" json:"OwnerUID,omitempty" yaml:"OwnerUID,omitempty"`
 	LabelSlug        string     `gorm:"type:VARBINARY(160);unique_index;" json:"Slug" yaml:"-"`
 	CustomSlug       string     `gorm:"type:VARBINARY(160);index;" json:"CustomSlug" yaml:"-"`
 	LabelName        string     `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
@@ -173,6 +174,18 @@ func (m *Label) SetName(name string) {
 	m.CustomSlug = txt.Slug(name)
 }
 
+// SetOwner changes the user who owns the label, the UID must be valid or empty.
+func (m *Label) SetOwner(userUID string) {
+	if userUID == "" || rnd.IsPPID(userUID, 'u') {
+		m.OwnerUID = userUID
+	}
+}
+
+// OwnedBy tests if the label is owned by the specified user.
+func (m *Label) OwnedBy(userUID string) bool {
+	return userUID != "" && m.OwnerUID == userUID
+}
+
 // UpdateClassify updates a label if necessary
 func (m *Label) UpdateClassify(label classify.Label) error {
 	save := false
@@ -245,3 +245,12 @@ func TestLabel_Update(t *testing.T) {
 	})
 
 }
+
+func TestLabel_SetOwner(t *testing.T) {
+	m := NewLabel("Owned", 0)
+	assert.False(t, m.OwnedBy("uqxc08w3d0ej2283"))
+	m.SetOwner("uqxc08w3d0ej2283")
+	assert.True(t, m.OwnedBy("uqxc08w3d0ej2283"))
+	m.SetOwner("invalid")
+	assert.Equal(t, "uqxc08w3d0ej2283", m.OwnerUID)
+}
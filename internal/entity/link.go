@@ -2,31 +2,49 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
 
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// Link types.
+const (
+	LinkTypeShare  = ""
+	LinkTypeUpload = "upload"
+)
+
 type Links []Link
 
 // Link represents a sharing link.
 type Link struct {
-	LinkUID     string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
-	ShareUID    string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"Share" yaml:"Share"`
-	ShareSlug   string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
-	LinkToken   string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
-	LinkExpires int       `json:"Expires" yaml:"Expires,omitempty"`
-	LinkViews   uint      `json:"Views" yaml:"-"`
-	MaxViews    uint      `json:"MaxViews" yaml:"-"`
-	HasPassword bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
-	CanComment  bool      `json:"CanComment" yaml:"CanComment,omitempty"`
-	CanEdit     bool      `json:"CanEdit" yaml:"CanEdit,omitempty"`
-	CreatedAt   time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
-	ModifiedAt  time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
+	LinkUID       string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
+	ShareUID      string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"Share" yaml:"Share"`
+	ShareSlug     string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
+	LinkToken     string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
+	LinkType      string    `gorm:"type:VARBINARY(16);" json:"Type" yaml:"Type,omitempty"`
+	LinkExpires   int       `json:"Expires" yaml:"Expires,omitempty"`
+	LinkViews     uint      `json:"Views" yaml:"-"`
+	MaxViews      uint      `json:"MaxViews" yaml:"-"`
+	LinkDownloads uint      `json:"Downloads" yaml:"-"`
+	MaxDownloads  uint      `json:"MaxDownloads" yaml:"-"`
+	ViewOnly      bool      `json:"ViewOnly" yaml:"ViewOnly,omitempty"`
+	LinkUploads   uint      `json:"Uploads" yaml:"-"`
+	MaxUploads    uint      `json:"MaxUploads" yaml:"MaxUploads,omitempty"`
+	UploadSize    int64     `json:"UploadSize" yaml:"-"`
+	MaxUploadSize int64     `json:"MaxUploadSize" yaml:"MaxUploadSize,omitempty"`
+	UploadTypes   string    `gorm:"type:VARBINARY(255);" json:"UploadTypes" yaml:"UploadTypes,omitempty"`
+	Moderate      bool      `json:"Moderate" yaml:"Moderate,omitempty"`
+	HasPassword   bool      `json:"HasPassword" yaml:"HasPassword,omitempty"`
+	CanComment    bool      `json:"CanComment" yaml:"CanComment,omitempty"`
+	CanEdit       bool      `json:"CanEdit" yaml:"CanEdit,omitempty"`
+	CreatedAt     time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
+	ModifiedAt    time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
 }
 
 // BeforeCreate creates a random UID if needed before inserting a new row to the database.
@@ -65,6 +83,142 @@ func (m *Link) Redeem() {
 	}
 }
 
+// RedeemDownload increments the download counter.
+func (m *Link) RedeemDownload() {
+	m.LinkDownloads += 1
+
+	result := Db().Model(m).UpdateColumn("LinkDownloads", m.LinkDownloads)
+
+	if result.RowsAffected == 0 {
+		log.Warnf("link: failed updating share download counter for %s", m.LinkUID)
+	}
+}
+
+// Downloadable tests if originals may be downloaded with this link.
+func (m *Link) Downloadable() bool {
+	if m.ViewOnly || m.Expired() {
+		return false
+	}
+
+	return m.MaxDownloads == 0 || m.LinkDownloads < m.MaxDownloads
+}
+
+// Shares tests if the entity with the specified uid is shared with this link,
+// either directly or as part of a shared album. Uploads pending review are not shared.
+func (m *Link) Shares(uid string) bool {
+	if uid == "" {
+		return false
+	} else if m.ShareUID == uid {
+		return true
+	} else if !rnd.IsPPID(m.ShareUID, 'a') || !rnd.IsPPID(uid, 'p') {
+		return false
+	}
+
+	count := 0
+
+	if err := Db().Model(&PhotoAlbum{}).
+		Where("album_uid = ? AND photo_uid = ? AND hidden = FALSE", m.ShareUID, uid).
+		Where("photo_uid NOT IN (SELECT photo_uid FROM links_uploads WHERE review = TRUE)").
+		Count(&count).Error; err != nil {
+		log.Errorf("link: %s (find shared photo)", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// AcceptsUploads tests if this is an upload link, which only allows adding files to the album.
+func (m *Link) AcceptsUploads() bool {
+	return m.LinkType == LinkTypeUpload
+}
+
+// AllowsUploadType tests if the file type may be uploaded with this link. Types are a comma-separated
+// list of file formats or media types, e.g. "jpg,raw,video". All media files are allowed if empty.
+func (m *Link) AllowsUploadType(fileName string) bool {
+	mediaType := fs.GetMediaType(fileName)
+
+	if mediaType == fs.MediaSidecar || mediaType == fs.MediaOther {
+		return false
+	} else if m.UploadTypes == "" {
+		return true
+	}
+
+	format := fs.GetFileFormat(fileName)
+
+	for _, t := range strings.Split(strings.ToLower(m.UploadTypes), ",") {
+		t = strings.TrimSpace(t)
+
+		if t == string(format) || t == string(mediaType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UploadQuotaExceeded tests if uploading the specified number of files and bytes exceeds the link quota.
+func (m *Link) UploadQuotaExceeded(count int, size int64) bool {
+	if m.MaxUploads > 0 && m.LinkUploads+uint(count) > m.MaxUploads {
+		return true
+	}
+
+	return m.MaxUploadSize > 0 && m.UploadSize+size > m.MaxUploadSize
+}
+
+// UploadSizeLeft returns the number of bytes that may still be uploaded, or -1 if there is no limit.
+func (m *Link) UploadSizeLeft() int64 {
+	if m.MaxUploadSize <= 0 {
+		return -1
+	} else if m.UploadSize >= m.MaxUploadSize {
+		return 0
+	}
+
+	return m.MaxUploadSize - m.UploadSize
+}
+
+// ReserveUpload adds the number of files and bytes to the link counters, unless this exceeds the quota.
+// The counters are checked and updated in a single statement, so concurrent uploads cannot exceed it.
+func (m *Link) ReserveUpload(count int, size int64) bool {
+	result := UnscopedDb().Model(&Link{}).
+		Where("link_uid = ?", m.LinkUID).
+		Where("max_uploads = 0 OR link_uploads + ? <= max_uploads", count).
+		Where("max_upload_size = 0 OR upload_size + ? <= max_upload_size", size).
+		UpdateColumns(map[string]interface{}{
+			"link_uploads": gorm.Expr("link_uploads + ?", count),
+			"upload_size":  gorm.Expr("upload_size + ?", size),
+		})
+
+	if result.Error != nil {
+		log.Errorf("link: %s (reserve upload quota of %s)", result.Error, m.LinkUID)
+		return false
+	} else if result.RowsAffected == 0 {
+		return false
+	}
+
+	m.LinkUploads += uint(count)
+	m.UploadSize += size
+
+	return true
+}
+
+// ReleaseUpload subtracts the number of files and bytes from the link counters, e.g. if they could not be saved.
+func (m *Link) ReleaseUpload(count int, size int64) {
+	result := UnscopedDb().Model(&Link{}).
+		Where("link_uid = ? AND link_uploads >= ? AND upload_size >= ?", m.LinkUID, count, size).
+		UpdateColumns(map[string]interface{}{
+			"link_uploads": gorm.Expr("link_uploads - ?", count),
+			"upload_size":  gorm.Expr("upload_size - ?", size),
+		})
+
+	if result.RowsAffected == 0 {
+		log.Warnf("link: failed updating upload counters for %s", m.LinkUID)
+		return
+	}
+
+	m.LinkUploads -= uint(count)
+	m.UploadSize -= size
+}
+
 func (m *Link) Expired() bool {
 	if m.MaxViews > 0 && m.LinkViews >= m.MaxViews {
 		return true
@@ -125,12 +279,20 @@ func (m *Link) Save() error {
 	return Db().Save(m).Error
 }
 
-// Delete the link.
+// Delete the link and its access history.
 func (m *Link) Delete() error {
 	if m.LinkToken == "" {
 		return fmt.Errorf("link: empty share token")
 	}
 
+	if err := Db().Delete(&LinkAccess{}, "link_uid = ?", m.LinkUID).Error; err != nil {
+		return err
+	}
+
+	if err := Db().Model(&LinkUpload{}).Where("link_uid = ?", m.LinkUID).UpdateColumn("link_uid", "").Error; err != nil {
+		return err
+	}
+
 	return Db().Delete(m).Error
 }
 
@@ -181,9 +343,10 @@ func FindLinks(token, share string) (result Links) {
 }
 
 // FindValidLinks returns a slice of non-expired links for a token and share UID (at least one must be provided).
+// Upload links are excluded, as they do not allow browsing shared content.
 func FindValidLinks(token, share string) (result Links) {
 	for _, link := range FindLinks(token, share) {
-		if !link.Expired() {
+		if !link.Expired() && !link.AcceptsUploads() {
 			result = append(result, link)
 		}
 	}
@@ -191,6 +354,21 @@ func FindValidLinks(token, share string) (result Links) {
 	return result
 }
 
+// FindUploadLink returns the non-expired upload link with the specified token, or nil if none exists.
+func FindUploadLink(token string) *Link {
+	if token == "" {
+		return nil
+	}
+
+	for _, link := range FindLinks(token, "") {
+		if link.AcceptsUploads() && !link.Expired() {
+			return &link
+		}
+	}
+
+	return nil
+}
+
 // String returns an human readable identifier for logging.
 func (m *Link) String() string {
 	return sanitize.Log(m.LinkUID)
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Link access types.
+const (
+	LinkAccessView     = "view"
+	LinkAccessDownload = "download"
+	LinkAccessUpload   = "upload"
+)
+
+type LinkAccesses []LinkAccess
+
+// LinkAccess represents a view, download, or upload of a shared link.
+type LinkAccess struct {
+	ID         uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	LinkUID    string    `gorm:"type:VARBINARY(42);index;" json:"LinkUID" yaml:"LinkUID"`
+	AccessType string    `gorm:"type:VARBINARY(16);" json:"Type" yaml:"Type"`
+	AccessName string    `gorm:"type:VARBINARY(755);" json:"Name" yaml:"Name,omitempty"`
+	ClientIP   string    `gorm:"type:VARBINARY(64);" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	UserAgent  string    `gorm:"size:512;" json:"UserAgent" yaml:"UserAgent,omitempty"`
+	CreatedAt  time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (LinkAccess) TableName() string {
+	return "links_access"
+}
+
+// AddAccess records a view, download, or upload with the link, name is the file name if any.
+func (m *Link) AddAccess(accessType, name, ip, userAgent string) {
+	access := LinkAccess{
+		LinkUID:    m.LinkUID,
+		AccessType: accessType,
+		AccessName: txt.Clip(name, 755),
+		ClientIP:   txt.Clip(ip, 64),
+		UserAgent:  txt.Clip(userAgent, 512),
+		CreatedAt:  TimeStamp(),
+	}
+
+	if err := Db().Create(&access).Error; err != nil {
+		log.Errorf("link: %s (add %s of %s)", err, accessType, m.String())
+	}
+}
+
+// Access returns the access history of the link, most recent first.
+func (m *Link) Access(limit int) (result LinkAccesses, err error) {
+	err = Db().Where("link_uid = ?", m.LinkUID).Order("created_at DESC, id DESC").Limit(limit).Find(&result).Error
+
+	return result, err
+}
@@ -195,3 +195,161 @@ func TestLink_String(t *testing.T) {
 		assert.Equal(t, uid, link.String())
 	})
 }
+
+func TestLink_Downloadable(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.True(t, link.Downloadable())
+
+	link.MaxDownloads = 2
+	link.LinkDownloads = 1
+
+	assert.True(t, link.Downloadable())
+
+	link.LinkDownloads = 2
+
+	assert.False(t, link.Downloadable())
+
+	link.MaxDownloads = 0
+	link.ViewOnly = true
+
+	assert.False(t, link.Downloadable())
+}
+
+func TestLink_Shares(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+
+	assert.True(t, link.Shares("at9lxuqxpogaaba8"))
+	assert.True(t, link.Shares("pt9jtdre2lvl0yh7"))
+	assert.False(t, link.Shares("pt9jtdre2lvl0y11"))
+	assert.False(t, link.Shares("at9lxuqxpogaaba7"))
+	assert.False(t, link.Shares(""))
+
+	photo := NewLink("pt9jtdre2lvl0y11", false, false)
+
+	assert.True(t, photo.Shares("pt9jtdre2lvl0y11"))
+	assert.False(t, photo.Shares("pt9jtdre2lvl0yh7"))
+}
+
+func TestLink_Access(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba7", false, false)
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	link.Redeem()
+	link.AddAccess(LinkAccessView, "", "127.0.0.1", "Mozilla/5.0")
+	link.RedeemDownload()
+	link.AddAccess(LinkAccessDownload, "christmas.jpg", "127.0.0.1", "Mozilla/5.0")
+
+	assert.Equal(t, uint(1), link.LinkDownloads)
+
+	result, err := link.Access(10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, result, 2) {
+		assert.Equal(t, LinkAccessDownload, result[0].AccessType)
+		assert.Equal(t, "christmas.jpg", result[0].AccessName)
+		assert.Equal(t, LinkAccessView, result[1].AccessType)
+	}
+
+	if err := link.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = link.Access(10)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 0)
+}
+
+func TestLink_AllowsUploadType(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+	link.LinkType = LinkTypeUpload
+
+	assert.True(t, link.AcceptsUploads())
+	assert.True(t, link.AllowsUploadType("party.jpg"))
+	assert.True(t, link.AllowsUploadType("party.mp4"))
+	assert.False(t, link.AllowsUploadType("party.xmp"))
+	assert.False(t, link.AllowsUploadType("party.exe"))
+
+	link.UploadTypes = "jpg, video"
+
+	assert.True(t, link.AllowsUploadType("party.JPG"))
+	assert.True(t, link.AllowsUploadType("party.mov"))
+	assert.False(t, link.AllowsUploadType("party.png"))
+	assert.False(t, link.AllowsUploadType("party.cr2"))
+}
+
+func TestLink_UploadQuotaExceeded(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+	link.LinkType = LinkTypeUpload
+
+	assert.False(t, link.UploadQuotaExceeded(100, 1<<30))
+
+	link.MaxUploads = 10
+	link.MaxUploadSize = 1000
+	link.LinkUploads = 8
+	link.UploadSize = 500
+
+	assert.False(t, link.UploadQuotaExceeded(2, 500))
+	assert.True(t, link.UploadQuotaExceeded(3, 100))
+	assert.True(t, link.UploadQuotaExceeded(1, 501))
+}
+
+func TestLink_ReserveUpload(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+	link.LinkType = LinkTypeUpload
+	link.MaxUploads = 3
+	link.MaxUploadSize = 1000
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	assert.Equal(t, int64(1000), link.UploadSizeLeft())
+	assert.True(t, link.ReserveUpload(2, 600))
+	assert.Equal(t, int64(400), link.UploadSizeLeft())
+	assert.False(t, link.ReserveUpload(1, 401))
+	assert.False(t, link.ReserveUpload(2, 100))
+
+	link.ReleaseUpload(2, 600)
+
+	assert.True(t, link.ReserveUpload(3, 1000))
+	assert.Equal(t, int64(0), link.UploadSizeLeft())
+
+	result := FindLink(link.LinkUID)
+
+	if assert.NotNil(t, result) {
+		assert.Equal(t, uint(3), result.LinkUploads)
+		assert.Equal(t, int64(1000), result.UploadSize)
+	}
+}
+
+func TestFindUploadLink(t *testing.T) {
+	link := NewLink("at9lxuqxpogaaba8", false, false)
+	link.LinkType = LinkTypeUpload
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer link.Delete()
+
+	if result := FindUploadLink(link.LinkToken); assert.NotNil(t, result) {
+		assert.Equal(t, link.LinkUID, result.LinkUID)
+	}
+
+	assert.Nil(t, FindUploadLink("1jxf3jfn2k"))
+	assert.Nil(t, FindUploadLink(""))
+	assert.Empty(t, FindValidLinks(link.LinkToken, ""))
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type LinkUploads []LinkUpload
+
+// LinkUpload represents a photo that was uploaded with an upload link.
+type LinkUpload struct {
+	ID        uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	LinkUID   string    `gorm:"type:VARBINARY(42);index;" json:"LinkUID" yaml:"LinkUID"`
+	PhotoUID  string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	FileName  string    `gorm:"type:VARBINARY(755);" json:"FileName" yaml:"FileName,omitempty"`
+	Review    bool      `json:"Review" yaml:"Review,omitempty"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (LinkUpload) TableName() string {
+	return "links_uploads"
+}
+
+// AddLinkUpload records a photo uploaded with the specified link. If the link requires moderation,
+// the photo is added to the album only after it has been approved, otherwise it is added right away.
+func AddLinkUpload(linkUID, photoUID, fileName string) error {
+	link := FindLink(linkUID)
+
+	if link == nil {
+		return fmt.Errorf("link: %s not found", sanitize.Log(linkUID))
+	}
+
+	upload := LinkUpload{
+		LinkUID:   link.LinkUID,
+		PhotoUID:  photoUID,
+		FileName:  txt.Clip(fileName, 755),
+		Review:    link.Moderate,
+		CreatedAt: TimeStamp(),
+	}
+
+	// Existing photos that are already visible in the album have nothing to review.
+	if upload.Review {
+		count := 0
+
+		if err := Db().Model(&PhotoAlbum{}).Where("photo_uid = ? AND album_uid = ? AND hidden = FALSE", photoUID, link.ShareUID).Count(&count).Error; err != nil {
+			return err
+		} else if count > 0 {
+			upload.Review = false
+		}
+	}
+
+	// Create the upload record first, so that moderated uploads are pending before anything else happens.
+	if err := Db().Create(&upload).Error; err != nil {
+		return fmt.Errorf("link: %s (add upload to %s)", err, link.String())
+	}
+
+	if upload.Review {
+		return nil
+	}
+
+	return AddPhotoToAlbums(photoUID, []string{link.ShareUID})
+}
+
+// Uploads returns the photos uploaded with the link, most recent first.
+func (m *Link) Uploads(limit int) (result LinkUploads, err error) {
+	err = Db().Where("link_uid = ?", m.LinkUID).Order("created_at DESC, id DESC").Limit(limit).Find(&result).Error
+
+	return result, err
+}
+
+// PendingReview tests if the photo was uploaded with a moderated link and has not been approved yet.
+func (m *Photo) PendingReview() bool {
+	if m.PhotoUID == "" {
+		return false
+	}
+
+	count := 0
+
+	if err := Db().Model(&LinkUpload{}).Where("photo_uid = ? AND review = TRUE", m.PhotoUID).Count(&count).Error; err != nil {
+		log.Errorf("photo: %s (find pending uploads)", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// approveUploads marks uploads of the photo as reviewed and adds it to the albums of the upload links.
+func (m *Photo) approveUploads() error {
+	var uploads LinkUploads
+
+	if err := Db().Where("photo_uid = ? AND review = TRUE", m.PhotoUID).Find(&uploads).Error; err != nil {
+		return err
+	}
+
+	for _, upload := range uploads {
+		if upload.LinkUID == "" {
+			// Link has been deleted.
+		} else if link := FindLink(upload.LinkUID); link == nil {
+			// Do nothing.
+		} else if err := AddPhotoToAlbums(m.PhotoUID, []string{link.ShareUID}); err != nil {
+			return err
+		}
+	}
+
+	return Db().Model(&LinkUpload{}).Where("photo_uid = ? AND review = TRUE", m.PhotoUID).UpdateColumn("review", false).Error
+}
@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inAlbum tests if the photo is visible in the album.
+func inAlbum(t *testing.T, photoUID, albumUID string) bool {
+	count := 0
+
+	if err := Db().Model(&PhotoAlbum{}).Where("photo_uid = ? AND album_uid = ? AND hidden = FALSE", photoUID, albumUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return count > 0
+}
+
+func TestAddLinkUpload(t *testing.T) {
+	t.Run("Moderate", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba8", false, false)
+		link.LinkType = LinkTypeUpload
+		link.Moderate = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		photo := Photo{PhotoQuality: 4}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, photo.PendingReview())
+
+		if err := AddLinkUpload(link.LinkUID, photo.PhotoUID, "party.jpg"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, photo.PendingReview())
+		assert.False(t, inAlbum(t, photo.PhotoUID, link.ShareUID))
+
+		uploads, err := link.Uploads(10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, uploads, 1) {
+			assert.Equal(t, photo.PhotoUID, uploads[0].PhotoUID)
+			assert.Equal(t, "party.jpg", uploads[0].FileName)
+			assert.True(t, uploads[0].Review)
+		}
+
+		var result Photo
+
+		if err := Db().Where("photo_uid = ?", photo.PhotoUID).First(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 4, result.PhotoQuality)
+
+		if err := result.Approve(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, result.PendingReview())
+		assert.GreaterOrEqual(t, result.PhotoQuality, 3)
+		assert.True(t, inAlbum(t, photo.PhotoUID, link.ShareUID))
+	})
+	t.Run("NoModeration", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba8", false, false)
+		link.LinkType = LinkTypeUpload
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		photo := Photo{PhotoQuality: 4}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := AddLinkUpload(link.LinkUID, photo.PhotoUID, "beach.jpg"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, photo.PendingReview())
+		assert.True(t, inAlbum(t, photo.PhotoUID, link.ShareUID))
+	})
+	t.Run("ExistingPhoto", func(t *testing.T) {
+		link := NewLink("at9lxuqxpogaaba8", false, false)
+		link.LinkType = LinkTypeUpload
+		link.Moderate = true
+
+		if err := link.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer link.Delete()
+
+		photo := Photo{PhotoQuality: 4}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := AddPhotoToAlbums(photo.PhotoUID, []string{link.ShareUID}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Uploading a duplicate must not hide a photo that is already in the album.
+		if err := AddLinkUpload(link.LinkUID, photo.PhotoUID, "duplicate.jpg"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, photo.PendingReview())
+		assert.True(t, inAlbum(t, photo.PhotoUID, link.ShareUID))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Error(t, AddLinkUpload("sqn0000000000000", "pt9jtdre2lvl0yh7", "party.jpg"))
+	})
+}
@@ -347,7 +347,7 @@ func (m *Marker) SyncSubject(updateRelated bool) (err error) {
 		return fmt.Errorf("%s (update known face)", err)
 	} else if !updateRelated {
 		return nil
-	} else if err := Db().Exec(`update markers set subj_uid=?,marker_review=0
+	} else if err := Db().Exec(`update markers set subj_uid=?,marker_review=FALSE
 					where marker_uid<>? and face_id=? and subj_src=? and subj_uid<>?`,
 		m.SubjUID, m.MarkerUID, m.FaceID, SrcAuto, m.SubjUID).Error; err != nil {
 		return fmt.Errorf("%s (update related markers)", err)
@@ -673,7 +673,7 @@ func FindFaceMarker(faceId string) *Marker {
 	var result Marker
 
 	if err := Db().Where("face_id = ?", faceId).
-		Where("thumb <> '' AND marker_invalid = 0").
+		Where("thumb <> '' AND marker_invalid = FALSE").
 		Order("face_dist ASC, q DESC").First(&result).Error; err != nil {
 		log.Warnf("markers: found no marker for face %s", sanitize.Log(faceId))
 		return nil
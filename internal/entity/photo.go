@@ -52,6 +52,7 @@ type Photo struct {
 	TakenAtLocal     time.Time    `gorm:"type:DATETIME;" yaml:"-"`
 	TakenSrc         string       `gorm:"type:VARBINARY(8);" json:"TakenSrc" yaml:"TakenSrc,omitempty"`
 	PhotoUID         string       `gorm:"type:VARBINARY(42);unique_index;index:idx_photos_taken_uid;" json:"UID" yaml:"UID"`
+	OwnerUID         string       `gorm:"type:VARBINARY(42);index;default:'';" json:"OwnerUID,omitempty" yaml:"OwnerUID,omitempty"`
 	PhotoType        string       `gorm:"type:VARBINARY(8);default:'image';" json:"Type" yaml:"Type"`
 	TypeSrc          string       `gorm:"type:VARBINARY(8);" json:"TypeSrc" yaml:"TypeSrc,omitempty"`
 	PhotoTitle       string       `gorm:"type:VARCHAR(200);" json:"Title" yaml:"Title"`
@@ -676,7 +677,7 @@ func (m *Photo) AllFilesMissing() bool {
 	count := 0
 
 	if err := Db().Model(&File{}).
-		Where("photo_id = ? AND file_missing = 0", m.ID).
+		Where("photo_id = ? AND file_missing = FALSE", m.ID).
 		Count(&count).Error; err != nil {
 		log.Error(err)
 	}
@@ -766,6 +767,14 @@ func (m *Photo) DeletePermanently() (files Files, err error) {
 		log.Errorf("photo: %s (remove labels)", err)
 	}
 
+	if err := UnscopedDb().Delete(PhotoText{}, "photo_id = ?", m.ID).Error; err != nil {
+		log.Errorf("photo: %s (remove text)", err)
+	}
+
+	if err := UnscopedDb().Delete(PhotoEmbedding{}, "photo_id = ?", m.ID).Error; err != nil {
+		log.Errorf("photo: %s (remove embedding)", err)
+	}
+
 	if err := UnscopedDb().Delete(PhotoAlbum{}, "photo_uid = ?", m.PhotoUID).Error; err != nil {
 		log.Errorf("photo: %s (remove albums)", err)
 	}
@@ -822,13 +831,29 @@ func (m *Photo) SetStack(stack int8) {
 	}
 }
 
-// Approve approves a photo in review.
+// SetOwner changes the user who owns the photo, the UID must be valid or empty.
+func (m *Photo) SetOwner(userUID string) {
+	if userUID == "" || rnd.IsPPID(userUID, 'u') {
+		m.OwnerUID = userUID
+	}
+}
+
+// OwnedBy tests if the photo is owned by the specified user.
+func (m *Photo) OwnedBy(userUID string) bool {
+	return userUID != "" && m.OwnerUID == userUID
+}
+
+// Approve approves a photo in review, including uploads with a moderated link.
 func (m *Photo) Approve() error {
-	if m.PhotoQuality >= 3 {
+	if m.PhotoQuality >= 3 && !m.PendingReview() {
 		// Nothing to do.
 		return nil
 	}
 
+	if err := m.approveUploads(); err != nil {
+		return err
+	}
+
 	edited := TimeStamp()
 	m.EditedAt = &edited
 	m.PhotoQuality = m.QualityScore()
@@ -870,7 +895,7 @@ func (m *Photo) SetPrimary(fileUID string) (err error) {
 	if fileUID != "" {
 		// Do nothing.
 	} else if err = Db().Model(File{}).
-		Where("photo_uid = ? AND file_type in ('jpg', 'heif') AND file_missing = 0 AND file_error = ''", m.PhotoUID).
+		Where("photo_uid = ? AND file_type in ('jpg', 'heif') AND file_missing = FALSE AND file_error = ''", m.PhotoUID).
 		Order("file_width DESC, file_hdr DESC").Limit(1).
 		Pluck("file_uid", &files).Error; err != nil {
 		return err
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// PhotoEmbedding represents the vector of a photo in the embedding space of a semantic search model.
+type PhotoEmbedding struct {
+	PhotoID        uint      `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	PhotoUID       string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	FileUID        string    `gorm:"type:VARBINARY(42);default:'';" json:"FileUID" yaml:"FileUID,omitempty"`
+	EmbeddingModel string    `gorm:"type:VARBINARY(64);index;default:'';" json:"Model" yaml:"Model"`
+	EmbeddingDim   int       `json:"Dim" yaml:"Dim"`
+	Embedding      []byte    `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"-"`
+	CreatedAt      time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt      time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoEmbedding) TableName() string {
+	return "photos_embeddings"
+}
+
+// NewPhotoEmbedding returns a new embedding for the file of a photo.
+func NewPhotoEmbedding(photo Photo, fileUID, model string, vector []float32) *PhotoEmbedding {
+	m := &PhotoEmbedding{
+		PhotoID:        photo.ID,
+		PhotoUID:       photo.PhotoUID,
+		FileUID:        fileUID,
+		EmbeddingModel: model,
+	}
+
+	m.SetVector(vector)
+
+	return m
+}
+
+// SetVector sets the embedding vector, which is stored as little-endian 32-bit floats.
+func (m *PhotoEmbedding) SetVector(vector []float32) {
+	m.EmbeddingDim = len(vector)
+	m.Embedding = make([]byte, 4*len(vector))
+
+	for i, x := range vector {
+		binary.LittleEndian.PutUint32(m.Embedding[4*i:], math.Float32bits(x))
+	}
+}
+
+// Vector returns the embedding vector.
+func (m *PhotoEmbedding) Vector() []float32 {
+	return DecodeVector(m.Embedding)
+}
+
+// Save inserts or updates the embedding of the photo.
+func (m *PhotoEmbedding) Save() error {
+	return UnscopedDb().Save(m).Error
+}
+
+// FindPhotoEmbedding returns the embedding of a photo, or nil if it has none.
+func FindPhotoEmbedding(photoID uint) *PhotoEmbedding {
+	m := PhotoEmbedding{}
+
+	if photoID < 1 {
+		return nil
+	} else if err := UnscopedDb().Where("photo_id = ?", photoID).First(&m).Error; err != nil {
+		return nil
+	}
+
+	return &m
+}
+
+// DecodeVector converts little-endian 32-bit floats to a vector.
+func DecodeVector(b []byte) []float32 {
+	vector := make([]float32, len(b)/4)
+
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+
+	return vector
+}
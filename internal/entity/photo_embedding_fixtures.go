@@ -0,0 +1,16 @@
+package entity
+
+type PhotoEmbeddingMap map[string]PhotoEmbedding
+
+// PhotoEmbeddingFixtures use a test model with 4 dimensions.
+var PhotoEmbeddingFixtures = PhotoEmbeddingMap{
+	"sign":  *NewPhotoEmbedding(PhotoFixtures.Get("19800101_000002_D640C559"), "ft8es39w45bnlqdw", "test", []float32{1, 0, 0, 0}),
+	"beach": *NewPhotoEmbedding(PhotoFixtures.Get("Photo04"), "", "test", []float32{0, 0.6, 0.8, 0}),
+}
+
+// CreatePhotoEmbeddingFixtures inserts known entities into the database for testing.
+func CreatePhotoEmbeddingFixtures() {
+	for _, entity := range PhotoEmbeddingFixtures {
+		Db().Create(&entity)
+	}
+}
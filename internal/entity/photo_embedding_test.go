@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoEmbedding_TableName(t *testing.T) {
+	assert.Equal(t, "photos_embeddings", PhotoEmbedding{}.TableName())
+}
+
+func TestNewPhotoEmbedding(t *testing.T) {
+	photo := PhotoFixtures.Get("19800101_000002_D640C559")
+	m := NewPhotoEmbedding(photo, "ft8es39w45bnlqdw", "ViT-B-32", []float32{0.5, -0.25, 1})
+
+	assert.Equal(t, photo.ID, m.PhotoID)
+	assert.Equal(t, photo.PhotoUID, m.PhotoUID)
+	assert.Equal(t, "ViT-B-32", m.EmbeddingModel)
+	assert.Equal(t, 3, m.EmbeddingDim)
+	assert.Len(t, m.Embedding, 12)
+	assert.Equal(t, []float32{0.5, -0.25, 1}, m.Vector())
+}
+
+func TestPhotoEmbedding_Save(t *testing.T) {
+	photo := PhotoFixtures.Get("Photo01")
+	m := NewPhotoEmbedding(photo, "", "test", []float32{0, 0, 0, 1})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.SetVector([]float32{0, 0, 1, 0})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := FindPhotoEmbedding(photo.ID); result == nil {
+		t.Fatal("embedding not found")
+	} else {
+		assert.Equal(t, []float32{0, 0, 1, 0}, result.Vector())
+	}
+
+	assert.Nil(t, FindPhotoEmbedding(0))
+}
+
+func TestPhotoEmbedding_DeletePermanently(t *testing.T) {
+	photo := Photo{PhotoTitle: "Embedding"}
+
+	if err := photo.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewPhotoEmbedding(photo, "", "test", []float32{0, 1, 0, 0}).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, FindPhotoEmbedding(photo.ID))
+
+	if _, err := photo.DeletePermanently(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindPhotoEmbedding(photo.ID))
+}
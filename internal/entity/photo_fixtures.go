@@ -699,6 +699,7 @@ var PhotoFixtures = PhotoMap{
 	"Photo11": { // JPG
 		ID:               1000011,
 		PhotoUID:         "pt9jtdre2lvl0y18",
+		OwnerUID:         "uqxc08w3d0ej2283",
 		TakenAt:          time.Date(2016, 12, 11, 9, 7, 18, 0, time.UTC),
 		TakenAtLocal:     time.Date(2016, 12, 11, 9, 7, 18, 0, time.UTC),
 		TakenSrc:         "",
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/photoprism/photoprism/internal/classify"
 )
 
@@ -15,6 +17,8 @@ type PhotoLabel struct {
 	Uncertainty int    `gorm:"type:SMALLINT"`
 	Photo       *Photo `gorm:"PRELOAD:false"`
 	Label       *Label `gorm:"PRELOAD:true"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 // TableName returns the entity database table name.
@@ -34,14 +38,20 @@ func NewPhotoLabel(photoID, labelID uint, uncertainty int, source string) *Photo
 	return result
 }
 
-// Updates multiple columns in the database.
-func (m *PhotoLabel) Updates(values interface{}) error {
-	return UnscopedDb().Model(m).UpdateColumns(values).Error
+// Updates multiple columns in the database and sets the update timestamp in the same statement, so that the change is synced.
+func (m *PhotoLabel) Updates(values Values) error {
+	columns := Values{"UpdatedAt": TimeStamp()}
+
+	for k, v := range values {
+		columns[k] = v
+	}
+
+	return UnscopedDb().Model(m).UpdateColumns(columns).Error
 }
 
-// Update a column in the database.
+// Update a column in the database and sets the update timestamp, so that the change is synced.
 func (m *PhotoLabel) Update(attr string, value interface{}) error {
-	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
+	return UnscopedDb().Model(m).UpdateColumns(Values{attr: value, "UpdatedAt": TimeStamp()}).Error
 }
 
 // Save saves the entity in the database.
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/stretchr/testify/assert"
@@ -89,3 +90,18 @@ func TestPhotoLabel_Update(t *testing.T) {
 		assert.Equal(t, uint(0x8), photoLabel.LabelID)
 	})
 }
+
+func TestPhotoLabel_Updates(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		photoLabel := PhotoLabel{LabelID: 555, PhotoID: 889, UpdatedAt: time.Now().Add(-time.Hour)}
+		updatedAt := photoLabel.UpdatedAt
+
+		if err := photoLabel.Updates(Values{"Uncertainty": 20, "LabelSrc": SrcManual}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 20, photoLabel.Uncertainty)
+		assert.Equal(t, SrcManual, photoLabel.LabelSrc)
+		assert.True(t, photoLabel.UpdatedAt.After(updatedAt))
+	})
+}
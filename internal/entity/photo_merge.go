@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/jinzhu/gorm"
@@ -13,7 +14,7 @@ var photoMergeMutex = sync.Mutex{}
 func (m *Photo) ResolvePrimary() error {
 	var file File
 
-	if err := Db().Where("file_primary = 1 AND photo_id = ?", m.ID).
+	if err := Db().Where("file_primary = TRUE AND photo_id = ?", m.ID).
 		Order("file_width DESC, file_hdr DESC").
 		First(&file).Error; err == nil && file.ID > 0 {
 		return file.ResolvePrimary()
@@ -87,6 +88,36 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		return Photo{}, merged, err
 	}
 
+	original = identical[0]
+
+	log.Debugf("photo: merging id %d with %d identical", original.ID, len(identical)-1)
+
+	merged, err = original.stack(identical[1:])
+
+	if original.ID != m.ID {
+		deleted := TimeStamp()
+		m.DeletedAt = &deleted
+		m.PhotoQuality = -1
+	}
+
+	return original, merged, err
+}
+
+// Stack merges the files, keywords, labels, and albums of other photos into this photo
+// and flags the other photos as deleted.
+func (m *Photo) Stack(photos Photos) (stacked Photos, err error) {
+	if !m.HasID() {
+		return stacked, fmt.Errorf("photo: cannot stack photos without id")
+	}
+
+	photoMergeMutex.Lock()
+	defer photoMergeMutex.Unlock()
+
+	return m.stack(photos)
+}
+
+// stack merges other photos into this photo, the caller must hold the photo merge mutex.
+func (m *Photo) stack(photos Photos) (stacked Photos, err error) {
 	logResult := func(res *gorm.DB) {
 		if res.Error != nil {
 			log.Errorf("merge: %s", res.Error.Error())
@@ -94,27 +125,29 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		}
 	}
 
-	for i, merge := range identical {
-		if i == 0 {
-			original = merge
-			log.Debugf("photo: merging id %d with %d identical", original.ID, len(identical)-1)
+	for _, merge := range photos {
+		if merge.ID == m.ID {
 			continue
 		}
 
 		deleted := TimeStamp()
 
-		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = FALSE WHERE photo_id = ?", m.ID, m.PhotoUID, merge.ID))
 		logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", TimeStamp(), merge.ID))
 
 		switch DbDialect() {
 		case MySQL:
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", m.PhotoUID, merge.PhotoUID))
 		case SQLite3:
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", m.ID, merge.ID))
+			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", m.PhotoUID, merge.PhotoUID))
+		case Postgres:
+			logResult(UnscopedDb().Exec("UPDATE photos_keywords SET photo_id = ? WHERE photo_id = ? AND keyword_id NOT IN (SELECT keyword_id FROM photos_keywords WHERE photo_id = ?)", m.ID, merge.ID, m.ID))
+			logResult(UnscopedDb().Exec("UPDATE photos_labels SET photo_id = ? WHERE photo_id = ? AND label_id NOT IN (SELECT label_id FROM photos_labels WHERE photo_id = ?)", m.ID, merge.ID, m.ID))
+			logResult(UnscopedDb().Exec("UPDATE photos_albums SET photo_uid = ? WHERE photo_uid = ? AND album_uid NOT IN (SELECT album_uid FROM photos_albums WHERE photo_uid = ?)", m.PhotoUID, merge.PhotoUID, m.PhotoUID))
 		default:
 			log.Warnf("sql: unsupported dialect %s", DbDialect())
 		}
@@ -122,14 +155,8 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 		merge.DeletedAt = &deleted
 		merge.PhotoQuality = -1
 
-		merged = append(merged, merge)
+		stacked = append(stacked, merge)
 	}
 
-	if original.ID != m.ID {
-		deleted := TimeStamp()
-		m.DeletedAt = &deleted
-		m.PhotoQuality = -1
-	}
-
-	return original, merged, err
+	return stacked, err
 }
@@ -95,3 +95,20 @@ func TestPhoto_Merge(t *testing.T) {
 		assert.Equal(t, 1000024, int(merged[0].ID))
 	})
 }
+
+func TestPhoto_Stack(t *testing.T) {
+	t.Run("NoID", func(t *testing.T) {
+		photo := Photo{}
+		stacked, err := photo.Stack(Photos{PhotoFixtures.Get("Photo23")})
+
+		assert.Error(t, err)
+		assert.Empty(t, stacked)
+	})
+	t.Run("SkipSelf", func(t *testing.T) {
+		photo := PhotoFixtures.Get("Photo23")
+		stacked, err := photo.Stack(Photos{photo})
+
+		assert.NoError(t, err)
+		assert.Empty(t, stacked)
+	})
+}
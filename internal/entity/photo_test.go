@@ -811,3 +811,26 @@ func TestPhoto_MapKey(t *testing.T) {
 	m := &Photo{TakenAt: time.Date(2016, 11, 11, 9, 7, 18, 0, time.UTC), CellID: "abc236"}
 	assert.Equal(t, "ogh006/abc236", m.MapKey())
 }
+
+func TestPhoto_SetOwner(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		m := &Photo{}
+		m.SetOwner("uqxc08w3d0ej2283")
+		assert.Equal(t, "uqxc08w3d0ej2283", m.OwnerUID)
+		assert.True(t, m.OwnedBy("uqxc08w3d0ej2283"))
+		assert.False(t, m.OwnedBy("uqxetse3cy5eo9z2"))
+		assert.False(t, m.OwnedBy(""))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		m := &Photo{OwnerUID: "uqxc08w3d0ej2283"}
+		m.SetOwner("pt9jtdre2lvl0y18")
+		assert.Equal(t, "uqxc08w3d0ej2283", m.OwnerUID)
+		m.SetOwner("")
+		assert.Equal(t, "", m.OwnerUID)
+		assert.False(t, m.OwnedBy(""))
+	})
+	t.Run("Fixture", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo11")
+		assert.True(t, m.OwnedBy(UserFixtures.Get("bob").UserUID))
+	})
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// PhotoText represents a text region found in a photo by optical character recognition.
+type PhotoText struct {
+	ID             uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	PhotoID        uint      `gorm:"index;" json:"-" yaml:"-"`
+	PhotoUID       string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	FileUID        string    `gorm:"type:VARBINARY(42);index;default:'';" json:"FileUID" yaml:"FileUID,omitempty"`
+	X              float32   `gorm:"type:FLOAT;" json:"X" yaml:"X,omitempty"`
+	Y              float32   `gorm:"type:FLOAT;" json:"Y" yaml:"Y,omitempty"`
+	W              float32   `gorm:"type:FLOAT;" json:"W" yaml:"W,omitempty"`
+	H              float32   `gorm:"type:FLOAT;" json:"H" yaml:"H,omitempty"`
+	TextLang       string    `gorm:"type:VARBINARY(16);default:'';" json:"Lang" yaml:"Lang,omitempty"`
+	TextConfidence float32   `gorm:"type:FLOAT;" json:"Confidence" yaml:"Confidence,omitempty"`
+	TextContent    string    `gorm:"type:TEXT;" json:"Text" yaml:"Text"`
+	CreatedAt      time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// PhotoTexts represents a list of text regions.
+type PhotoTexts []PhotoText
+
+// TableName returns the entity database table name.
+func (PhotoText) TableName() string {
+	return "photos_text"
+}
+
+// NewPhotoText returns a new text region for the file of a photo.
+func NewPhotoText(photo Photo, fileUID string, x, y, w, h float32, lang string, confidence float32, text string) PhotoText {
+	return PhotoText{
+		PhotoID:        photo.ID,
+		PhotoUID:       photo.PhotoUID,
+		FileUID:        fileUID,
+		X:              x,
+		Y:              y,
+		W:              w,
+		H:              h,
+		TextLang:       txt.Clip(lang, 16),
+		TextConfidence: confidence,
+		TextContent:    txt.Clip(text, txt.ClipText),
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *PhotoText) Create() error {
+	return Db().Create(m).Error
+}
+
+// Text returns the text of all regions, one per line.
+func (m PhotoTexts) Text() string {
+	lines := make([]string, len(m))
+
+	for i, t := range m {
+		lines[i] = t.TextContent
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ReplacePhotoTexts removes the text regions previously found in a photo and adds the new ones.
+func ReplacePhotoTexts(photoID uint, texts PhotoTexts) error {
+	if photoID < 1 {
+		return nil
+	}
+
+	if err := UnscopedDb().Delete(PhotoText{}, "photo_id = ?", photoID).Error; err != nil {
+		return err
+	}
+
+	for i := range texts {
+		texts[i].ID = 0
+		texts[i].PhotoID = photoID
+
+		if err := texts[i].Create(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package entity
+
+type PhotoTextMap map[string]PhotoText
+
+var PhotoTextFixtures = PhotoTextMap{
+	"sign": {
+		ID:             1000000,
+		PhotoID:        PhotoFixtures.Pointer("19800101_000002_D640C559").ID,
+		PhotoUID:       PhotoFixtures.Pointer("19800101_000002_D640C559").PhotoUID,
+		FileUID:        "ft8es39w45bnlqdw",
+		X:              0.1,
+		Y:              0.2,
+		W:              0.4,
+		H:              0.05,
+		TextLang:       "eng",
+		TextConfidence: 0.92,
+		TextContent:    "Welcome to Berlin",
+	},
+	"plate": {
+		ID:             1000001,
+		PhotoID:        PhotoFixtures.Pointer("19800101_000002_D640C559").ID,
+		PhotoUID:       PhotoFixtures.Pointer("19800101_000002_D640C559").PhotoUID,
+		FileUID:        "ft8es39w45bnlqdw",
+		X:              0.6,
+		Y:              0.7,
+		W:              0.2,
+		H:              0.04,
+		TextLang:       "eng",
+		TextConfidence: 0.81,
+		TextContent:    "B PP 2022",
+	},
+}
+
+// CreatePhotoTextFixtures inserts known entities into the database for testing.
+func CreatePhotoTextFixtures() {
+	for _, entity := range PhotoTextFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoText_TableName(t *testing.T) {
+	assert.Equal(t, "photos_text", PhotoText{}.TableName())
+}
+
+func TestNewPhotoText(t *testing.T) {
+	photo := PhotoFixtures.Get("19800101_000002_D640C559")
+	m := NewPhotoText(photo, "ft8es39w45bnlqdw", 0.1, 0.2, 0.3, 0.4, "eng", 0.9, "Hello World")
+
+	assert.Equal(t, photo.ID, m.PhotoID)
+	assert.Equal(t, photo.PhotoUID, m.PhotoUID)
+	assert.Equal(t, "ft8es39w45bnlqdw", m.FileUID)
+	assert.Equal(t, float32(0.3), m.W)
+	assert.Equal(t, "eng", m.TextLang)
+	assert.Equal(t, float32(0.9), m.TextConfidence)
+	assert.Equal(t, "Hello World", m.TextContent)
+}
+
+func TestPhotoTexts_Text(t *testing.T) {
+	m := PhotoTexts{{TextContent: "Hello"}, {TextContent: "World"}}
+
+	assert.Equal(t, "Hello\nWorld", m.Text())
+}
+
+func TestReplacePhotoTexts(t *testing.T) {
+	photo := PhotoFixtures.Get("Photo01")
+
+	texts := PhotoTexts{
+		NewPhotoText(photo, "", 0, 0, 0.5, 0.1, "eng", 0.8, "First"),
+		NewPhotoText(photo, "", 0, 0.5, 0.5, 0.1, "eng", 0.7, "Second"),
+	}
+
+	if err := ReplacePhotoTexts(photo.ID, texts); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReplacePhotoTexts(photo.ID, texts[1:]); err != nil {
+		t.Fatal(err)
+	}
+
+	var result PhotoTexts
+
+	if err := Db().Where("photo_id = ?", photo.ID).Find(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result, 1)
+	assert.Equal(t, "Second", result[0].TextContent)
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+)
+
+// TombstoneRetention specifies how long tombstones are kept. Clients that have not synced for a longer time
+// get a full snapshot instead of a delta, see photoprism.Snapshot.
+const TombstoneRetention = 90 * 24 * time.Hour
+
+// Tombstone represents a row that has been deleted permanently, so that clients syncing changes can delete it
+// as well. Tombstones are added by database triggers, see the migrations. RowKey contains the primary key,
+// or the values of composite keys separated by commas.
+type Tombstone struct {
+	ID        uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	RowTable  string    `gorm:"type:VARBINARY(64);index;" json:"Table" yaml:"Table"`
+	RowKey    string    `gorm:"type:VARBINARY(255);" json:"Key" yaml:"Key"`
+	CreatedAt time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// Tombstones represents a list of permanently deleted rows.
+type Tombstones []Tombstone
+
+// TableName returns the entity database table name.
+func (Tombstone) TableName() string {
+	return "tombstones"
+}
+
+// FindTombstones returns the rows of a table that have been deleted permanently since the specified time.
+func FindTombstones(table string, since time.Time) (result Tombstones, err error) {
+	err = UnscopedDb().Where("row_table = ? AND created_at >= ?", table, since.UTC().Truncate(time.Second)).Order("id").Find(&result).Error
+
+	return result, err
+}
+
+// DeleteTombstones removes tombstones created before the specified time and returns the number of deleted rows.
+func DeleteTombstones(before time.Time) (int, error) {
+	result := UnscopedDb().Where("created_at < ?", before.UTC()).Delete(&Tombstone{})
+
+	return int(result.RowsAffected), result.Error
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTombstones(t *testing.T) {
+	t.Run("PhotoLabel", func(t *testing.T) {
+		since := time.Now()
+		photo := PhotoFixtures.Get("Photo04")
+		label := FirstOrCreateLabel(NewLabel("Tombstone", 0))
+
+		if label == nil {
+			t.Fatal("label must not be nil")
+		}
+
+		m := NewPhotoLabel(photo.ID, label.ID, 10, "manual")
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := FindTombstones(PhotoLabel{}.TableName(), since)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key := fmt.Sprintf("%d,%d", photo.ID, label.ID)
+		found := false
+
+		for _, r := range result {
+			if r.RowKey == key {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("Future", func(t *testing.T) {
+		result, err := FindTombstones(PhotoLabel{}.TableName(), time.Now().Add(time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestDeleteTombstones(t *testing.T) {
+	expired := Tombstone{RowTable: "photos", RowKey: "1000999", CreatedAt: time.Now().Add(-2 * TombstoneRetention)}
+	recent := Tombstone{RowTable: "photos", RowKey: "1000998", CreatedAt: time.Now()}
+
+	if err := UnscopedDb().Create(&expired).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UnscopedDb().Create(&recent).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Delete(&recent)
+
+	n, err := DeleteTombstones(time.Now().Add(-TombstoneRetention))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, n, 1)
+
+	result, err := FindTombstones("photos", time.Now().Add(-3*TombstoneRetention))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+
+	for _, r := range result {
+		keys = append(keys, r.RowKey)
+	}
+
+	assert.NotContains(t, keys, expired.RowKey)
+	assert.Contains(t, keys, recent.RowKey)
+}
@@ -70,6 +70,7 @@ type User struct {
 	ResetToken     string     `gorm:"type:VARBINARY(64);" json:"-" yaml:"-"`
 	ApiToken       string     `gorm:"column:api_token;type:VARBINARY(128);" json:"-" yaml:"-"`
 	ApiSecret      string     `gorm:"column:api_secret;type:VARBINARY(128);" json:"-" yaml:"-"`
+	OidcSubject    string     `gorm:"type:VARBINARY(255);index;" json:"-" yaml:"-"`
 	LoginAttempts  int        `json:"-" yaml:"-"`
 	LoginAt        *time.Time `json:"-" yaml:"-"`
 	CreatedAt      time.Time  `json:"CreatedAt" yaml:"-"`
@@ -198,6 +199,38 @@ func FindUserByUID(uid string) *User {
 	}
 }
 
+// FindUserByOidcSubject returns the user linked to an OpenID Connect subject identifier, or nil if none was found.
+func FindUserByOidcSubject(subject string) *User {
+	if subject == "" {
+		return nil
+	}
+
+	result := User{}
+
+	if err := Db().Preload("Address").Where("oidc_subject = ?", subject).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Debugf("user with oidc subject %s not found", sanitize.Log(subject))
+		return nil
+	}
+}
+
+// FindUserByEmail returns an existing user by primary email address, or nil if not found.
+func FindUserByEmail(email string) *User {
+	if email == "" {
+		return nil
+	}
+
+	result := User{}
+
+	if err := Db().Preload("Address").Where("primary_email = ?", email).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Debugf("user with email %s not found", sanitize.Log(email))
+		return nil
+	}
+}
+
 // Delete marks the entity as deleted.
 func (m *User) Delete() error {
 	if m.ID <= 1 {
@@ -300,9 +333,7 @@ func (m *User) InvalidPassword(password string) bool {
 		return true
 	}
 
-	if (m.LoginAttempts - 5) > 0 {
-		time.Sleep(time.Second * 5 * time.Duration(m.LoginAttempts-5))
-	}
+	m.loginDelay()
 
 	pw := FindPassword(m.UserUID)
 
@@ -311,20 +342,39 @@ func (m *User) InvalidPassword(password string) bool {
 	}
 
 	if pw.InvalidPassword(password) {
-		if err := Db().Model(m).UpdateColumn("login_attempts", gorm.Expr("login_attempts + ?", 1)).Error; err != nil {
-			log.Errorf("user: %s (update login attempts)", err)
-		}
-
+		m.loginFailed()
 		return true
 	}
 
-	if err := Db().Model(m).Updates(map[string]interface{}{"login_attempts": 0, "login_at": TimeStamp()}).Error; err != nil {
-		log.Errorf("user: %s (update last login)", err)
+	// Failed attempts are reset after the passcode was entered if two-factor authentication is enabled.
+	if !m.TwoFactorEnabled() {
+		m.loginSucceeded()
 	}
 
 	return false
 }
 
+// loginDelay slows down further login attempts after too many have failed.
+func (m *User) loginDelay() {
+	if (m.LoginAttempts - 5) > 0 {
+		time.Sleep(time.Second * 5 * time.Duration(m.LoginAttempts-5))
+	}
+}
+
+// loginFailed increments the number of failed login attempts.
+func (m *User) loginFailed() {
+	if err := Db().Model(m).UpdateColumn("login_attempts", gorm.Expr("login_attempts + ?", 1)).Error; err != nil {
+		log.Errorf("user: %s (update login attempts)", err)
+	}
+}
+
+// loginSucceeded resets the number of failed login attempts and updates the last login time.
+func (m *User) loginSucceeded() {
+	if err := Db().Model(m).Updates(map[string]interface{}{"login_attempts": 0, "login_at": TimeStamp()}).Error; err != nil {
+		log.Errorf("user: %s (update last login)", err)
+	}
+}
+
 // Role returns the user role for ACL permission checks.
 func (m *User) Role() acl.Role {
 	if m.RoleAdmin {
@@ -350,6 +400,15 @@ func (m *User) Role() acl.Role {
 	return acl.RoleDefault
 }
 
+// SetRole changes the user role flags, unknown roles reset them to the default role.
+func (m *User) SetRole(role acl.Role) {
+	m.RoleAdmin = role == acl.RoleAdmin
+	m.RoleChild = role == acl.RoleChild
+	m.RoleFamily = role == acl.RoleFamily
+	m.RoleFriend = role == acl.RoleFriend
+	m.RoleGuest = role == acl.RoleGuest
+}
+
 // Validate Makes sure username and email are unique and meet requirements. Returns error if any property is invalid
 func (m *User) Validate() error {
 	if m.Username() == "" {
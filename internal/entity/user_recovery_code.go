@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// RecoveryCodeCount is the number of recovery codes generated when two-factor authentication is enabled.
+const RecoveryCodeCount = 10
+
+// recoveryCodeCost is the bcrypt cost of recovery code hashes. It is lower than for passwords,
+// since the codes are random and all of them may have to be compared when logging in.
+const recoveryCodeCost = bcrypt.DefaultCost
+
+type UserRecoveryCodes []UserRecoveryCode
+
+// UserRecoveryCode represents a one-time code that can be used instead of a TOTP passcode,
+// e.g. if the authenticator device got lost. Like passwords, only its hash is stored.
+type UserRecoveryCode struct {
+	ID        uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	UserUID   string     `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID"`
+	Hash      string     `gorm:"type:VARBINARY(255);" json:"-" yaml:"-"`
+	UsedAt    *time.Time `json:"UsedAt,omitempty" yaml:"-"`
+	CreatedAt time.Time  `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (UserRecoveryCode) TableName() string {
+	return "users_recovery_codes"
+}
+
+// normalizeRecoveryCode returns the recovery code in lowercase and without separators.
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+}
+
+// NewRecoveryCodes replaces the recovery codes of a user and returns the new codes.
+// They are only stored as hash and can't be displayed again.
+func NewRecoveryCodes(userUID string) (codes []string, err error) {
+	if err = DeleteRecoveryCodes(userUID); err != nil {
+		return codes, err
+	}
+
+	codes = make([]string, 0, RecoveryCodeCount)
+
+	for i := 0; i < RecoveryCodeCount; i++ {
+		code := rnd.Token(5) + "-" + rnd.Token(5)
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(normalizeRecoveryCode(code)), recoveryCodeCost)
+
+		if err != nil {
+			return codes, err
+		}
+
+		m := UserRecoveryCode{UserUID: userUID, Hash: string(hash)}
+
+		if err = Db().Create(&m).Error; err != nil {
+			return codes, err
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+// FindRecoveryCodes returns the unused recovery codes of a user.
+func FindRecoveryCodes(userUID string) (result UserRecoveryCodes, err error) {
+	err = Db().Where("user_uid = ? AND used_at IS NULL", userUID).Order("id").Find(&result).Error
+
+	return result, err
+}
+
+// RedeemRecoveryCode returns true if the code matches an unused recovery code of the user,
+// which is then marked as used.
+func RedeemRecoveryCode(userUID, code string) bool {
+	code = normalizeRecoveryCode(code)
+
+	if userUID == "" || code == "" {
+		return false
+	}
+
+	codes, err := FindRecoveryCodes(userUID)
+
+	if err != nil {
+		log.Errorf("user: %s (find recovery codes)", err)
+		return false
+	}
+
+	for _, m := range codes {
+		if bcrypt.CompareHashAndPassword([]byte(m.Hash), []byte(code)) != nil {
+			continue
+		}
+
+		if err := Db().Model(&m).UpdateColumn("used_at", TimeStamp()).Error; err != nil {
+			log.Errorf("user: %s (redeem recovery code)", err)
+			return false
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// DeleteRecoveryCodes removes all recovery codes of a user.
+func DeleteRecoveryCodes(userUID string) error {
+	return Db().Where("user_uid = ?", userUID).Delete(&UserRecoveryCode{}).Error
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, err := NewRecoveryCodes("uqxc08w3d0ej2283")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, codes, RecoveryCodeCount)
+	assert.Len(t, codes[0], 11)
+	assert.NotEqual(t, codes[0], codes[1])
+
+	found, err := FindRecoveryCodes("uqxc08w3d0ej2283")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, found, RecoveryCodeCount)
+	assert.NotContains(t, found[0].Hash, codes[0])
+
+	t.Run("Redeem", func(t *testing.T) {
+		assert.True(t, RedeemRecoveryCode("uqxc08w3d0ej2283", strings.ToUpper(codes[1])))
+		assert.False(t, RedeemRecoveryCode("uqxc08w3d0ej2283", codes[1]))
+		assert.False(t, RedeemRecoveryCode("uqxetse3cy5eo9z2", codes[2]))
+		assert.False(t, RedeemRecoveryCode("uqxc08w3d0ej2283", ""))
+
+		found, err := FindRecoveryCodes("uqxc08w3d0ej2283")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, found, RecoveryCodeCount-1)
+	})
+	t.Run("Replace", func(t *testing.T) {
+		if _, err := NewRecoveryCodes("uqxc08w3d0ej2283"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, RedeemRecoveryCode("uqxc08w3d0ej2283", codes[2]))
+	})
+
+	if err := DeleteRecoveryCodes("uqxc08w3d0ej2283"); err != nil {
+		t.Fatal(err)
+	}
+
+	found, _ = FindRecoveryCodes("uqxc08w3d0ej2283")
+	assert.Len(t, found, 0)
+}
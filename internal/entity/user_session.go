@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type UserSessions []UserSession
+
+// UserSession represents a client session. The secret session id is only stored as hash,
+// so that sessions can be listed and revoked by their public UID. Sessions created with a
+// personal API token keep its scope, so that they don't get more rights than the token.
+type UserSession struct {
+	SessHash    string    `gorm:"type:VARBINARY(64);primary_key;auto_increment:false;" json:"-" yaml:"-"`
+	SessUID     string    `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	UserUID     string    `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID"`
+	ShareTokens string    `gorm:"type:VARBINARY(2048);" json:"-" yaml:"-"`
+	ShareUIDs   string    `gorm:"type:VARBINARY(4096);" json:"-" yaml:"-"`
+	SessScope   string    `gorm:"type:VARBINARY(64);" json:"Scope" yaml:"Scope,omitempty"`
+	UserAgent   string    `gorm:"size:512;" json:"UserAgent" yaml:"UserAgent,omitempty"`
+	ClientIP    string    `gorm:"type:VARBINARY(64);" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	Current     bool      `gorm:"-" json:"Current" yaml:"-"`
+	ExpiresAt   time.Time `gorm:"index;" json:"ExpiresAt" yaml:"-"`
+	LastSeenAt  time.Time `json:"LastSeenAt" yaml:"-"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (UserSession) TableName() string {
+	return "users_sessions"
+}
+
+// SessionHash returns the hash that is stored instead of the secret session id.
+func SessionHash(id string) string {
+	h := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(h[:])
+}
+
+// NewUserSession returns a new session for the user that expires after the specified duration.
+func NewUserSession(id, userUID string, expires time.Duration) *UserSession {
+	now := TimeStamp()
+
+	return &UserSession{
+		SessHash:   SessionHash(id),
+		UserUID:    userUID,
+		ExpiresAt:  now.Add(expires),
+		LastSeenAt: now,
+	}
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *UserSession) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.SessUID, 'e') {
+		return nil
+	}
+
+	return scope.SetColumn("SessUID", rnd.PPID('e'))
+}
+
+// SetClient updates the user agent and IP address of the client.
+func (m *UserSession) SetClient(userAgent, clientIP string) {
+	m.UserAgent = txt.Clip(userAgent, 512)
+	m.ClientIP = txt.Clip(clientIP, 64)
+}
+
+// SetTokens updates the share tokens redeemed in this session.
+func (m *UserSession) SetTokens(tokens []string) {
+	m.ShareTokens = strings.Join(tokens, ",")
+}
+
+// Tokens returns the share tokens redeemed in this session.
+func (m *UserSession) Tokens() []string {
+	if m.ShareTokens == "" {
+		return nil
+	}
+
+	return strings.Split(m.ShareTokens, ",")
+}
+
+// SetShares updates the UIDs of the shares unlocked in this session.
+func (m *UserSession) SetShares(uids []string) {
+	m.ShareUIDs = strings.Join(uids, ",")
+}
+
+// Shares returns the UIDs of the shares unlocked in this session, e.g. with a link password.
+func (m *UserSession) Shares() []string {
+	if m.ShareUIDs == "" {
+		return nil
+	}
+
+	return strings.Split(m.ShareUIDs, ",")
+}
+
+// Expired tests if the session has expired.
+func (m *UserSession) Expired() bool {
+	return m.ExpiresAt.Before(time.Now())
+}
+
+// Save inserts a new row to the database or updates a row if the primary key already exists.
+func (m *UserSession) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the session from the database, so that it can no longer be used.
+func (m *UserSession) Delete() error {
+	return Db().Where("sess_hash = ?", m.SessHash).Delete(&UserSession{}).Error
+}
+
+// Seen updates the timestamp of the last activity.
+func (m *UserSession) Seen() {
+	seenAt := TimeStamp()
+
+	// Update the timestamp at most once per minute.
+	if seenAt.Sub(m.LastSeenAt) < time.Minute {
+		return
+	}
+
+	if err := Db().Model(m).UpdateColumn("last_seen_at", seenAt).Error; err != nil {
+		log.Errorf("session: %s (update last seen)", err)
+		return
+	}
+
+	m.LastSeenAt = seenAt
+}
+
+// FindUserSession returns the session with the secret id, or nil if it does not exist.
+func FindUserSession(id string) *UserSession {
+	if id == "" {
+		return nil
+	}
+
+	result := UserSession{}
+
+	if err := Db().Where("sess_hash = ?", SessionHash(id)).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserSessionByUID returns the session of a user with the specified UID, or nil if it does not exist.
+func FindUserSessionByUID(userUID, sessUID string) *UserSession {
+	result := UserSession{}
+
+	if err := Db().Where("user_uid = ? AND sess_uid = ?", userUID, sessUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserSessions returns the sessions of a user that have not expired, most recently used first.
+func FindUserSessions(userUID string) (result UserSessions, err error) {
+	err = Db().Where("user_uid = ? AND expires_at > ?", userUID, TimeStamp()).
+		Order("last_seen_at DESC, sess_uid").Find(&result).Error
+
+	return result, err
+}
+
+// DeleteUserSessions removes all sessions of a user except the one with the specified id, if any.
+func DeleteUserSessions(userUID, exceptID string) (deleted int64, err error) {
+	stmt := Db().Where("user_uid = ?", userUID)
+
+	if exceptID != "" {
+		stmt = stmt.Where("sess_hash <> ?", SessionHash(exceptID))
+	}
+
+	res := stmt.Delete(&UserSession{})
+
+	return res.RowsAffected, res.Error
+}
+
+// DeleteExpiredSessions removes all expired sessions from the database.
+func DeleteExpiredSessions() (deleted int64, err error) {
+	res := Db().Where("expires_at < ?", TimeStamp()).Delete(&UserSession{})
+
+	return res.RowsAffected, res.Error
+}
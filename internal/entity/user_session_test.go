@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestNewUserSession(t *testing.T) {
+	m := NewUserSession("abc", "uqxetse3cy5eo9z2", time.Hour)
+
+	assert.Equal(t, SessionHash("abc"), m.SessHash)
+	assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+	assert.False(t, m.Expired())
+	assert.Len(t, m.SessHash, 64)
+}
+
+func TestUserSession_Tokens(t *testing.T) {
+	m := NewUserSession("abc", "uqxetse3cy5eo9z2", time.Hour)
+
+	assert.Nil(t, m.Tokens())
+
+	m.SetTokens([]string{"1jxf3jfn2k", "4jxf3jfn2k"})
+
+	assert.Equal(t, "1jxf3jfn2k,4jxf3jfn2k", m.ShareTokens)
+	assert.Equal(t, []string{"1jxf3jfn2k", "4jxf3jfn2k"}, m.Tokens())
+}
+
+func TestUserSession_Save(t *testing.T) {
+	id := rnd.Token(10) + rnd.Token(10)
+
+	m := NewUserSession(id, "uqxc08w3d0ej2283", time.Hour)
+	m.SetClient("curl/7.79.1", "127.0.0.1")
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, rnd.IsPPID(m.SessUID, 'e'))
+
+	found := FindUserSession(id)
+
+	if assert.NotNil(t, found) {
+		assert.Equal(t, m.SessUID, found.SessUID)
+		assert.Equal(t, "curl/7.79.1", found.UserAgent)
+	}
+
+	assert.NotNil(t, FindUserSessionByUID("uqxc08w3d0ej2283", m.SessUID))
+	assert.Nil(t, FindUserSessionByUID("uqxetse3cy5eo9z2", m.SessUID))
+
+	sessions, err := FindUserSessions("uqxc08w3d0ej2283")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(sessions), 1)
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindUserSession(id))
+}
+
+func TestDeleteUserSessions(t *testing.T) {
+	current := NewUserSession("current", "uqxqg7i1kperxvu7", time.Hour)
+	other := NewUserSession("other", "uqxqg7i1kperxvu7", time.Hour)
+	expired := NewUserSession("expired", "uqxqg7i1kperxvu7", -time.Hour)
+
+	for _, m := range []*UserSession{current, other, expired} {
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sessions, err := FindUserSessions("uqxqg7i1kperxvu7")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, sessions, 2)
+
+	if deleted, err := DeleteExpiredSessions(); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.GreaterOrEqual(t, deleted, int64(1))
+	}
+
+	if deleted, err := DeleteUserSessions("uqxqg7i1kperxvu7", "current"); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, int64(1), deleted)
+	}
+
+	assert.NotNil(t, FindUserSession("current"))
+	assert.Nil(t, FindUserSession("other"))
+
+	if _, err := DeleteUserSessions("uqxqg7i1kperxvu7", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindUserSession("current"))
+}
@@ -0,0 +1,173 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// UserTokenPrefix is the prefix of personal API token secrets, so that they can be told apart from session IDs.
+const UserTokenPrefix = "pp_"
+
+// userTokenSize is the number of random bytes in a personal API token secret.
+const userTokenSize = 20
+
+type UserTokens []UserToken
+
+// UserToken represents a personal API token that can be used instead of a password by scripts and mobile clients.
+type UserToken struct {
+	TokenUID   string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	UserUID    string     `gorm:"type:VARBINARY(42);index;" json:"UserUID" yaml:"UserUID"`
+	TokenName  string     `gorm:"size:64;" json:"Name" yaml:"Name"`
+	TokenScope string     `gorm:"type:VARBINARY(16);" json:"Scope" yaml:"Scope"`
+	TokenHash  string     `gorm:"type:VARBINARY(64);unique_index;" json:"-" yaml:"-"`
+	Secret     string     `gorm:"-" json:"Secret,omitempty" yaml:"-"`
+	UsedAt     *time.Time `json:"UsedAt,omitempty" yaml:"-"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt  *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (UserToken) TableName() string {
+	return "users_tokens"
+}
+
+// NewUserToken returns a new personal API token with a random secret that is only stored as hash.
+func NewUserToken(userUID, name string, scope acl.Scope) *UserToken {
+	b := make([]byte, userTokenSize)
+
+	if _, err := rand.Read(b); err != nil {
+		log.Errorf("token: %s", err)
+		return nil
+	}
+
+	secret := UserTokenPrefix + hex.EncodeToString(b)
+
+	result := &UserToken{
+		UserUID:    userUID,
+		TokenName:  txt.Clip(sanitize.Name(name), txt.ClipUsername),
+		TokenScope: scope.String(),
+		TokenHash:  UserTokenHash(secret),
+		Secret:     secret,
+	}
+
+	if result.TokenName == "" {
+		result.TokenName = "API Token"
+	}
+
+	return result
+}
+
+// IsUserToken tests if the string looks like a personal API token secret.
+func IsUserToken(s string) bool {
+	if !strings.HasPrefix(s, UserTokenPrefix) || len(s) != len(UserTokenPrefix)+2*userTokenSize {
+		return false
+	}
+
+	return rnd.IsHex(strings.TrimPrefix(s, UserTokenPrefix))
+}
+
+// UserTokenHash returns the hash that is stored instead of the token secret.
+func UserTokenHash(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *UserToken) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.TokenUID, 'k') {
+		return nil
+	}
+
+	return scope.SetColumn("TokenUID", rnd.PPID('k'))
+}
+
+// Create inserts a new row to the database.
+func (m *UserToken) Create() error {
+	if !m.Scope().Valid() {
+		return fmt.Errorf("token: invalid scope %s", sanitize.Log(m.TokenScope))
+	} else if m.UserUID == "" || m.TokenHash == "" {
+		return fmt.Errorf("token: user and secret must not be empty")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Revoke marks the token as deleted, so that it can no longer be used.
+func (m *UserToken) Revoke() error {
+	return Db().Delete(m).Error
+}
+
+// Scope returns the token scope.
+func (m *UserToken) Scope() acl.Scope {
+	return acl.Scope(m.TokenScope)
+}
+
+// User returns the user the token belongs to, or nil if the user does not exist or is disabled.
+func (m *UserToken) User() *User {
+	if user := FindUserByUID(m.UserUID); user == nil || user.UserDisabled || !user.Registered() {
+		return nil
+	} else {
+		return user
+	}
+}
+
+// Used updates the timestamp of the last use.
+func (m *UserToken) Used() {
+	usedAt := TimeStamp()
+
+	// Update the timestamp at most once per minute.
+	if m.UsedAt != nil && usedAt.Sub(*m.UsedAt) < time.Minute {
+		return
+	}
+
+	if err := Db().Model(m).UpdateColumn("used_at", usedAt).Error; err != nil {
+		log.Errorf("token: %s (update last use)", err)
+		return
+	}
+
+	m.UsedAt = &usedAt
+}
+
+// FindUserToken returns the token matching the secret, or nil if it does not exist or has been revoked.
+func FindUserToken(secret string) *UserToken {
+	if !IsUserToken(secret) {
+		return nil
+	}
+
+	result := UserToken{}
+
+	if err := Db().Where("token_hash = ?", UserTokenHash(secret)).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserTokens returns the tokens of a user sorted by creation date.
+func FindUserTokens(userUID string) (result UserTokens, err error) {
+	err = Db().Where("user_uid = ?", userUID).Order("created_at, token_uid").Find(&result).Error
+	return result, err
+}
+
+// FindUserTokenByUID returns the token of a user with the specified UID, or nil if it does not exist.
+func FindUserTokenByUID(userUID, tokenUID string) *UserToken {
+	result := UserToken{}
+
+	if err := Db().Where("user_uid = ? AND token_uid = ?", userUID, tokenUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
@@ -0,0 +1,64 @@
+package entity
+
+import "github.com/photoprism/photoprism/internal/acl"
+
+type UserTokenMap map[string]UserToken
+
+func (m UserTokenMap) Get(name string) UserToken {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return UserToken{}
+}
+
+func (m UserTokenMap) Pointer(name string) *UserToken {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &UserToken{}
+}
+
+// UserTokenFixtures contain the secrets for testing, only their hashes are stored in the database.
+var UserTokenFixtures = UserTokenMap{
+	"alice_full": {
+		TokenUID:   "krf6b4amx2z6zk3s",
+		UserUID:    "uqxetse3cy5eo9z2",
+		TokenName:  "Backup Script",
+		TokenScope: acl.ScopeFull.String(),
+		TokenHash:  UserTokenHash("pp_a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"),
+		Secret:     "pp_a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1",
+	},
+	"alice_read": {
+		TokenUID:   "krf6b4amx2z6zk4r",
+		UserUID:    "uqxetse3cy5eo9z2",
+		TokenName:  "Photo Frame",
+		TokenScope: acl.ScopeRead.String(),
+		TokenHash:  UserTokenHash("pp_b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2"),
+		Secret:     "pp_b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2b2",
+	},
+	"alice_upload": {
+		TokenUID:   "krf6b4amx2z6zk5u",
+		UserUID:    "uqxetse3cy5eo9z2",
+		TokenName:  "Phone",
+		TokenScope: acl.ScopeUpload.String(),
+		TokenHash:  UserTokenHash("pp_c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3"),
+		Secret:     "pp_c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3c3",
+	},
+	"friend_full": {
+		TokenUID:   "krf6b4amx2z6zk6f",
+		UserUID:    "uqxqg7i1kperxvu7",
+		TokenName:  "Disabled User",
+		TokenScope: acl.ScopeFull.String(),
+		TokenHash:  UserTokenHash("pp_d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4"),
+		Secret:     "pp_d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4d4",
+	},
+}
+
+// CreateUserTokenFixtures inserts known entities into the database for testing.
+func CreateUserTokenFixtures() {
+	for _, entity := range UserTokenFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestNewUserToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewUserToken("uqxetse3cy5eo9z2", "my script", acl.ScopeRead)
+
+		assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+		assert.Equal(t, "My Script", m.TokenName)
+		assert.Equal(t, acl.ScopeRead, m.Scope())
+		assert.True(t, IsUserToken(m.Secret))
+		assert.Equal(t, UserTokenHash(m.Secret), m.TokenHash)
+		assert.NotEqual(t, m.Secret, NewUserToken("uqxetse3cy5eo9z2", "", acl.ScopeRead).Secret)
+	})
+	t.Run("DefaultName", func(t *testing.T) {
+		m := NewUserToken("uqxetse3cy5eo9z2", "", acl.ScopeFull)
+		assert.Equal(t, "API Token", m.TokenName)
+	})
+}
+
+func TestIsUserToken(t *testing.T) {
+	assert.True(t, IsUserToken("pp_a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"))
+	assert.False(t, IsUserToken("pp_a1a1"))
+	assert.False(t, IsUserToken("pp_x1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"))
+	assert.False(t, IsUserToken("a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1a1"))
+	assert.False(t, IsUserToken(""))
+}
+
+func TestUserToken_Create(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewUserToken("uqxc08w3d0ej2283", "Upload", acl.ScopeUpload)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, rnd.IsPPID(m.TokenUID, 'k'))
+
+		found := FindUserToken(m.Secret)
+
+		if assert.NotNil(t, found) {
+			assert.Equal(t, m.TokenUID, found.TokenUID)
+			assert.Equal(t, "", found.Secret)
+		}
+
+		if err := m.Revoke(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindUserToken(m.Secret))
+	})
+	t.Run("InvalidScope", func(t *testing.T) {
+		m := NewUserToken("uqxc08w3d0ej2283", "Upload", acl.Scope("admin"))
+		assert.Error(t, m.Create())
+	})
+}
+
+func TestFindUserToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := FindUserToken(UserTokenFixtures.Get("alice_read").Secret)
+
+		if assert.NotNil(t, m) {
+			assert.Equal(t, "Photo Frame", m.TokenName)
+			assert.Equal(t, acl.ScopeRead, m.Scope())
+
+			if user := m.User(); assert.NotNil(t, user) {
+				assert.Equal(t, "alice", user.UserName)
+			}
+
+			m.Used()
+			assert.NotNil(t, m.UsedAt)
+		}
+	})
+	t.Run("DisabledUser", func(t *testing.T) {
+		m := FindUserToken(UserTokenFixtures.Get("friend_full").Secret)
+
+		if assert.NotNil(t, m) {
+			assert.Nil(t, m.User())
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindUserToken("pp_0000000000000000000000000000000000000000"))
+		assert.Nil(t, FindUserToken("xxx"))
+	})
+}
+
+func TestFindUserTokens(t *testing.T) {
+	tokens, err := FindUserTokens("uqxetse3cy5eo9z2")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(tokens), 3)
+
+	if m := FindUserTokenByUID("uqxetse3cy5eo9z2", "krf6b4amx2z6zk3s"); assert.NotNil(t, m) {
+		assert.Equal(t, "Backup Script", m.TokenName)
+	}
+
+	assert.Nil(t, FindUserTokenByUID("uqxc08w3d0ej2283", "krf6b4amx2z6zk3s"))
+}
@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/totp"
+)
+
+// TotpIssuer is the default issuer name shown in authenticator apps.
+const TotpIssuer = "PhotoPrism"
+
+// UserTotp represents the time-based one-time password (TOTP) secret of a user for two-factor authentication.
+// It is only used for logging in after it has been verified with a valid passcode. Counter is the time step
+// of the last accepted passcode, so that passcodes cannot be used again.
+type UserTotp struct {
+	UserUID    string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UserUID" yaml:"UserUID"`
+	Secret     string     `gorm:"type:VARBINARY(64);" json:"-" yaml:"-"`
+	Counter    int64      `json:"-" yaml:"-"`
+	VerifiedAt *time.Time `json:"VerifiedAt,omitempty" yaml:"-"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (UserTotp) TableName() string {
+	return "users_totp"
+}
+
+// NewUserTotp returns a new, unverified TOTP secret for the specified user.
+func NewUserTotp(userUID string) (*UserTotp, error) {
+	if userUID == "" {
+		return nil, errors.New("totp: user uid must not be empty")
+	}
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserTotp{UserUID: userUID, Secret: secret}, nil
+}
+
+// FindUserTotp returns the TOTP secret of a user, or nil if none exists.
+func FindUserTotp(userUID string) *UserTotp {
+	if userUID == "" {
+		return nil
+	}
+
+	result := UserTotp{}
+
+	if err := Db().Where("user_uid = ?", userUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Enabled tests if the secret has been verified, so that a passcode is required for logging in.
+func (m *UserTotp) Enabled() bool {
+	return m.VerifiedAt != nil && m.Secret != ""
+}
+
+// Redeem tests if the passcode is currently valid and newer than the last accepted passcode.
+// The counter is updated with a single statement, so that concurrent requests cannot use the same passcode.
+func (m *UserTotp) Redeem(code string) bool {
+	c, ok := totp.Match(m.Secret, code, time.Now())
+
+	if !ok || int64(c) <= m.Counter {
+		return false
+	}
+
+	result := Db().Model(&UserTotp{}).Where("user_uid = ? AND counter < ?", m.UserUID, int64(c)).UpdateColumn("counter", int64(c))
+
+	if result.Error != nil {
+		log.Errorf("totp: %s (redeem passcode)", result.Error)
+		return false
+	} else if result.RowsAffected != 1 {
+		return false
+	}
+
+	m.Counter = int64(c)
+
+	return true
+}
+
+// URI returns the provisioning URI that can be displayed as QR code.
+func (m *UserTotp) URI(issuer, account string) string {
+	if issuer == "" {
+		issuer = TotpIssuer
+	}
+
+	return totp.URI(issuer, account, m.Secret)
+}
+
+// Verify enables the secret for logging in if the passcode is valid.
+func (m *UserTotp) Verify(code string) error {
+	c, ok := totp.Match(m.Secret, code, time.Now())
+
+	if !ok || int64(c) <= m.Counter {
+		return errors.New("totp: invalid passcode")
+	}
+
+	m.Counter = int64(c)
+	m.VerifiedAt = TimePointer()
+
+	return m.Save()
+}
+
+// Save inserts a new row to the database or updates a row if the primary key already exists.
+func (m *UserTotp) Save() error {
+	return Db().Save(m).Error
+}
+
+// Delete removes the secret from the database.
+func (m *UserTotp) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// TwoFactorEnabled tests if the user must enter a passcode after the password when logging in.
+func (m *User) TwoFactorEnabled() bool {
+	if !m.Registered() {
+		return false
+	}
+
+	if t := FindUserTotp(m.UserUID); t != nil {
+		return t.Enabled()
+	}
+
+	return false
+}
+
+// InvalidPasscode returns true if the passcode is neither valid for the TOTP secret of the user
+// nor an unused recovery code. Passcodes and recovery codes can only be used once.
+func (m *User) InvalidPasscode(code string) bool {
+	if !m.Registered() || code == "" {
+		return true
+	}
+
+	m.loginDelay()
+
+	t := FindUserTotp(m.UserUID)
+
+	if t == nil || !t.Enabled() {
+		return true
+	}
+
+	if !t.Redeem(code) && !RedeemRecoveryCode(m.UserUID, code) {
+		m.loginFailed()
+		return true
+	}
+
+	m.loginSucceeded()
+
+	return false
+}
+
+// ResetTwoFactor disables two-factor authentication and removes the TOTP secret and recovery codes of the user.
+func (m *User) ResetTwoFactor() error {
+	if err := DeleteRecoveryCodes(m.UserUID); err != nil {
+		return err
+	}
+
+	return Db().Where("user_uid = ?", m.UserUID).Delete(&UserTotp{}).Error
+}
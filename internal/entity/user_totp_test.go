@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/totp"
+)
+
+func TestNewUserTotp(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m, err := NewUserTotp("uqxc08w3d0ej2283")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "uqxc08w3d0ej2283", m.UserUID)
+		assert.Len(t, m.Secret, 32)
+		assert.False(t, m.Enabled())
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		m, err := NewUserTotp("")
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+}
+
+// enableTestTotp enables two-factor authentication for a user until the test is complete.
+func enableTestTotp(t *testing.T, userUID string) *UserTotp {
+	m := &UserTotp{UserUID: userUID, Secret: "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP", VerifiedAt: TimePointer()}
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return m
+}
+
+func TestFindUserTotp(t *testing.T) {
+	t.Run("Friend", func(t *testing.T) {
+		enableTestTotp(t, "uqxqg7i1kperxvu7")
+
+		m := FindUserTotp("uqxqg7i1kperxvu7")
+
+		if assert.NotNil(t, m) {
+			assert.True(t, m.Enabled())
+			assert.Equal(t, "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP", m.Secret)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindUserTotp("uqxetse3cy5eo9z2"))
+		assert.Nil(t, FindUserTotp(""))
+	})
+}
+
+func TestUserTotp_URI(t *testing.T) {
+	m := UserTotp{UserUID: "uqxqg7i1kperxvu7", Secret: "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"}
+
+	assert.Equal(t, "otpauth://totp/PhotoPrism:friend?algorithm=SHA1&digits=6&issuer=PhotoPrism&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP", m.URI("", "friend"))
+	assert.Contains(t, m.URI("Family Photos", "friend"), "issuer=Family+Photos")
+}
+
+func TestUserTotp_Verify(t *testing.T) {
+	m, err := NewUserTotp("uqxc08w3d0ej2283")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Error(t, m.Verify("000000x"))
+	assert.False(t, m.Enabled())
+
+	code, err := totp.Code(m.Secret, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Verify(code); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Enabled())
+
+	bob := UserFixtures.Pointer("bob")
+	assert.True(t, bob.TwoFactorEnabled())
+
+	if err := bob.ResetTwoFactor(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, bob.TwoFactorEnabled())
+	assert.Nil(t, FindUserTotp(bob.UserUID))
+}
+
+func TestUser_InvalidPasscode(t *testing.T) {
+	m := UserFixtures.Pointer("friend")
+	secret := enableTestTotp(t, m.UserUID).Secret
+
+	t.Run("Valid", func(t *testing.T) {
+		code, err := totp.Code(secret, time.Now())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.InvalidPasscode(code))
+	})
+	t.Run("Reused", func(t *testing.T) {
+		code, err := totp.Code(secret, time.Now().Add(-totp.Period*time.Second))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Passcodes of the same or an earlier time step are rejected once a passcode has been accepted.
+		assert.True(t, m.InvalidPasscode(code))
+
+		if code, err = totp.Code(secret, time.Now().Add(totp.Period*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.InvalidPasscode(code))
+		assert.True(t, m.InvalidPasscode(code))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.True(t, m.InvalidPasscode("12345"))
+		assert.True(t, m.InvalidPasscode(""))
+	})
+	t.Run("RecoveryCode", func(t *testing.T) {
+		codes, err := NewRecoveryCodes(m.UserUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.InvalidPasscode(codes[0]))
+		assert.True(t, m.InvalidPasscode(codes[0]))
+
+		if err := DeleteRecoveryCodes(m.UserUID); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("NotEnabled", func(t *testing.T) {
+		assert.True(t, UserFixtures.Pointer("alice").InvalidPasscode("123456"))
+		assert.False(t, UserFixtures.Pointer("alice").TwoFactorEnabled())
+	})
+}
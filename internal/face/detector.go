@@ -0,0 +1,77 @@
+package face
+
+import (
+	"strings"
+	"time"
+)
+
+// Supported detector names.
+const (
+	DetectorBuiltin     = "builtin"
+	DetectorInsightFace = "insightface"
+	DetectorGrpc        = "grpc"
+	DetectorNone        = "none"
+)
+
+// DefaultDetector is used if no detector name is configured.
+const DefaultDetector = DetectorInsightFace
+
+// Detector finds faces in an image file and returns them with their embeddings.
+//
+// Implementations must return the image dimensions in Face.Rows and Face.Cols, the face
+// area with its center and size in pixels, and at most one L2-normalized embedding per face.
+// Faces without embeddings can be displayed, but not be clustered or matched with people.
+type Detector interface {
+	// Name returns the detector name.
+	Name() string
+	// Detect returns the faces found in the image file.
+	Detect(fileName string, minSize, expected int) (Faces, error)
+}
+
+// DetectorOptions represents face detector settings.
+type DetectorOptions struct {
+	Name    string
+	Url     string
+	Timeout time.Duration
+}
+
+// NewDetector returns the face detector matching the options.
+func NewDetector(opt DetectorOptions) Detector {
+	name := strings.ToLower(strings.TrimSpace(opt.Name))
+
+	if name == "" {
+		name = DefaultDetector
+	}
+
+	switch name {
+	case DetectorBuiltin:
+		return NewBuiltin()
+	case DetectorInsightFace:
+		return NewInsightFace(opt.Url, opt.Timeout)
+	case DetectorGrpc:
+		return NewGrpc(opt.Url, opt.Timeout)
+	case DetectorNone:
+		return NewNoDetector()
+	default:
+		log.Warnf("faces: unknown detector %s, face detection disabled", opt.Name)
+		return NewNoDetector()
+	}
+}
+
+// NoDetector is used when face detection is disabled.
+type NoDetector struct{}
+
+// NewNoDetector returns a detector that never finds any faces.
+func NewNoDetector() *NoDetector {
+	return &NoDetector{}
+}
+
+// Name returns the detector name.
+func (d *NoDetector) Name() string {
+	return DetectorNone
+}
+
+// Detect always returns an empty result.
+func (d *NoDetector) Detect(fileName string, minSize, expected int) (Faces, error) {
+	return Faces{}, nil
+}
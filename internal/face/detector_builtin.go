@@ -0,0 +1,154 @@
+package face
+
+import (
+	_ "embed"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+
+	pigo "github.com/esimov/pigo/core"
+)
+
+//go:embed cascade/facefinder
+var cascadeFile []byte
+
+//go:embed cascade/puploc
+var puplocFile []byte
+
+// Builtin finds faces with the Pigo cascade classifier that is compiled into the binary.
+// It does not compute embeddings, so detected faces cannot be clustered or recognized.
+type Builtin struct {
+	classifier   *pigo.Pigo
+	puploc       *pigo.PuplocCascade
+	angle        float64
+	shiftFactor  float64
+	scaleFactor  float64
+	iouThreshold float64
+	perturb      int
+	err          error
+}
+
+// NewBuiltin returns a new built-in detector instance.
+func NewBuiltin() *Builtin {
+	d := &Builtin{
+		angle:        0.0,
+		shiftFactor:  0.1,
+		scaleFactor:  1.1,
+		iouThreshold: float64(OverlapThresholdFloor) / 100,
+		perturb:      63,
+	}
+
+	if d.classifier, d.err = pigo.NewPigo().Unpack(cascadeFile); d.err != nil {
+		return d
+	}
+
+	d.puploc, d.err = pigo.NewPuplocCascade().UnpackCascade(puplocFile)
+
+	return d
+}
+
+// Name returns the detector name.
+func (d *Builtin) Name() string {
+	return DetectorBuiltin
+}
+
+// Detect returns the faces found in the image file.
+func (d *Builtin) Detect(fileName string, minSize, expected int) (faces Faces, err error) {
+	if d.err != nil {
+		return faces, d.err
+	}
+
+	if minSize < 20 {
+		minSize = 20
+	}
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return faces, err
+	}
+
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+
+	if err != nil {
+		return faces, err
+	}
+
+	cols, rows := img.Bounds().Dx(), img.Bounds().Dy()
+
+	if cols < minSize || rows < minSize {
+		return faces, fmt.Errorf("image size %dx%d is too small", cols, rows)
+	}
+
+	params := pigo.CascadeParams{
+		MinSize:     minSize,
+		MaxSize:     min(cols, rows) - 4,
+		ShiftFactor: d.shiftFactor,
+		ScaleFactor: d.scaleFactor,
+		ImageParams: pigo.ImageParams{
+			Pixels: pigo.RgbToGrayscale(img),
+			Rows:   rows,
+			Cols:   cols,
+			Dim:    cols,
+		},
+	}
+
+	det := d.classifier.ClusterDetections(d.classifier.RunCascade(params, d.angle), d.iouThreshold)
+
+	for _, f := range det {
+		if f.Q < builtinQualityThreshold(f.Scale) {
+			continue
+		}
+
+		faces = append(faces, Face{
+			Rows:  rows,
+			Cols:  cols,
+			Score: int(min(f.Q, 100)),
+			Area:  NewArea("face", f.Row, f.Col, f.Scale),
+			Eyes:  d.eyes(f, params.ImageParams),
+		})
+	}
+
+	return faces, nil
+}
+
+// builtinQualityThreshold returns the scale adjusted cascade quality threshold, which uses
+// a different scale than the detection scores of neural network models.
+func builtinQualityThreshold(scale int) float32 {
+	return QualityThreshold(scale) - float32(ScoreThreshold) + BuiltinScoreThreshold
+}
+
+// eyes returns the eye positions of a face, if they can be found.
+func (d *Builtin) eyes(f pigo.Detection, img pigo.ImageParams) (result Areas) {
+	if f.Scale <= 50 {
+		return result
+	}
+
+	left := d.puploc.RunDetector(pigo.Puploc{
+		Row:      f.Row - int(0.075*float32(f.Scale)),
+		Col:      f.Col - int(0.175*float32(f.Scale)),
+		Scale:    float32(f.Scale) * 0.25,
+		Perturbs: d.perturb,
+	}, img, d.angle, false)
+
+	if left != nil && left.Row > 0 && left.Col > 0 {
+		result = append(result, NewArea("eye_l", left.Row, left.Col, int(left.Scale)))
+	}
+
+	right := d.puploc.RunDetector(pigo.Puploc{
+		Row:      f.Row - int(0.075*float32(f.Scale)),
+		Col:      f.Col + int(0.185*float32(f.Scale)),
+		Scale:    float32(f.Scale) * 0.25,
+		Perturbs: d.perturb,
+	}, img, d.angle, false)
+
+	if right != nil && right.Row > 0 && right.Col > 0 {
+		result = append(result, NewArea("eye_r", right.Row, right.Col, int(right.Scale)))
+	}
+
+	return result
+}
@@ -0,0 +1,31 @@
+package face
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuiltin_Detect(t *testing.T) {
+	d := NewBuiltin()
+
+	t.Run("Name", func(t *testing.T) {
+		assert.Equal(t, DetectorBuiltin, d.Name())
+	})
+	t.Run("1.jpg", func(t *testing.T) {
+		faces, err := d.Detect("testdata/1.jpg", 20, -1)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, faces)
+
+		for _, f := range faces {
+			assert.Greater(t, f.Rows, 0)
+			assert.Greater(t, f.Cols, 0)
+			assert.Empty(t, f.Embeddings)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := d.Detect("testdata/foo.jpg", 20, -1)
+		assert.Error(t, err)
+	})
+}
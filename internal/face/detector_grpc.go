@@ -0,0 +1,156 @@
+package face
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/photoprism/photoprism/internal/face/detectorpb"
+)
+
+// DefaultGrpcUrl is the default gRPC face detection service endpoint.
+const DefaultGrpcUrl = "http://localhost:50051"
+
+// GrpcDetector calls a gRPC face detection service, see detectorpb/detector.proto.
+//
+// The image data is sent along with the file name, so the service doesn't need access to
+// the storage folder. Transport security is used if the service URL starts with https://.
+type GrpcDetector struct {
+	timeout time.Duration
+	conn    *grpc.ClientConn
+	client  detectorpb.FaceDetectorClient
+	err     error
+}
+
+// NewGrpc returns a new gRPC detector instance. The connection is established with the first request.
+func NewGrpc(serviceUrl string, timeout time.Duration) *GrpcDetector {
+	d := &GrpcDetector{timeout: timeout}
+
+	target, creds, err := grpcTarget(serviceUrl)
+
+	if err != nil {
+		d.err = err
+		return d
+	}
+
+	if d.conn, d.err = grpc.NewClient(target, grpc.WithTransportCredentials(creds)); d.err == nil {
+		d.client = detectorpb.NewFaceDetectorClient(d.conn)
+	}
+
+	return d
+}
+
+// grpcTarget returns the client target and transport credentials for the service URL.
+func grpcTarget(serviceUrl string) (target string, creds credentials.TransportCredentials, err error) {
+	if serviceUrl == "" {
+		serviceUrl = DefaultGrpcUrl
+	} else if !strings.Contains(serviceUrl, "://") {
+		serviceUrl = "http://" + serviceUrl
+	}
+
+	u, err := url.Parse(serviceUrl)
+
+	if err != nil {
+		return "", nil, err
+	} else if u.Host == "" {
+		return "", nil, fmt.Errorf("invalid service url %s", serviceUrl)
+	}
+
+	switch u.Scheme {
+	case "http", "grpc":
+		return u.Host, insecure.NewCredentials(), nil
+	case "https", "grpcs":
+		return u.Host, credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12}), nil
+	default:
+		return "", nil, fmt.Errorf("unsupported service url scheme %s", u.Scheme)
+	}
+}
+
+// Name returns the detector name.
+func (d *GrpcDetector) Name() string {
+	return DetectorGrpc
+}
+
+// Close closes the connection to the service.
+func (d *GrpcDetector) Close() error {
+	if d.conn == nil {
+		return nil
+	}
+
+	return d.conn.Close()
+}
+
+// Detect returns the faces found in the image file.
+func (d *GrpcDetector) Detect(fileName string, minSize, expected int) (faces Faces, err error) {
+	if d.err != nil {
+		return faces, d.err
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return faces, err
+	}
+
+	ctx := context.Background()
+
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+
+	result, err := d.client.Detect(ctx, &detectorpb.DetectRequest{
+		FileName: fileName,
+		MinSize:  int32(minSize),
+		Expected: int32(expected),
+		Image:    data,
+	})
+
+	if err != nil {
+		return faces, err
+	} else if len(result.GetFaces()) == 0 {
+		return faces, nil
+	}
+
+	width, height := int(result.GetWidth()), int(result.GetHeight())
+
+	if width <= 0 || height <= 0 {
+		if width, height, err = imageSize(fileName); err != nil {
+			return faces, err
+		}
+	}
+
+	for _, f := range result.GetFaces() {
+		if len(f.GetBox()) != 4 {
+			log.Debugf("faces: ignored result with invalid bounding box %v", f.GetBox())
+			continue
+		}
+
+		kps := make([][2]float64, 0, len(f.GetLandmarks())/2)
+
+		for j := 0; j+1 < len(f.GetLandmarks()); j += 2 {
+			kps = append(kps, [2]float64{float64(f.Landmarks[j]), float64(f.Landmarks[j+1])})
+		}
+
+		embedding := make([]float64, len(f.GetEmbedding()))
+
+		for j, v := range f.GetEmbedding() {
+			embedding[j] = float64(v)
+		}
+
+		box := [4]float64{float64(f.Box[0]), float64(f.Box[1]), float64(f.Box[2]), float64(f.Box[3])}
+
+		faces = append(faces, NewDetectedFace(height, width, box, float64(f.GetScore()), kps, embedding))
+	}
+
+	return faces, nil
+}
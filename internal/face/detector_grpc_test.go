@@ -0,0 +1,104 @@
+package face
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/photoprism/photoprism/internal/face/detectorpb"
+)
+
+// StubGrpc implements the gRPC face detection service and responds with the result passed as argument.
+type StubGrpc struct {
+	detectorpb.UnimplementedFaceDetectorServer
+	Result *detectorpb.DetectResponse
+}
+
+// Detect returns the stub result.
+func (s *StubGrpc) Detect(ctx context.Context, req *detectorpb.DetectRequest) (*detectorpb.DetectResponse, error) {
+	if len(req.GetImage()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing image")
+	}
+
+	return s.Result, nil
+}
+
+// startStubGrpc starts a gRPC server with the stub service and returns its address.
+func startStubGrpc(t *testing.T, result *detectorpb.DetectResponse) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	detectorpb.RegisterFaceDetectorServer(srv, &StubGrpc{Result: result})
+
+	go func() { _ = srv.Serve(l) }()
+
+	t.Cleanup(srv.Stop)
+
+	return l.Addr().String()
+}
+
+func TestGrpcDetector_Detect(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		addr := startStubGrpc(t, &detectorpb.DetectResponse{
+			Width:  640,
+			Height: 480,
+			Faces: []*detectorpb.Face{
+				{Box: []float32{100, 100, 200, 220}, Score: 0.75, Landmarks: []float32{130, 150, 170, 150}, Embedding: []float32{3, 4}},
+				{Box: []float32{1, 2}, Score: 0.9},
+			},
+		})
+
+		d := NewGrpc("http://"+addr, time.Second)
+		defer d.Close()
+
+		faces, err := d.Detect("testdata/1.jpg", 20, -1)
+
+		assert.NoError(t, err)
+		assert.Len(t, faces, 1)
+		assert.Equal(t, 480, faces[0].Rows)
+		assert.Equal(t, 75, faces[0].Score)
+		assert.Equal(t, NewArea("face", 160, 150, 120), faces[0].Area)
+		assert.Len(t, faces[0].Landmarks, 2)
+		assert.Len(t, faces[0].Embeddings, 1)
+	})
+	t.Run("NoFaces", func(t *testing.T) {
+		d := NewGrpc(startStubGrpc(t, &detectorpb.DetectResponse{}), time.Second)
+		defer d.Close()
+
+		faces, err := d.Detect("testdata/1.jpg", 20, -1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, faces)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		d := NewGrpc(startStubGrpc(t, &detectorpb.DetectResponse{}), time.Second)
+		defer d.Close()
+
+		_, err := d.Detect("testdata/foo.jpg", 20, -1)
+
+		assert.Error(t, err)
+	})
+	t.Run("Unavailable", func(t *testing.T) {
+		d := NewGrpc("127.0.0.1:1", time.Second)
+		defer d.Close()
+
+		_, err := d.Detect("testdata/1.jpg", 20, -1)
+
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("InvalidUrl", func(t *testing.T) {
+		_, err := NewGrpc("ftp://localhost:50051", time.Second).Detect("testdata/1.jpg", 20, -1)
+
+		assert.Error(t, err)
+	})
+}
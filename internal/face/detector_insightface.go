@@ -0,0 +1,183 @@
+package face
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultInsightFaceUrl is the default InsightFace service endpoint.
+const DefaultInsightFaceUrl = "http://localhost:8008"
+
+// InsightFace represents a face as returned by an InsightFace compatible service.
+//
+// Box contains the top left and bottom right corner (x1, y1, x2, y2) and Kps the
+// x and y coordinates of the five facial landmarks (eyes, nose, mouth corners), both
+// in pixels. Embedding is not required to be normalized.
+type InsightFace struct {
+	Box       []float64   `json:"bbox"`
+	Kps       [][]float64 `json:"kps"`
+	Score     float64     `json:"det_score"`
+	Embedding []float64   `json:"embedding"`
+}
+
+// InsightFaceResponse represents a service response that includes the image size.
+type InsightFaceResponse struct {
+	Width  int           `json:"width"`
+	Height int           `json:"height"`
+	Faces  []InsightFace `json:"faces"`
+}
+
+// InsightFaceDetector sends image file names to an InsightFace compatible HTTP service.
+//
+// Protocol:
+//
+//	GET <url>?f=<absolute image file name>
+//
+// The service must be able to read the file and respond with status 200 and either
+// a JSON encoded InsightFaceResponse, or a JSON array of InsightFace results with the
+// image size in the "X-Width" and "X-Height" response headers. If the image size is
+// missing, it is read from the file.
+type InsightFaceDetector struct {
+	url    string
+	client *http.Client
+}
+
+// NewInsightFace returns a new InsightFace detector instance.
+func NewInsightFace(serviceUrl string, timeout time.Duration) *InsightFaceDetector {
+	if serviceUrl == "" {
+		serviceUrl = DefaultInsightFaceUrl
+	}
+
+	return &InsightFaceDetector{url: serviceUrl, client: &http.Client{Timeout: timeout}}
+}
+
+// Name returns the detector name.
+func (d *InsightFaceDetector) Name() string {
+	return DetectorInsightFace
+}
+
+// Detect returns the faces found in the image file.
+func (d *InsightFaceDetector) Detect(fileName string, minSize, expected int) (faces Faces, err error) {
+	reqUrl := d.url
+
+	if strings.Contains(reqUrl, "?") {
+		reqUrl += "&f=" + url.QueryEscape(fileName)
+	} else {
+		reqUrl += "?f=" + url.QueryEscape(fileName)
+	}
+
+	resp, err := d.client.Get(reqUrl)
+
+	if err != nil {
+		return faces, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return faces, fmt.Errorf("service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return faces, err
+	}
+
+	var result InsightFaceResponse
+
+	if trimmed := strings.TrimSpace(string(body)); strings.HasPrefix(trimmed, "[") {
+		if err = json.Unmarshal(body, &result.Faces); err != nil {
+			return faces, err
+		}
+
+		result.Width, _ = strconv.Atoi(resp.Header.Get("X-Width"))
+		result.Height, _ = strconv.Atoi(resp.Header.Get("X-Height"))
+	} else if err = json.Unmarshal(body, &result); err != nil {
+		return faces, err
+	}
+
+	if len(result.Faces) == 0 {
+		return faces, nil
+	}
+
+	if result.Width <= 0 || result.Height <= 0 {
+		if result.Width, result.Height, err = imageSize(fileName); err != nil {
+			return faces, err
+		}
+	}
+
+	for _, f := range result.Faces {
+		if len(f.Box) != 4 {
+			log.Debugf("faces: ignored result with invalid bounding box %v", f.Box)
+			continue
+		}
+
+		kps := make([][2]float64, 0, len(f.Kps))
+
+		for _, p := range f.Kps {
+			if len(p) >= 2 {
+				kps = append(kps, [2]float64{p[0], p[1]})
+			}
+		}
+
+		faces = append(faces, NewDetectedFace(result.Height, result.Width, [4]float64{f.Box[0], f.Box[1], f.Box[2], f.Box[3]}, f.Score, kps, f.Embedding))
+	}
+
+	return faces, nil
+}
+
+// imageSize returns the width and height of an image file.
+func imageSize(fileName string) (width, height int, err error) {
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer file.Close()
+
+	cfg, _, err := image.DecodeConfig(file)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return cfg.Width, cfg.Height, nil
+}
+
+// NewDetectedFace creates a face from a bounding box with the top left and bottom right corner in pixels,
+// a detection score between 0 and 1, optional landmark coordinates, and an optional embedding.
+func NewDetectedFace(rows, cols int, box [4]float64, score float64, kps [][2]float64, embedding []float64) Face {
+	w := box[2] - box[0]
+	h := box[3] - box[1]
+
+	f := Face{
+		Rows:      rows,
+		Cols:      cols,
+		Score:     int(score * 100),
+		Area:      NewArea("face", int((box[3]+box[1])/2.0), int((box[2]+box[0])/2.0), int(Max64(w, h))),
+		Eyes:      make(Areas, 0),
+		Landmarks: make(Areas, len(kps)),
+	}
+
+	for j, p := range kps {
+		f.Landmarks[j] = NewArea("l", int(p[1]), int(p[0]), 1)
+	}
+
+	if len(embedding) > 0 {
+		q, e := L2Norm64(embedding, 1e-12)
+		f.Q = q
+		f.Embeddings = Embeddings{NewEmbedding(e)}
+	}
+
+	return f
+}
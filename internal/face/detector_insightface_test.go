@@ -0,0 +1,112 @@
+package face
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// StubInsightFace returns an HTTP handler that implements the InsightFace detector protocol
+// and responds with the faces passed as argument.
+func StubInsightFace(width, height int, faces []InsightFace) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("f") == "" {
+			http.Error(w, "missing file name", http.StatusBadRequest)
+			return
+		}
+
+		if faces == nil {
+			faces = []InsightFace{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Width", strconv.Itoa(width))
+		w.Header().Set("X-Height", strconv.Itoa(height))
+
+		_ = json.NewEncoder(w).Encode(faces)
+	})
+}
+
+func TestInsightFaceDetector_Detect(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		srv := httptest.NewServer(StubInsightFace(640, 480, []InsightFace{
+			{
+				Box:       []float64{100, 100, 200, 220},
+				Kps:       [][]float64{{130, 150}, {170, 150}, {150, 170}, {135, 195}, {165, 195}},
+				Score:     0.87,
+				Embedding: []float64{1, 1, 1, 1},
+			},
+		}))
+		defer srv.Close()
+
+		faces, err := NewInsightFace(srv.URL, time.Second).Detect("testdata/1.jpg", 20, -1)
+
+		assert.NoError(t, err)
+		assert.Len(t, faces, 1)
+		assert.Equal(t, 480, faces[0].Rows)
+		assert.Equal(t, 640, faces[0].Cols)
+		assert.Equal(t, 87, faces[0].Score)
+		assert.Equal(t, NewArea("face", 160, 150, 120), faces[0].Area)
+		assert.Len(t, faces[0].Landmarks, 5)
+		assert.Len(t, faces[0].Embeddings, 1)
+		assert.InDelta(t, 0.5, faces[0].Embeddings[0][0], 0.0001)
+	})
+	t.Run("NoFaces", func(t *testing.T) {
+		srv := httptest.NewServer(StubInsightFace(640, 480, nil))
+		defer srv.Close()
+
+		faces, err := NewInsightFace(srv.URL, time.Second).Detect("testdata/1.jpg", 20, -1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, faces)
+	})
+	t.Run("InvalidBox", func(t *testing.T) {
+		srv := httptest.NewServer(StubInsightFace(640, 480, []InsightFace{{Box: []float64{}, Score: 0.9}}))
+		defer srv.Close()
+
+		faces, err := NewInsightFace(srv.URL, time.Second).Detect("testdata/1.jpg", 20, -1)
+
+		assert.NoError(t, err)
+		assert.Empty(t, faces)
+	})
+	t.Run("MissingSize", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"faces":[{"bbox":[10,10,50,50],"det_score":0.7}]}`))
+		}))
+		defer srv.Close()
+
+		faces, err := NewInsightFace(srv.URL, time.Second).Detect("testdata/1.jpg", 20, -1)
+
+		assert.NoError(t, err)
+		assert.Len(t, faces, 1)
+		assert.Greater(t, faces[0].Rows, 0)
+		assert.Greater(t, faces[0].Cols, 0)
+		assert.Empty(t, faces[0].Landmarks)
+		assert.Empty(t, faces[0].Embeddings)
+	})
+	t.Run("StatusError", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		_, err := NewInsightFace(srv.URL, time.Second).Detect("testdata/1.jpg", 20, -1)
+
+		assert.Error(t, err)
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer srv.Close()
+
+		_, err := NewInsightFace(srv.URL, 50*time.Millisecond).Detect("testdata/1.jpg", 20, -1)
+
+		assert.Error(t, err)
+	})
+}
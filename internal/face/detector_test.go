@@ -0,0 +1,78 @@
+package face
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDetector(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		d := NewDetector(DetectorOptions{})
+		assert.Equal(t, DefaultDetector, d.Name())
+	})
+	t.Run("Builtin", func(t *testing.T) {
+		d := NewDetector(DetectorOptions{Name: DetectorBuiltin})
+		assert.Equal(t, DetectorBuiltin, d.Name())
+	})
+	t.Run("InsightFace", func(t *testing.T) {
+		d := NewDetector(DetectorOptions{Name: "InsightFace", Url: "http://localhost:9999", Timeout: time.Second})
+		assert.Equal(t, DetectorInsightFace, d.Name())
+	})
+	t.Run("Grpc", func(t *testing.T) {
+		d := NewDetector(DetectorOptions{Name: DetectorGrpc})
+		assert.Equal(t, DetectorGrpc, d.Name())
+	})
+	t.Run("None", func(t *testing.T) {
+		d := NewDetector(DetectorOptions{Name: DetectorNone})
+		assert.Equal(t, DetectorNone, d.Name())
+
+		faces, err := d.Detect("testdata/1.jpg", 20, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, faces)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		d := NewDetector(DetectorOptions{Name: "foo"})
+		assert.Equal(t, DetectorNone, d.Name())
+	})
+}
+
+func TestNet_Detect(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		faces, err := NewNet(NewBuiltin(), true).Detect("testdata/1.jpg", 20, false, -1)
+		assert.NoError(t, err)
+		assert.Empty(t, faces)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewNet(NewBuiltin(), false).Detect("testdata/foo.jpg", 20, false, -1)
+		assert.Error(t, err)
+	})
+	t.Run("NilDetector", func(t *testing.T) {
+		net := NewNet(nil, false)
+		assert.Equal(t, DetectorNone, net.Detector().Name())
+	})
+}
+
+func TestNewDetectedFace(t *testing.T) {
+	t.Run("Landmarks", func(t *testing.T) {
+		f := NewDetectedFace(100, 200, [4]float64{10, 20, 50, 80}, 0.9, [][2]float64{{20, 40}, {40, 40}}, []float64{3, 4})
+
+		assert.Equal(t, 100, f.Rows)
+		assert.Equal(t, 200, f.Cols)
+		assert.Equal(t, 90, f.Score)
+		assert.Equal(t, NewArea("face", 50, 30, 60), f.Area)
+		assert.Len(t, f.Landmarks, 2)
+		assert.Equal(t, NewArea("l", 40, 20, 1), f.Landmarks[0])
+		assert.Equal(t, 5.0, f.Q)
+		assert.Len(t, f.Embeddings, 1)
+		assert.InDelta(t, 0.6, f.Embeddings[0][0], 0.0001)
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		f := NewDetectedFace(100, 200, [4]float64{10, 20, 50, 80}, 0.5, nil, nil)
+
+		assert.Empty(t, f.Landmarks)
+		assert.Empty(t, f.Embeddings)
+		assert.Equal(t, 0.0, f.Q)
+	})
+}
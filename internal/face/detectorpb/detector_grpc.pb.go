@@ -0,0 +1,117 @@
+// Face detection service protocol used by the "grpc" face detector.
+//
+// Run "go generate ./internal/face/detectorpb" after changing this file.
+//
+// The service receives the image data along with the original file name and returns the
+// detected faces with their bounding box, landmarks, and embedding in pixel coordinates.
+// Embeddings don't need to be normalized. Requests are sent without transport security.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: detector.proto
+
+package detectorpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	FaceDetector_Detect_FullMethodName = "/photoprism.face.v1.FaceDetector/Detect"
+)
+
+// FaceDetectorClient is the client API for FaceDetector service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FaceDetectorClient interface {
+	Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*DetectResponse, error)
+}
+
+type faceDetectorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFaceDetectorClient(cc grpc.ClientConnInterface) FaceDetectorClient {
+	return &faceDetectorClient{cc}
+}
+
+func (c *faceDetectorClient) Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*DetectResponse, error) {
+	out := new(DetectResponse)
+	err := c.cc.Invoke(ctx, FaceDetector_Detect_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FaceDetectorServer is the server API for FaceDetector service.
+// All implementations must embed UnimplementedFaceDetectorServer
+// for forward compatibility
+type FaceDetectorServer interface {
+	Detect(context.Context, *DetectRequest) (*DetectResponse, error)
+	mustEmbedUnimplementedFaceDetectorServer()
+}
+
+// UnimplementedFaceDetectorServer must be embedded to have forward compatible implementations.
+type UnimplementedFaceDetectorServer struct {
+}
+
+func (UnimplementedFaceDetectorServer) Detect(context.Context, *DetectRequest) (*DetectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Detect not implemented")
+}
+func (UnimplementedFaceDetectorServer) mustEmbedUnimplementedFaceDetectorServer() {}
+
+// UnsafeFaceDetectorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FaceDetectorServer will
+// result in compilation errors.
+type UnsafeFaceDetectorServer interface {
+	mustEmbedUnimplementedFaceDetectorServer()
+}
+
+func RegisterFaceDetectorServer(s grpc.ServiceRegistrar, srv FaceDetectorServer) {
+	s.RegisterService(&FaceDetector_ServiceDesc, srv)
+}
+
+func _FaceDetector_Detect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaceDetectorServer).Detect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FaceDetector_Detect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaceDetectorServer).Detect(ctx, req.(*DetectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FaceDetector_ServiceDesc is the grpc.ServiceDesc for FaceDetector service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FaceDetector_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "photoprism.face.v1.FaceDetector",
+	HandlerType: (*FaceDetectorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Detect",
+			Handler:    _FaceDetector_Detect_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "detector.proto",
+}
@@ -0,0 +1,45 @@
+package face
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Net detects faces using the configured Detector.
+type Net struct {
+	detector Detector
+	disabled bool
+}
+
+// NewNet returns a new instance that uses the detector passed as argument.
+func NewNet(detector Detector, disabled bool) *Net {
+	if detector == nil {
+		detector = NewNoDetector()
+	}
+
+	return &Net{detector: detector, disabled: disabled}
+}
+
+// Detector returns the face detector in use.
+func (t *Net) Detector() Detector {
+	return t.detector
+}
+
+// Detect runs the face detector over the provided source image.
+func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int) (faces Faces, err error) {
+	if t.disabled {
+		return faces, nil
+	}
+
+	if !fs.FileExists(fileName) {
+		return faces, fmt.Errorf("faces: file %s not found", filepath.Base(fileName))
+	}
+
+	if faces, err = t.detector.Detect(fileName, minSize, expected); err != nil {
+		return Faces{}, fmt.Errorf("faces: %s (%s)", err, t.detector.Name())
+	}
+
+	return faces, nil
+}
@@ -8,6 +8,7 @@ var CropSize = crop.Sizes[crop.Tile112]          // Face image crop size for Fac
 var OverlapThreshold = 42                        // Face area overlap threshold in percent.
 var OverlapThresholdFloor = OverlapThreshold - 1 // Reduced overlap area to avoid rounding inconsistencies.
 var ScoreThreshold = 70.0                        // Min face score.
+var BuiltinScoreThreshold float32 = 9.0          // Min cascade quality score of the built-in detector.
 var ClusterScoreThreshold = 99                   // Min score for faces forming a cluster.
 var SizeThreshold = 112                          // Min face size in pixels.
 var ClusterSizeThreshold = 30                    // Min size for faces forming a cluster in pixels.
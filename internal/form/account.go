@@ -1,9 +1,12 @@
 package form
 
 import (
+	"fmt"
+
 	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/s3"
 )
 
 // Account represents a remote service account form for uploading, downloading or syncing media files.
@@ -15,6 +18,7 @@ type Account struct {
 	AccKey        string `json:"AccKey"`
 	AccUser       string `json:"AccUser"`
 	AccPass       string `json:"AccPass"`
+	AccHostKey    string `json:"AccHostKey"`
 	AccTimeout    string `json:"AccTimeout"` // Request timeout: default, high, medium, low, none
 	AccError      string `json:"AccError"`
 	AccShare      bool   `json:"AccShare"`   // Manual upload enabled, see SharePath, ShareSize, and ShareExpires.
@@ -38,12 +42,35 @@ func NewAccount(m interface{}) (f Account, err error) {
 }
 
 func (f *Account) ServiceDiscovery() error {
+	// Buckets cannot be discovered, so only check if they can be accessed.
+	if f.AccType == remote.ServiceS3 {
+		client, err := s3.New(f.AccURL, f.AccKey, f.AccUser, f.AccPass, 0)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = client.Files("/")
+
+		return err
+	}
+
 	acc, err := remote.Discover(f.AccURL, f.AccUser, f.AccPass)
 
 	if err != nil {
 		return err
 	}
 
+	// Keep the private key file of SFTP accounts.
+	if acc.AccKey == "" {
+		acc.AccKey = f.AccKey
+	}
+
+	// Verify the pinned SFTP host key instead of replacing it.
+	if f.AccHostKey != "" && acc.AccHostKey != "" && f.AccHostKey != acc.AccHostKey {
+		return fmt.Errorf("host key mismatch, expected %s but got %s", f.AccHostKey, acc.AccHostKey)
+	}
+
 	err = deepcopier.Copy(acc).To(f)
 
 	return err
@@ -0,0 +1,7 @@
+package form
+
+// AccountConflict represents a form for resolving a sync conflict by keeping the local or remote file.
+type AccountConflict struct {
+	RemoteName string `json:"RemoteName" binding:"required"`
+	Keep       string `json:"Keep" binding:"required"`
+}
@@ -1,6 +1,9 @@
 package form
 
 import (
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,4 +57,53 @@ func TestAccount_ServiceDiscovery(t *testing.T) {
 		err := account.ServiceDiscovery()
 		assert.Equal(t, "service URL is empty", err.Error())
 	})
+	t.Run("s3", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("<ListBucketResult></ListBucketResult>"))
+		}))
+
+		defer srv.Close()
+
+		account := Account{AccName: "Bucket", AccURL: srv.URL + "/photos", AccType: "s3", AccUser: "minio", AccPass: "minio123"}
+
+		assert.NoError(t, account.ServiceDiscovery())
+		assert.Equal(t, "s3", account.AccType)
+		assert.Equal(t, srv.URL+"/photos", account.AccURL)
+	})
+	t.Run("s3 without bucket", func(t *testing.T) {
+		account := Account{AccName: "Bucket", AccURL: "http://localhost:9000/", AccType: "s3"}
+
+		assert.Error(t, account.ServiceDiscovery())
+	})
+	t.Run("local", func(t *testing.T) {
+		dir := t.TempDir()
+		account := Account{AccURL: dir}
+
+		assert.NoError(t, account.ServiceDiscovery())
+		assert.Equal(t, "local", account.AccType)
+		assert.Equal(t, "file://"+dir, account.AccURL)
+	})
+	t.Run("sftp", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer l.Close()
+
+		go func() {
+			if conn, err := l.Accept(); err == nil {
+				_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_8.9\r\n"))
+				_ = conn.Close()
+			}
+		}()
+
+		account := Account{AccURL: "sftp://" + l.Addr().String() + "/photos", AccUser: "admin", AccKey: "/home/photoprism/.ssh/id_ed25519"}
+
+		assert.NoError(t, account.ServiceDiscovery())
+		assert.Equal(t, "sftp", account.AccType)
+		assert.Equal(t, "admin", account.AccUser)
+		assert.Equal(t, "/home/photoprism/.ssh/id_ed25519", account.AccKey)
+	})
 }
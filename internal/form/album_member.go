@@ -0,0 +1,18 @@
+package form
+
+import "strings"
+
+// AlbumMember represents a registered user an album is shared with.
+type AlbumMember struct {
+	User string `json:"User"` // Username or user UID.
+	Role string `json:"Role"` // "viewer" or "contributor".
+}
+
+// MemberRole returns the normalized member role, viewer by default.
+func (f AlbumMember) MemberRole() string {
+	if role := strings.ToLower(strings.TrimSpace(f.Role)); role != "" {
+		return role
+	}
+
+	return "viewer"
+}
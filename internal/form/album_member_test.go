@@ -0,0 +1,13 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumMember_MemberRole(t *testing.T) {
+	assert.Equal(t, "viewer", AlbumMember{User: "bob"}.MemberRole())
+	assert.Equal(t, "contributor", AlbumMember{User: "bob", Role: " Contributor "}.MemberRole())
+	assert.Equal(t, "owner", AlbumMember{User: "bob", Role: "owner"}.MemberRole())
+}
@@ -0,0 +1,6 @@
+package form
+
+// Duplicates represents a request to accept a near-duplicate or burst group suggestion.
+type Duplicates struct {
+	Action string `json:"Action"`
+}
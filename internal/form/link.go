@@ -2,11 +2,18 @@ package form
 
 // Link represents a link sharing form.
 type Link struct {
-	Password    string `json:"Password"`
-	ShareSlug   string `json:"Slug"`
-	LinkToken   string `json:"Token"`
-	LinkExpires int    `json:"Expires"`
-	MaxViews    uint   `json:"MaxViews"`
-	CanComment  bool   `json:"CanComment"`
-	CanEdit     bool   `json:"CanEdit"`
+	Password      string `json:"Password"`
+	ShareSlug     string `json:"Slug"`
+	LinkToken     string `json:"Token"`
+	LinkType      string `json:"Type"`
+	LinkExpires   int    `json:"Expires"`
+	MaxViews      uint   `json:"MaxViews"`
+	MaxDownloads  uint   `json:"MaxDownloads"`
+	ViewOnly      bool   `json:"ViewOnly"`
+	MaxUploads    uint   `json:"MaxUploads"`
+	MaxUploadSize int64  `json:"MaxUploadSize"`
+	UploadTypes   string `json:"UploadTypes"`
+	Moderate      bool   `json:"Moderate"`
+	CanComment    bool   `json:"CanComment"`
+	CanEdit       bool   `json:"CanEdit"`
 }
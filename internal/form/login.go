@@ -5,6 +5,7 @@ type Login struct {
 	UserName    string `json:"username"`
 	Password    string `json:"password"`
 	Token       string `json:"token"`
+	Passcode    string `json:"passcode"`
 	Counterless bool   `json:"counterless"`
 }
 
@@ -20,6 +21,10 @@ func (f Login) HasPassword() bool {
 	return f.Password != "" && len(f.Password) <= 255
 }
 
+func (f Login) HasPasscode() bool {
+	return f.Passcode != "" && len(f.Passcode) <= 255
+}
+
 func (f Login) HasCredentials() bool {
 	return f.HasUserName() && f.HasPassword()
 }
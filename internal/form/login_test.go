@@ -39,6 +39,17 @@ func TestLogin_HasPassword(t *testing.T) {
 	})
 }
 
+func TestLogin_HasPasscode(t *testing.T) {
+	t.Run("false", func(t *testing.T) {
+		form := &Login{UserName: "John", Password: "passwd"}
+		assert.Equal(t, false, form.HasPasscode())
+	})
+	t.Run("true", func(t *testing.T) {
+		form := &Login{UserName: "John", Password: "passwd", Passcode: "123456"}
+		assert.Equal(t, true, form.HasPasscode())
+	})
+}
+
 func TestLogin_HasCredentials(t *testing.T) {
 	t.Run("false", func(t *testing.T) {
 		form := &Login{Email: "test@test.com", Password: "passwd123", Token: ""}
@@ -0,0 +1,8 @@
+package form
+
+// Passcode represents a time-based one-time password or recovery code for two-factor authentication,
+// changes may alternatively be confirmed with the current password.
+type Passcode struct {
+	Passcode string `json:"Passcode"`
+	Password string `json:"Password"`
+}
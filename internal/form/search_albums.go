@@ -18,6 +18,8 @@ type SearchAlbums struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+
+	Scope SearchScope `form:"-" serialize:"-"`
 }
 
 func (f *SearchAlbums) GetQuery() string {
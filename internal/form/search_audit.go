@@ -0,0 +1,14 @@
+package form
+
+import "time"
+
+// SearchAudit represents search form fields for "/api/v1/audit".
+type SearchAudit struct {
+	User   string    `form:"user"`
+	Action string    `form:"action"`
+	UID    string    `form:"uid"`
+	After  time.Time `form:"after" time_format:"2006-01-02"`
+	Before time.Time `form:"before" time_format:"2006-01-02"`
+	Count  int       `form:"count" serialize:"-"`
+	Offset int       `form:"offset" serialize:"-"`
+}
@@ -50,6 +50,8 @@ type SearchGeo struct {
 	Lens     int       `form:"lens"`
 	Count    int       `form:"count" serialize:"-"`
 	Offset   int       `form:"offset" serialize:"-"`
+
+	Scope SearchScope `form:"-" serialize:"-"`
 }
 
 // GetQuery returns the query parameter as string.
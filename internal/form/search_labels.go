@@ -11,6 +11,8 @@ type SearchLabels struct {
 	Count    int    `form:"count" binding:"required" serialize:"-"`
 	Offset   int    `form:"offset" serialize:"-"`
 	Order    string `form:"order" serialize:"-"`
+
+	Scope SearchScope `form:"-" serialize:"-"`
 }
 
 func (f *SearchLabels) GetQuery() string {
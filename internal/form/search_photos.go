@@ -1,9 +1,13 @@
 package form
 
 import (
+	"strings"
 	"time"
 )
 
+// SemanticPrefix marks search queries that describe the image content, e.g. ~"dog on a beach".
+const SemanticPrefix = "~"
+
 // SearchPhotos represents search form fields for "/api/v1/photos".
 type SearchPhotos struct {
 	Query     string    `form:"q"`
@@ -71,8 +75,12 @@ type SearchPhotos struct {
 	Offset    int       `form:"offset" serialize:"-"`                   // Result FILE offset
 	Order     string    `form:"order" serialize:"-"`                    // Sort order
 	Merged    bool      `form:"merged" serialize:"-"`                   // Merge FILES in response
-	Notes     string    `form:"notes"`
+	Notes     string    `form:"notes"`                                  // Finds notes and text found by OCR
+	Ocr       string    `form:"ocr"`                                    // Finds text found by OCR only
+	Semantic  string    `form:"semantic"`                               // Finds photos matching a description
 	BeforeDay int       `form:"beforeday"`
+
+	Scope SearchScope `form:"-" serialize:"-"` // Limits results to items the user may access
 }
 
 func (f *SearchPhotos) GetQuery() string {
@@ -84,6 +92,8 @@ func (f *SearchPhotos) SetQuery(q string) {
 }
 
 func (f *SearchPhotos) ParseQueryString() error {
+	f.parseSemanticQuery()
+
 	if err := ParseQueryString(f); err != nil {
 		return err
 	}
@@ -116,6 +126,29 @@ func (f *SearchPhotos) ParseQueryString() error {
 	return nil
 }
 
+// parseSemanticQuery moves a description from the query to the semantic field. If it is quoted,
+// the rest of the query is parsed as usual, otherwise the whole query is used as description.
+func (f *SearchPhotos) parseSemanticQuery() {
+	q := strings.TrimSpace(f.Query)
+
+	if !strings.HasPrefix(q, SemanticPrefix) {
+		return
+	}
+
+	q = strings.TrimSpace(strings.TrimPrefix(q, SemanticPrefix))
+
+	if strings.HasPrefix(q, `"`) {
+		if end := strings.Index(q[1:], `"`); end >= 0 {
+			f.Semantic = strings.TrimSpace(q[1 : end+1])
+			f.Query = q[end+2:]
+			return
+		}
+	}
+
+	f.Semantic = strings.Trim(q, `"`)
+	f.Query = ""
+}
+
 // Serialize returns a string containing non-empty fields and values of a struct.
 func (f *SearchPhotos) Serialize() string {
 	return Serialize(f, false)
@@ -8,6 +8,7 @@ type SearchPhotosSlim struct {
 	Album   string    `form:"album"`
 	Path    string    `form:"path"`
 	Notes   string    `fotm:"notes"`
+	Ocr     string    `form:"ocr"`
 	Public  bool      `form:"public"`
 	Before  time.Time `form:"before" time_format:"2006-01-02"` // Finds images taken before date
 	After   time.Time `form:"after" time_format:"2006-01-02"`
@@ -16,4 +17,6 @@ type SearchPhotosSlim struct {
 	Count   int       `form:"count" binding:"required" serialize:"-"`
 	Offset  int       `form:"offset" serialize:"-"`
 	Order   string    `form:"order" serialize:"-"`
+
+	Scope SearchScope `form:"-" serialize:"-"`
 }
@@ -589,6 +589,48 @@ func TestParseQueryString(t *testing.T) {
 	})
 }
 
+func TestSearchPhotos_ParseSemanticQuery(t *testing.T) {
+	t.Run("Quoted", func(t *testing.T) {
+		form := &SearchPhotos{Query: `~"dog in the park" year:2020 cat`}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "dog in the park", form.Semantic)
+		assert.Equal(t, "2020", form.Year)
+		assert.Equal(t, "cat", form.Query)
+	})
+	t.Run("Unquoted", func(t *testing.T) {
+		form := &SearchPhotos{Query: "~ dog on a beach"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "dog on a beach", form.Semantic)
+		assert.Equal(t, "", form.Query)
+	})
+	t.Run("Filter", func(t *testing.T) {
+		form := &SearchPhotos{Query: `semantic:"red car"`}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "red car", form.Semantic)
+	})
+	t.Run("None", func(t *testing.T) {
+		form := &SearchPhotos{Query: "dog ~beach"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", form.Semantic)
+	})
+}
+
 func TestNewPhotoSearch(t *testing.T) {
 	r := NewPhotoSearch("cat")
 	assert.IsType(t, SearchPhotos{}, r)
@@ -0,0 +1,15 @@
+package form
+
+// SearchScope limits search results to items a user may access. It can't be set
+// with request parameters or the query string and must be applied by the caller.
+type SearchScope struct {
+	Owner   string   // User UID, results include the items owned by this user.
+	Shared  []string // Album UIDs shared with the user, results include their public items.
+	Library bool     // Include all public items of other users.
+	Member  string   // User UID, album results include the role of this user in albums shared with them.
+}
+
+// Empty tests if the search results are not limited.
+func (s SearchScope) Empty() bool {
+	return s.Owner == ""
+}
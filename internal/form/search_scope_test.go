@@ -0,0 +1,22 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchScope_Empty(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.True(t, SearchScope{}.Empty())
+		assert.True(t, SearchScope{Library: true}.Empty())
+	})
+	t.Run("Owner", func(t *testing.T) {
+		assert.False(t, SearchScope{Owner: "uqxc08w3d0ej2283"}.Empty())
+	})
+	t.Run("QueryString", func(t *testing.T) {
+		f := &SearchPhotos{Query: "scope:uqxc08w3d0ej2283 owner:uqxc08w3d0ej2283"}
+		_ = f.ParseQueryString()
+		assert.True(t, f.Scope.Empty())
+	})
+}
@@ -35,7 +35,7 @@ func Serialize(f interface{}, all bool) string {
 		fieldInfo := v.Type().Field(i).Tag.Get("serialize")
 
 		// Serialize field values as string.
-		if fieldName != "" && (fieldInfo != "-" || all) {
+		if fieldName != "" && fieldName != "-" && (fieldInfo != "-" || all) {
 			switch t := fieldValue.Interface().(type) {
 			case time.Time:
 				if val := fieldValue.Interface().(time.Time); !val.IsZero() {
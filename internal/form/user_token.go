@@ -0,0 +1,18 @@
+package form
+
+import "github.com/photoprism/photoprism/internal/acl"
+
+// UserToken represents a new personal API token.
+type UserToken struct {
+	Name  string `json:"Name"`
+	Scope string `json:"Scope"`
+}
+
+// TokenScope returns the token scope, read-only by default.
+func (f UserToken) TokenScope() acl.Scope {
+	if f.Scope == "" {
+		return acl.ScopeRead
+	}
+
+	return acl.ParseScope(f.Scope)
+}
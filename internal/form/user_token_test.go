@@ -0,0 +1,16 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+func TestUserToken_TokenScope(t *testing.T) {
+	assert.Equal(t, acl.ScopeRead, UserToken{}.TokenScope())
+	assert.Equal(t, acl.ScopeUpload, UserToken{Scope: "upload-only"}.TokenScope())
+	assert.Equal(t, acl.ScopeFull, UserToken{Scope: "full"}.TokenScope())
+	assert.Equal(t, acl.Scope(""), UserToken{Scope: "admin"}.TokenScope())
+}
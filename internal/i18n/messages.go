@@ -35,6 +35,11 @@ const (
 	ErrInvalidLink
 	ErrInvalidName
 	ErrBusy
+	ErrPasscodeRequired
+	ErrInvalidPasscode
+	ErrUploadQuota
+	ErrUnsupportedType
+	ErrTooManyAttempts
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -114,6 +119,11 @@ var Messages = MessageMap{
 	ErrInvalidLink:        gettext("Invalid link"),
 	ErrInvalidName:        gettext("Invalid name"),
 	ErrBusy:               gettext("Busy, please try again later"),
+	ErrPasscodeRequired:   gettext("Please enter your verification code"),
+	ErrInvalidPasscode:    gettext("Invalid verification code, please try again"),
+	ErrUploadQuota:        gettext("Upload limit exceeded"),
+	ErrUnsupportedType:    gettext("Unsupported file type"),
+	ErrTooManyAttempts:    gettext("Too many failed attempts, please try again later"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
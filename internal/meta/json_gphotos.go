@@ -4,19 +4,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"runtime/debug"
+	"strings"
 	"time"
 
 	"gopkg.in/photoprism/go-tz.v2/tz"
 )
 
 type GPhoto struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Views       int    `json:"imageViews,string"`
-	Geo         GGeo   `json:"geoData"`
-	TakenAt     GTime  `json:"photoTakenTime"`
-	CreatedAt   GTime  `json:"creationTime"`
-	UpdatedAt   GTime  `json:"modificationTime"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Views       int       `json:"imageViews,string"`
+	Geo         GGeo      `json:"geoData"`
+	TakenAt     GTime     `json:"photoTakenTime"`
+	CreatedAt   GTime     `json:"creationTime"`
+	UpdatedAt   GTime     `json:"modificationTime"`
+	Favorited   bool      `json:"favorited"`
+	Archived    bool      `json:"archived"`
+	Trashed     bool      `json:"trashed"`
+	People      []GPerson `json:"people"`
 }
 
 func (m GPhoto) SanitizedTitle() string {
@@ -27,6 +32,21 @@ func (m GPhoto) SanitizedDescription() string {
 	return SanitizeDescription(m.Description)
 }
 
+// PeopleNames returns the names of people tagged in the photo.
+func (m GPhoto) PeopleNames() (names []string) {
+	for _, p := range m.People {
+		if name := strings.TrimSpace(p.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+type GPerson struct {
+	Name string `json:"name"`
+}
+
 type GMeta struct {
 	Album GAlbum `json:"albumData"`
 }
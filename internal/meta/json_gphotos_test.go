@@ -0,0 +1,24 @@
+package meta
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGPhoto_PeopleNames(t *testing.T) {
+	t.Run("People", func(t *testing.T) {
+		p := GPhoto{}
+
+		if err := json.Unmarshal([]byte(`{"title": "IMG_1234.JPG", "favorited": true, "people": [{"name": "Jane Doe"}, {"name": " "}, {"name": " John Doe "}]}`), &p); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, p.Favorited)
+		assert.Equal(t, []string{"Jane Doe", "John Doe"}, p.PeopleNames())
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.Empty(t, GPhoto{}.PeopleNames())
+	})
+}
@@ -1,4 +1,15 @@
 // Code generated by go generate; DO NOT EDIT.
 package migrate
 
-var DialectMySQL = Migrations{}
+var DialectMySQL = Migrations{
+	{
+		ID:         "20261017-000003",
+		Dialect:    "mysql",
+		Statements: []string{"-- Full-text index covering titles, descriptions, keywords, notes, OCR text, labels, people and places.\nDROP TRIGGER IF EXISTS photo_search_photos_ai;", "DROP TRIGGER IF EXISTS photo_search_photos_au;", "DROP TRIGGER IF EXISTS photo_search_photos_ad;", "DROP TRIGGER IF EXISTS photo_search_details_ai;", "DROP TRIGGER IF EXISTS photo_search_details_au;", "DROP TRIGGER IF EXISTS photo_search_details_ad;", "DROP TRIGGER IF EXISTS photo_search_text_ai;", "DROP TRIGGER IF EXISTS photo_search_text_ad;", "DROP TRIGGER IF EXISTS photo_search_labels_ai;", "DROP TRIGGER IF EXISTS photo_search_labels_au;", "DROP TRIGGER IF EXISTS photo_search_labels_ad;", "DROP TRIGGER IF EXISTS photo_search_label_names_au;", "DROP TRIGGER IF EXISTS photo_search_markers_ai;", "DROP TRIGGER IF EXISTS photo_search_markers_au;", "DROP TRIGGER IF EXISTS photo_search_markers_ad;", "DROP TRIGGER IF EXISTS photo_search_subjects_au;", "DROP VIEW IF EXISTS photo_search_src;", "DROP TABLE IF EXISTS photo_search;", "CREATE TABLE photo_search (photo_id INT UNSIGNED NOT NULL, title TEXT, description TEXT, keywords TEXT, notes TEXT, ocr TEXT, labels TEXT, subjects TEXT, places TEXT, PRIMARY KEY (photo_id)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;", "-- The ngram parser also supports languages without spaces between words, such as Chinese and Japanese.\nCREATE FULLTEXT INDEX idx_photo_search_all ON photo_search (title, description, keywords, notes, ocr, labels, subjects, places) WITH PARSER ngram;", "CREATE FULLTEXT INDEX idx_photo_search_notes ON photo_search (notes, ocr) WITH PARSER ngram;", "CREATE FULLTEXT INDEX idx_photo_search_ocr ON photo_search (ocr) WITH PARSER ngram;", "-- Text indexed for each photo, labels include the names of their categories.\nCREATE VIEW photo_search_src AS SELECT p.id AS photo_id, COALESCE(p.photo_title, '') AS title, COALESCE(p.photo_description, '') AS description, COALESCE(d.keywords, '') AS keywords, COALESCE(d.notes, '') AS notes, COALESCE((SELECT GROUP_CONCAT(t.text_content SEPARATOR ' ') FROM photos_text t WHERE t.photo_id = p.id), '') AS ocr, CONCAT_WS(' ', (SELECT GROUP_CONCAT(l.label_name SEPARATOR ' ') FROM photos_labels pl JOIN labels l ON l.id = pl.label_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100), (SELECT GROUP_CONCAT(cl.label_name SEPARATOR ' ') FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels cl ON cl.id = c.category_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100)) AS labels, COALESCE((SELECT GROUP_CONCAT(CONCAT_WS(' ', s.subj_name, s.subj_alias) SEPARATOR ' ') FROM files f JOIN markers m ON m.file_uid = f.file_uid AND m.marker_invalid = 0 JOIN subjects s ON s.subj_uid = m.subj_uid WHERE f.photo_id = p.id), '') AS subjects, COALESCE((SELECT CONCAT_WS(' ', pc.place_label, pc.place_keywords) FROM places pc WHERE pc.id = p.place_id), '') AS places FROM photos p LEFT JOIN details d ON d.photo_id = p.id;", "-- Triggers to keep the full-text index up to date.\nCREATE TRIGGER photo_search_photos_ai AFTER INSERT ON photos FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id = NEW.id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = NEW.id; END;", "CREATE TRIGGER photo_search_photos_au AFTER UPDATE ON photos FOR EACH ROW BEGIN IF NOT (NEW.photo_title <=> OLD.photo_title AND NEW.photo_description <=> OLD.photo_description AND NEW.place_id <=> OLD.place_id) THEN DELETE FROM photo_search WHERE photo_id = NEW.id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = NEW.id; END IF; END;", "CREATE TRIGGER photo_search_photos_ad AFTER DELETE ON photos FOR EACH ROW DELETE FROM photo_search WHERE photo_id = OLD.id;", "CREATE TRIGGER photo_search_details_ai AFTER INSERT ON details FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id = NEW.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = NEW.photo_id; END;", "CREATE TRIGGER photo_search_details_au AFTER UPDATE ON details FOR EACH ROW BEGIN IF NOT (NEW.keywords <=> OLD.keywords AND NEW.notes <=> OLD.notes) THEN DELETE FROM photo_search WHERE photo_id = NEW.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = NEW.photo_id; END IF; END;", "CREATE TRIGGER photo_search_details_ad AFTER DELETE ON details FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id = OLD.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = OLD.photo_id; END;", "CREATE TRIGGER photo_search_text_ai AFTER INSERT ON photos_text FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id = NEW.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = NEW.photo_id; END;", "CREATE TRIGGER photo_search_text_ad AFTER DELETE ON photos_text FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id = OLD.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = OLD.photo_id; END;", "CREATE TRIGGER photo_search_labels_ai AFTER INSERT ON photos_labels FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id = NEW.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = NEW.photo_id; END;", "CREATE TRIGGER photo_search_labels_au AFTER UPDATE ON photos_labels FOR EACH ROW BEGIN IF NOT (NEW.uncertainty <=> OLD.uncertainty) THEN DELETE FROM photo_search WHERE photo_id = NEW.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = NEW.photo_id; END IF; END;", "CREATE TRIGGER photo_search_labels_ad AFTER DELETE ON photos_labels FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id = OLD.photo_id; INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id = OLD.photo_id; END;", "CREATE TRIGGER photo_search_label_names_au AFTER UPDATE ON labels FOR EACH ROW BEGIN IF NOT (NEW.label_name <=> OLD.label_name) THEN DELETE FROM photo_search WHERE photo_id IN (SELECT photo_id FROM photos_labels WHERE label_id = NEW.id); INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM photos_labels WHERE label_id = NEW.id); END IF; END;", "CREATE TRIGGER photo_search_markers_ai AFTER INSERT ON markers FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = NEW.file_uid); INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = NEW.file_uid); END;", "CREATE TRIGGER photo_search_markers_au AFTER UPDATE ON markers FOR EACH ROW BEGIN IF NOT (NEW.subj_uid <=> OLD.subj_uid AND NEW.marker_invalid <=> OLD.marker_invalid) THEN DELETE FROM photo_search WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = NEW.file_uid); INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = NEW.file_uid); END IF; END;", "CREATE TRIGGER photo_search_markers_ad AFTER DELETE ON markers FOR EACH ROW BEGIN DELETE FROM photo_search WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = OLD.file_uid); INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = OLD.file_uid); END;", "CREATE TRIGGER photo_search_subjects_au AFTER UPDATE ON subjects FOR EACH ROW BEGIN IF NOT (NEW.subj_name <=> OLD.subj_name AND NEW.subj_alias <=> OLD.subj_alias) THEN DELETE FROM photo_search WHERE photo_id IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_uid = f.file_uid WHERE m.subj_uid = NEW.subj_uid); INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src WHERE photo_id IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_uid = f.file_uid WHERE m.subj_uid = NEW.subj_uid); END IF; END;", "INSERT INTO photo_search (title, description, keywords, notes, ocr, labels, subjects, places, photo_id) SELECT title, description, keywords, notes, ocr, labels, subjects, places, photo_id FROM photo_search_src;"},
+	},
+	{
+		ID:         "20261018-000002",
+		Dialect:    "mysql",
+		Statements: []string{"-- Add tombstones for rows deleted permanently, so that clients syncing changes can delete them as well.\nDROP TRIGGER IF EXISTS tombstones_photos_ad;", "DROP TRIGGER IF EXISTS tombstones_files_ad;", "DROP TRIGGER IF EXISTS tombstones_albums_ad;", "DROP TRIGGER IF EXISTS tombstones_photos_albums_ad;", "DROP TRIGGER IF EXISTS tombstones_labels_ad;", "DROP TRIGGER IF EXISTS tombstones_photos_labels_ad;", "-- Timestamps are stored in UTC like those set by the ORM.\nCREATE TRIGGER tombstones_photos_ad AFTER DELETE ON photos FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());", "CREATE TRIGGER tombstones_files_ad AFTER DELETE ON files FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('files', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());", "CREATE TRIGGER tombstones_albums_ad AFTER DELETE ON albums FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('albums', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());", "CREATE TRIGGER tombstones_photos_albums_ad AFTER DELETE ON photos_albums FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_albums', CONCAT(OLD.photo_uid, ',', OLD.album_uid), UTC_TIMESTAMP());", "CREATE TRIGGER tombstones_labels_ad AFTER DELETE ON labels FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('labels', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());", "CREATE TRIGGER tombstones_photos_labels_ad AFTER DELETE ON photos_labels FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_labels', CONCAT(OLD.photo_id, ',', OLD.label_id), UTC_TIMESTAMP());"},
+	},
+}
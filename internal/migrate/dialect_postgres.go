@@ -0,0 +1,15 @@
+// Code generated by go generate; DO NOT EDIT.
+package migrate
+
+var DialectPostgres = Migrations{
+	{
+		ID:         "20261017-000005",
+		Dialect:    "postgres",
+		Statements: []string{"-- Full-text index covering titles, descriptions, keywords, notes, OCR text, labels, people and places.\nDROP TRIGGER IF EXISTS photo_search_photos ON photos;", "DROP TRIGGER IF EXISTS photo_search_details ON details;", "DROP TRIGGER IF EXISTS photo_search_text ON photos_text;", "DROP TRIGGER IF EXISTS photo_search_labels ON photos_labels;", "DROP TRIGGER IF EXISTS photo_search_label_names ON labels;", "DROP TRIGGER IF EXISTS photo_search_markers ON markers;", "DROP TRIGGER IF EXISTS photo_search_subjects ON subjects;", "DROP VIEW IF EXISTS photo_search_src;", "DROP TABLE IF EXISTS photo_search;", "-- Weights: A = title, B = description, labels and people, C = keywords, notes and places, D = OCR text.\nCREATE TABLE photo_search (photo_id INTEGER NOT NULL PRIMARY KEY, title TEXT, description TEXT, keywords TEXT, notes TEXT, ocr TEXT, labels TEXT, subjects TEXT, places TEXT, document TSVECTOR GENERATED ALWAYS AS (setweight(to_tsvector('simple', COALESCE(title, '')), 'A') || setweight(to_tsvector('simple', CONCAT_WS(' ', description, labels, subjects)), 'B') || setweight(to_tsvector('simple', CONCAT_WS(' ', keywords, notes, places)), 'C') || setweight(to_tsvector('simple', COALESCE(ocr, '')), 'D')) STORED, notes_document TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', CONCAT_WS(' ', notes, ocr))) STORED, ocr_document TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', COALESCE(ocr, ''))) STORED);", "CREATE INDEX idx_photo_search_all ON photo_search USING GIN (document);", "CREATE INDEX idx_photo_search_notes ON photo_search USING GIN (notes_document);", "CREATE INDEX idx_photo_search_ocr ON photo_search USING GIN (ocr_document);", "-- Text indexed for each photo, labels include the names of their categories.\nCREATE VIEW photo_search_src AS SELECT p.id AS photo_id, COALESCE(p.photo_title, '') AS title, COALESCE(p.photo_description, '') AS description, COALESCE(d.keywords, '') AS keywords, COALESCE(d.notes, '') AS notes, COALESCE((SELECT string_agg(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = p.id), '') AS ocr, CONCAT_WS(' ', (SELECT string_agg(l.label_name, ' ') FROM photos_labels pl JOIN labels l ON l.id = pl.label_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100), (SELECT string_agg(cl.label_name, ' ') FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels cl ON cl.id = c.category_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100)) AS labels, COALESCE((SELECT string_agg(CONCAT_WS(' ', s.subj_name, s.subj_alias), ' ') FROM files f JOIN markers m ON m.file_uid = f.file_uid AND m.marker_invalid = FALSE JOIN subjects s ON s.subj_uid = m.subj_uid WHERE f.photo_id = p.id), '') AS subjects, COALESCE((SELECT CONCAT_WS(' ', pc.place_label, pc.place_keywords) FROM places pc WHERE pc.id = p.place_id), '') AS places FROM photos p LEFT JOIN details d ON d.photo_id = p.id;", "-- Functions and triggers to keep the full-text index up to date.\nCREATE OR REPLACE FUNCTION photo_search_update(pid integer) RETURNS void AS $$ DELETE FROM photo_search WHERE photo_id = pid; INSERT INTO photo_search (photo_id, title, description, keywords, notes, ocr, labels, subjects, places) SELECT photo_id, title, description, keywords, notes, ocr, labels, subjects, places FROM photo_search_src WHERE photo_id = pid; $$ LANGUAGE sql;", "CREATE OR REPLACE FUNCTION photo_search_photos() RETURNS trigger AS $$ BEGIN IF TG_OP = 'DELETE' THEN DELETE FROM photo_search WHERE photo_id = OLD.id; ELSIF TG_OP = 'INSERT' THEN PERFORM photo_search_update(NEW.id); ELSIF NEW.photo_title IS DISTINCT FROM OLD.photo_title OR NEW.photo_description IS DISTINCT FROM OLD.photo_description OR NEW.place_id IS DISTINCT FROM OLD.place_id THEN PERFORM photo_search_update(NEW.id); END IF; RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE OR REPLACE FUNCTION photo_search_details() RETURNS trigger AS $$ BEGIN IF TG_OP = 'DELETE' THEN PERFORM photo_search_update(OLD.photo_id); ELSIF TG_OP = 'INSERT' THEN PERFORM photo_search_update(NEW.photo_id); ELSIF NEW.keywords IS DISTINCT FROM OLD.keywords OR NEW.notes IS DISTINCT FROM OLD.notes THEN PERFORM photo_search_update(NEW.photo_id); END IF; RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE OR REPLACE FUNCTION photo_search_text() RETURNS trigger AS $$ BEGIN IF TG_OP = 'DELETE' THEN PERFORM photo_search_update(OLD.photo_id); ELSE PERFORM photo_search_update(NEW.photo_id); END IF; RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE OR REPLACE FUNCTION photo_search_labels() RETURNS trigger AS $$ BEGIN IF TG_OP = 'DELETE' THEN PERFORM photo_search_update(OLD.photo_id); ELSIF TG_OP = 'INSERT' THEN PERFORM photo_search_update(NEW.photo_id); ELSIF NEW.uncertainty IS DISTINCT FROM OLD.uncertainty THEN PERFORM photo_search_update(NEW.photo_id); END IF; RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE OR REPLACE FUNCTION photo_search_label_names() RETURNS trigger AS $$ BEGIN IF NEW.label_name IS DISTINCT FROM OLD.label_name THEN PERFORM photo_search_update(pl.photo_id) FROM photos_labels pl WHERE pl.label_id = NEW.id; END IF; RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE OR REPLACE FUNCTION photo_search_markers() RETURNS trigger AS $$ BEGIN IF TG_OP = 'DELETE' THEN PERFORM photo_search_update(f.photo_id) FROM files f WHERE f.file_uid = OLD.file_uid; ELSIF TG_OP = 'INSERT' OR NEW.subj_uid IS DISTINCT FROM OLD.subj_uid OR NEW.marker_invalid IS DISTINCT FROM OLD.marker_invalid THEN PERFORM photo_search_update(f.photo_id) FROM files f WHERE f.file_uid = NEW.file_uid; END IF; RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE OR REPLACE FUNCTION photo_search_subjects() RETURNS trigger AS $$ BEGIN IF NEW.subj_name IS DISTINCT FROM OLD.subj_name OR NEW.subj_alias IS DISTINCT FROM OLD.subj_alias THEN PERFORM photo_search_update(f.photo_id) FROM files f JOIN markers m ON m.file_uid = f.file_uid WHERE m.subj_uid = NEW.subj_uid; END IF; RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE TRIGGER photo_search_photos AFTER INSERT OR UPDATE OR DELETE ON photos FOR EACH ROW EXECUTE FUNCTION photo_search_photos();", "CREATE TRIGGER photo_search_details AFTER INSERT OR UPDATE OR DELETE ON details FOR EACH ROW EXECUTE FUNCTION photo_search_details();", "CREATE TRIGGER photo_search_text AFTER INSERT OR DELETE ON photos_text FOR EACH ROW EXECUTE FUNCTION photo_search_text();", "CREATE TRIGGER photo_search_labels AFTER INSERT OR UPDATE OR DELETE ON photos_labels FOR EACH ROW EXECUTE FUNCTION photo_search_labels();", "CREATE TRIGGER photo_search_label_names AFTER UPDATE ON labels FOR EACH ROW EXECUTE FUNCTION photo_search_label_names();", "CREATE TRIGGER photo_search_markers AFTER INSERT OR UPDATE OR DELETE ON markers FOR EACH ROW EXECUTE FUNCTION photo_search_markers();", "CREATE TRIGGER photo_search_subjects AFTER UPDATE ON subjects FOR EACH ROW EXECUTE FUNCTION photo_search_subjects();", "INSERT INTO photo_search (photo_id, title, description, keywords, notes, ocr, labels, subjects, places) SELECT photo_id, title, description, keywords, notes, ocr, labels, subjects, places FROM photo_search_src;"},
+	},
+	{
+		ID:         "20261018-000003",
+		Dialect:    "postgres",
+		Statements: []string{"-- Add tombstones for rows deleted permanently, so that clients syncing changes can delete them as well.\nDROP TRIGGER IF EXISTS tombstones ON photos;", "DROP TRIGGER IF EXISTS tombstones ON files;", "DROP TRIGGER IF EXISTS tombstones ON albums;", "DROP TRIGGER IF EXISTS tombstones ON photos_albums;", "DROP TRIGGER IF EXISTS tombstones ON labels;", "DROP TRIGGER IF EXISTS tombstones ON photos_labels;", "-- The trigger arguments are the names of the key columns.\nCREATE OR REPLACE FUNCTION tombstones_add() RETURNS trigger AS $$ BEGIN INSERT INTO tombstones (row_table, row_key, created_at) SELECT TG_TABLE_NAME, string_agg(to_jsonb(OLD) ->> k.name, ',' ORDER BY k.pos), CURRENT_TIMESTAMP FROM unnest(TG_ARGV) WITH ORDINALITY AS k(name, pos); RETURN NULL; END; $$ LANGUAGE plpgsql;", "CREATE TRIGGER tombstones AFTER DELETE ON photos FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');", "CREATE TRIGGER tombstones AFTER DELETE ON files FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');", "CREATE TRIGGER tombstones AFTER DELETE ON albums FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');", "CREATE TRIGGER tombstones AFTER DELETE ON photos_albums FOR EACH ROW EXECUTE FUNCTION tombstones_add('photo_uid', 'album_uid');", "CREATE TRIGGER tombstones AFTER DELETE ON labels FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');", "CREATE TRIGGER tombstones AFTER DELETE ON photos_labels FOR EACH ROW EXECUTE FUNCTION tombstones_add('photo_id', 'label_id');"},
+	},
+}
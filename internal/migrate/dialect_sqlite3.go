@@ -1,4 +1,25 @@
 // Code generated by go generate; DO NOT EDIT.
 package migrate
 
-var DialectSQLite3 = Migrations{}
+var DialectSQLite3 = Migrations{
+	{
+		ID:         "20231005-000001",
+		Dialect:    "sqlite3",
+		Statements: []string{"CREATE VIRTUAL TABLE photo_search USING fts5(keywords, notes, content='', tokenize = 'simple', contentless_delete=1);", "-- Triggers to keep the FTS index up to date.\nCREATE TRIGGER photos_ai AFTER INSERT ON details BEGIN\n  INSERT INTO photo_search(rowid, keywords, notes) VALUES (new.photo_id, new.keywords, new.notes);\nEND;", "CREATE TRIGGER photos_ad AFTER DELETE ON details BEGIN\n  delete from photo_search where rowid = old.photo_id;\nEND;", "CREATE TRIGGER photos_au AFTER UPDATE ON details BEGIN\n  update photo_search set keywords = new.keywords, notes = new.notes where rowid = new.photo_id;\nEND;", "INSERT INTO photo_search(rowid, keywords, notes) select photo_id, keywords, notes from details;"},
+	},
+	{
+		ID:         "20261017-000001",
+		Dialect:    "sqlite3",
+		Statements: []string{"-- Recreate the FTS index with a separate column for text found by OCR.\nDROP TRIGGER IF EXISTS photos_ai;", "DROP TRIGGER IF EXISTS photos_ad;", "DROP TRIGGER IF EXISTS photos_au;", "DROP TABLE IF EXISTS photo_search;", "-- Move text previously found by OCR from the notes to the photos_text table.\nINSERT INTO photos_text (photo_id, photo_uid, file_uid, x, y, w, h, text_lang, text_confidence, text_content, created_at) SELECT d.photo_id, p.photo_uid, COALESCE(f.file_uid, ''), 0, 0, 1, 1, '', 0, d.notes, CURRENT_TIMESTAMP FROM details d JOIN photos p ON p.id = d.photo_id LEFT JOIN files f ON f.photo_id = d.photo_id AND f.file_primary = 1 WHERE d.notes_src = 'auto' AND d.notes <> '' AND d.photo_id NOT IN (SELECT photo_id FROM photos_text);", "UPDATE details SET notes = '', notes_src = '' WHERE notes_src = 'auto';", "CREATE VIRTUAL TABLE photo_search USING fts5(keywords, notes, ocr, content='', tokenize = 'simple', contentless_delete=1);", "-- Triggers to keep the FTS index up to date.\nCREATE TRIGGER photos_ai AFTER INSERT ON details BEGIN INSERT INTO photo_search(rowid, keywords, notes, ocr) VALUES (new.photo_id, new.keywords, new.notes, (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = new.photo_id)); END;", "CREATE TRIGGER photos_ad AFTER DELETE ON details BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; END;", "CREATE TRIGGER photos_au AFTER UPDATE ON details BEGIN UPDATE photo_search SET keywords = new.keywords, notes = new.notes, ocr = (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = new.photo_id) WHERE rowid = new.photo_id; END;", "CREATE TRIGGER photos_text_ai AFTER INSERT ON photos_text BEGIN UPDATE photo_search SET keywords = (SELECT d.keywords FROM details d WHERE d.photo_id = new.photo_id), notes = (SELECT d.notes FROM details d WHERE d.photo_id = new.photo_id), ocr = (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = new.photo_id) WHERE rowid = new.photo_id; END;", "CREATE TRIGGER photos_text_ad AFTER DELETE ON photos_text BEGIN UPDATE photo_search SET keywords = (SELECT d.keywords FROM details d WHERE d.photo_id = old.photo_id), notes = (SELECT d.notes FROM details d WHERE d.photo_id = old.photo_id), ocr = (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = old.photo_id) WHERE rowid = old.photo_id; END;", "INSERT INTO photo_search(rowid, keywords, notes, ocr) SELECT d.photo_id, d.keywords, d.notes, (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = d.photo_id) FROM details d;"},
+	},
+	{
+		ID:         "20261017-000002",
+		Dialect:    "sqlite3",
+		Statements: []string{"-- Recreate the FTS index so that it covers titles, descriptions, keywords, notes, OCR text, labels, people and places.\nDROP TRIGGER IF EXISTS photos_ai;", "DROP TRIGGER IF EXISTS photos_ad;", "DROP TRIGGER IF EXISTS photos_au;", "DROP TRIGGER IF EXISTS photos_text_ai;", "DROP TRIGGER IF EXISTS photos_text_ad;", "DROP TABLE IF EXISTS photo_search;", "DROP VIEW IF EXISTS photo_search_src;", "CREATE VIRTUAL TABLE photo_search USING fts5(title, description, keywords, notes, ocr, labels, subjects, places, content='', tokenize = 'simple', contentless_delete=1);", "-- Text indexed for each photo, labels include the names of their categories.\nCREATE VIEW photo_search_src AS SELECT p.id AS photo_id, COALESCE(p.photo_title, '') AS title, COALESCE(p.photo_description, '') AS description, COALESCE(d.keywords, '') AS keywords, COALESCE(d.notes, '') AS notes, COALESCE((SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = p.id), '') AS ocr, COALESCE((SELECT group_concat(l.label_name, ' ') FROM photos_labels pl JOIN labels l ON l.id = pl.label_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100), '') || ' ' || COALESCE((SELECT group_concat(cl.label_name, ' ') FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels cl ON cl.id = c.category_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100), '') AS labels, COALESCE((SELECT group_concat(s.subj_name || ' ' || s.subj_alias, ' ') FROM files f JOIN markers m ON m.file_uid = f.file_uid AND m.marker_invalid = 0 JOIN subjects s ON s.subj_uid = m.subj_uid WHERE f.photo_id = p.id), '') AS subjects, COALESCE((SELECT pc.place_label || ' ' || pc.place_keywords FROM places pc WHERE pc.id = p.place_id), '') AS places FROM photos p LEFT JOIN details d ON d.photo_id = p.id;", "-- Triggers to keep the FTS index up to date.\nCREATE TRIGGER photo_search_photos_ai AFTER INSERT ON photos BEGIN DELETE FROM photo_search WHERE rowid = new.id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.id; END;", "CREATE TRIGGER photo_search_photos_au AFTER UPDATE OF photo_title, photo_description, place_id ON photos BEGIN DELETE FROM photo_search WHERE rowid = new.id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.id; END;", "CREATE TRIGGER photo_search_photos_ad AFTER DELETE ON photos BEGIN DELETE FROM photo_search WHERE rowid = old.id; END;", "CREATE TRIGGER photo_search_details_ai AFTER INSERT ON details BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;", "CREATE TRIGGER photo_search_details_au AFTER UPDATE OF keywords, notes ON details BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;", "CREATE TRIGGER photo_search_details_ad AFTER DELETE ON details BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = old.photo_id; END;", "CREATE TRIGGER photo_search_text_ai AFTER INSERT ON photos_text BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;", "CREATE TRIGGER photo_search_text_ad AFTER DELETE ON photos_text BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = old.photo_id; END;", "CREATE TRIGGER photo_search_labels_ai AFTER INSERT ON photos_labels BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;", "CREATE TRIGGER photo_search_labels_au AFTER UPDATE OF uncertainty ON photos_labels BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;", "CREATE TRIGGER photo_search_labels_ad AFTER DELETE ON photos_labels BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = old.photo_id; END;", "CREATE TRIGGER photo_search_label_names_au AFTER UPDATE OF label_name ON labels BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM photos_labels WHERE label_id = new.id); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM photos_labels WHERE label_id = new.id); END;", "CREATE TRIGGER photo_search_markers_ai AFTER INSERT ON markers BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); END;", "CREATE TRIGGER photo_search_markers_au AFTER UPDATE OF subj_uid, marker_invalid ON markers BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); END;", "CREATE TRIGGER photo_search_markers_ad AFTER DELETE ON markers BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM files WHERE file_uid = old.file_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = old.file_uid); END;", "CREATE TRIGGER photo_search_subjects_au AFTER UPDATE OF subj_name, subj_alias ON subjects BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_uid = f.file_uid WHERE m.subj_uid = new.subj_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_uid = f.file_uid WHERE m.subj_uid = new.subj_uid); END;", "INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src;"},
+	},
+	{
+		ID:         "20261018-000001",
+		Dialect:    "sqlite3",
+		Statements: []string{"-- Add tombstones for rows deleted permanently, so that clients syncing changes can delete them as well.\nDROP TRIGGER IF EXISTS tombstones_photos_ad;", "DROP TRIGGER IF EXISTS tombstones_files_ad;", "DROP TRIGGER IF EXISTS tombstones_albums_ad;", "DROP TRIGGER IF EXISTS tombstones_photos_albums_ad;", "DROP TRIGGER IF EXISTS tombstones_labels_ad;", "DROP TRIGGER IF EXISTS tombstones_photos_labels_ad;", "-- Timestamps use the same format as the ORM, so that they can be compared.\nCREATE TRIGGER tombstones_photos_ad AFTER DELETE ON photos BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;", "CREATE TRIGGER tombstones_files_ad AFTER DELETE ON files BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('files', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;", "CREATE TRIGGER tombstones_albums_ad AFTER DELETE ON albums BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('albums', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;", "CREATE TRIGGER tombstones_photos_albums_ad AFTER DELETE ON photos_albums BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_albums', old.photo_uid || ',' || old.album_uid, strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;", "CREATE TRIGGER tombstones_labels_ad AFTER DELETE ON labels BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('labels', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;", "CREATE TRIGGER tombstones_photos_labels_ad AFTER DELETE ON photos_labels BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_labels', old.photo_id || ',' || old.label_id, strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;"},
+	},
+}
@@ -2,11 +2,13 @@ package migrate
 
 // Supported database dialects.
 const (
-	MySQL   = "mysql"
-	SQLite3 = "sqlite3"
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite3  = "sqlite3"
 )
 
 var Dialects = map[string]Migrations{
-	MySQL:   DialectMySQL,
-	SQLite3: DialectSQLite3,
+	MySQL:    DialectMySQL,
+	Postgres: DialectPostgres,
+	SQLite3:  DialectSQLite3,
 }
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"text/template"
 )
@@ -36,18 +37,37 @@ func gen_migrations(name string) {
 
 	fmt.Printf("generating %s...", dialect)
 
+	// Matches trigger bodies, which may contain multiple statements.
+	triggerBegin := regexp.MustCompile(`(?is)\bCREATE\s+TRIGGER\b.*\bBEGIN\b`)
+	triggerEnd := regexp.MustCompile(`(?i)\bEND$`)
+
 	strToStmts := func(b []byte) (result []string) {
 		stmts := bytes.Split(b, []byte(";\n"))
 		result = make([]string, 0, len(stmts))
 
+		var block []byte
+
 		for i := range stmts {
-			if s := bytes.TrimSpace(stmts[i]); len(s) > 0 {
+			// Statements in trigger bodies are kept together until the closing END.
+			if len(block) > 0 {
+				block = append(append(block, ";\n"...), stmts[i]...)
+			} else {
+				block = stmts[i]
+			}
+
+			if triggerBegin.Match(block) && !triggerEnd.Match(bytes.TrimSpace(block)) && i < len(stmts)-1 {
+				continue
+			}
+
+			if s := bytes.TrimSpace(block); len(s) > 0 {
 				if s[len(s)-1] != ';' {
 					s = append(s, ';')
 				}
 
 				result = append(result, string(s))
 			}
+
+			block = nil
 		}
 
 		return result
@@ -95,6 +115,7 @@ func gen_migrations(name string) {
 
 func main() {
 	gen_migrations("MySQL")
+	gen_migrations("Postgres")
 	gen_migrations("SQLite3")
 }
 
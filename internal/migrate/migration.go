@@ -7,6 +7,9 @@ import (
 	"github.com/jinzhu/gorm"
 )
 
+// NgramParser is the full-text index parser option for MySQL that is not supported by MariaDB.
+const NgramParser = " WITH PARSER ngram"
+
 // Migration represents a database schema migration.
 type Migration struct {
 	ID         string     `gorm:"size:16;primary_key;auto_increment:false;" json:"ID" yaml:"ID"`
@@ -111,6 +114,14 @@ func (m *Migration) Execute(db *gorm.DB) error {
 			} else if strings.HasPrefix(q, "DROP TABLE ") &&
 				strings.Contains(e, "DROP") {
 				log.Tracef("migrate: %s (ignored, probably didn't exist anymored)", err)
+			} else if strings.Contains(s, NgramParser) {
+				// MariaDB does not support the ngram full-text parser,
+				// so the index is created with the default parser instead.
+				if err = db.Exec(strings.Replace(s, NgramParser, "", 1)).Error; err != nil {
+					return err
+				}
+
+				log.Warnf("migrate: ngram full-text parser not supported, using default parser")
 			} else {
 				return err
 			}
@@ -0,0 +1,15 @@
+-- Add tombstones for rows deleted permanently, so that clients syncing changes can delete them as well.
+DROP TRIGGER IF EXISTS tombstones_photos_ad;
+DROP TRIGGER IF EXISTS tombstones_files_ad;
+DROP TRIGGER IF EXISTS tombstones_albums_ad;
+DROP TRIGGER IF EXISTS tombstones_photos_albums_ad;
+DROP TRIGGER IF EXISTS tombstones_labels_ad;
+DROP TRIGGER IF EXISTS tombstones_photos_labels_ad;
+
+-- Timestamps are stored in UTC like those set by the ORM.
+CREATE TRIGGER tombstones_photos_ad AFTER DELETE ON photos FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());
+CREATE TRIGGER tombstones_files_ad AFTER DELETE ON files FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('files', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());
+CREATE TRIGGER tombstones_albums_ad AFTER DELETE ON albums FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('albums', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());
+CREATE TRIGGER tombstones_photos_albums_ad AFTER DELETE ON photos_albums FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_albums', CONCAT(OLD.photo_uid, ',', OLD.album_uid), UTC_TIMESTAMP());
+CREATE TRIGGER tombstones_labels_ad AFTER DELETE ON labels FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('labels', CAST(OLD.id AS CHAR), UTC_TIMESTAMP());
+CREATE TRIGGER tombstones_photos_labels_ad AFTER DELETE ON photos_labels FOR EACH ROW INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_labels', CONCAT(OLD.photo_id, ',', OLD.label_id), UTC_TIMESTAMP());
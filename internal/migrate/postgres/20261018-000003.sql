@@ -0,0 +1,16 @@
+-- Add tombstones for rows deleted permanently, so that clients syncing changes can delete them as well.
+DROP TRIGGER IF EXISTS tombstones ON photos;
+DROP TRIGGER IF EXISTS tombstones ON files;
+DROP TRIGGER IF EXISTS tombstones ON albums;
+DROP TRIGGER IF EXISTS tombstones ON photos_albums;
+DROP TRIGGER IF EXISTS tombstones ON labels;
+DROP TRIGGER IF EXISTS tombstones ON photos_labels;
+
+-- The trigger arguments are the names of the key columns.
+CREATE OR REPLACE FUNCTION tombstones_add() RETURNS trigger AS $$ BEGIN INSERT INTO tombstones (row_table, row_key, created_at) SELECT TG_TABLE_NAME, string_agg(to_jsonb(OLD) ->> k.name, ',' ORDER BY k.pos), CURRENT_TIMESTAMP FROM unnest(TG_ARGV) WITH ORDINALITY AS k(name, pos); RETURN NULL; END; $$ LANGUAGE plpgsql;
+CREATE TRIGGER tombstones AFTER DELETE ON photos FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');
+CREATE TRIGGER tombstones AFTER DELETE ON files FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');
+CREATE TRIGGER tombstones AFTER DELETE ON albums FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');
+CREATE TRIGGER tombstones AFTER DELETE ON photos_albums FOR EACH ROW EXECUTE FUNCTION tombstones_add('photo_uid', 'album_uid');
+CREATE TRIGGER tombstones AFTER DELETE ON labels FOR EACH ROW EXECUTE FUNCTION tombstones_add('id');
+CREATE TRIGGER tombstones AFTER DELETE ON photos_labels FOR EACH ROW EXECUTE FUNCTION tombstones_add('photo_id', 'label_id');
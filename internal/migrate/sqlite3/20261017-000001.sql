@@ -0,0 +1,20 @@
+-- Recreate the FTS index with a separate column for text found by OCR.
+DROP TRIGGER IF EXISTS photos_ai;
+DROP TRIGGER IF EXISTS photos_ad;
+DROP TRIGGER IF EXISTS photos_au;
+DROP TABLE IF EXISTS photo_search;
+
+-- Move text previously found by OCR from the notes to the photos_text table.
+INSERT INTO photos_text (photo_id, photo_uid, file_uid, x, y, w, h, text_lang, text_confidence, text_content, created_at) SELECT d.photo_id, p.photo_uid, COALESCE(f.file_uid, ''), 0, 0, 1, 1, '', 0, d.notes, CURRENT_TIMESTAMP FROM details d JOIN photos p ON p.id = d.photo_id LEFT JOIN files f ON f.photo_id = d.photo_id AND f.file_primary = 1 WHERE d.notes_src = 'auto' AND d.notes <> '' AND d.photo_id NOT IN (SELECT photo_id FROM photos_text);
+UPDATE details SET notes = '', notes_src = '' WHERE notes_src = 'auto';
+
+CREATE VIRTUAL TABLE photo_search USING fts5(keywords, notes, ocr, content='', tokenize = 'simple', contentless_delete=1);
+
+-- Triggers to keep the FTS index up to date.
+CREATE TRIGGER photos_ai AFTER INSERT ON details BEGIN INSERT INTO photo_search(rowid, keywords, notes, ocr) VALUES (new.photo_id, new.keywords, new.notes, (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = new.photo_id)); END;
+CREATE TRIGGER photos_ad AFTER DELETE ON details BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; END;
+CREATE TRIGGER photos_au AFTER UPDATE ON details BEGIN UPDATE photo_search SET keywords = new.keywords, notes = new.notes, ocr = (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = new.photo_id) WHERE rowid = new.photo_id; END;
+CREATE TRIGGER photos_text_ai AFTER INSERT ON photos_text BEGIN UPDATE photo_search SET keywords = (SELECT d.keywords FROM details d WHERE d.photo_id = new.photo_id), notes = (SELECT d.notes FROM details d WHERE d.photo_id = new.photo_id), ocr = (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = new.photo_id) WHERE rowid = new.photo_id; END;
+CREATE TRIGGER photos_text_ad AFTER DELETE ON photos_text BEGIN UPDATE photo_search SET keywords = (SELECT d.keywords FROM details d WHERE d.photo_id = old.photo_id), notes = (SELECT d.notes FROM details d WHERE d.photo_id = old.photo_id), ocr = (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = old.photo_id) WHERE rowid = old.photo_id; END;
+
+INSERT INTO photo_search(rowid, keywords, notes, ocr) SELECT d.photo_id, d.keywords, d.notes, (SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = d.photo_id) FROM details d;
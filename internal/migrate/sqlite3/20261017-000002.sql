@@ -0,0 +1,33 @@
+-- Recreate the FTS index so that it covers titles, descriptions, keywords, notes, OCR text, labels, people and places.
+DROP TRIGGER IF EXISTS photos_ai;
+DROP TRIGGER IF EXISTS photos_ad;
+DROP TRIGGER IF EXISTS photos_au;
+DROP TRIGGER IF EXISTS photos_text_ai;
+DROP TRIGGER IF EXISTS photos_text_ad;
+DROP TABLE IF EXISTS photo_search;
+DROP VIEW IF EXISTS photo_search_src;
+
+CREATE VIRTUAL TABLE photo_search USING fts5(title, description, keywords, notes, ocr, labels, subjects, places, content='', tokenize = 'simple', contentless_delete=1);
+
+-- Text indexed for each photo, labels include the names of their categories.
+CREATE VIEW photo_search_src AS SELECT p.id AS photo_id, COALESCE(p.photo_title, '') AS title, COALESCE(p.photo_description, '') AS description, COALESCE(d.keywords, '') AS keywords, COALESCE(d.notes, '') AS notes, COALESCE((SELECT group_concat(t.text_content, ' ') FROM photos_text t WHERE t.photo_id = p.id), '') AS ocr, COALESCE((SELECT group_concat(l.label_name, ' ') FROM photos_labels pl JOIN labels l ON l.id = pl.label_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100), '') || ' ' || COALESCE((SELECT group_concat(cl.label_name, ' ') FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels cl ON cl.id = c.category_id WHERE pl.photo_id = p.id AND pl.uncertainty < 100), '') AS labels, COALESCE((SELECT group_concat(s.subj_name || ' ' || s.subj_alias, ' ') FROM files f JOIN markers m ON m.file_uid = f.file_uid AND m.marker_invalid = 0 JOIN subjects s ON s.subj_uid = m.subj_uid WHERE f.photo_id = p.id), '') AS subjects, COALESCE((SELECT pc.place_label || ' ' || pc.place_keywords FROM places pc WHERE pc.id = p.place_id), '') AS places FROM photos p LEFT JOIN details d ON d.photo_id = p.id;
+
+-- Triggers to keep the FTS index up to date.
+CREATE TRIGGER photo_search_photos_ai AFTER INSERT ON photos BEGIN DELETE FROM photo_search WHERE rowid = new.id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.id; END;
+CREATE TRIGGER photo_search_photos_au AFTER UPDATE OF photo_title, photo_description, place_id ON photos BEGIN DELETE FROM photo_search WHERE rowid = new.id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.id; END;
+CREATE TRIGGER photo_search_photos_ad AFTER DELETE ON photos BEGIN DELETE FROM photo_search WHERE rowid = old.id; END;
+CREATE TRIGGER photo_search_details_ai AFTER INSERT ON details BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;
+CREATE TRIGGER photo_search_details_au AFTER UPDATE OF keywords, notes ON details BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;
+CREATE TRIGGER photo_search_details_ad AFTER DELETE ON details BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = old.photo_id; END;
+CREATE TRIGGER photo_search_text_ai AFTER INSERT ON photos_text BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;
+CREATE TRIGGER photo_search_text_ad AFTER DELETE ON photos_text BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = old.photo_id; END;
+CREATE TRIGGER photo_search_labels_ai AFTER INSERT ON photos_labels BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;
+CREATE TRIGGER photo_search_labels_au AFTER UPDATE OF uncertainty ON photos_labels BEGIN DELETE FROM photo_search WHERE rowid = new.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = new.photo_id; END;
+CREATE TRIGGER photo_search_labels_ad AFTER DELETE ON photos_labels BEGIN DELETE FROM photo_search WHERE rowid = old.photo_id; INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id = old.photo_id; END;
+CREATE TRIGGER photo_search_label_names_au AFTER UPDATE OF label_name ON labels BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM photos_labels WHERE label_id = new.id); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM photos_labels WHERE label_id = new.id); END;
+CREATE TRIGGER photo_search_markers_ai AFTER INSERT ON markers BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); END;
+CREATE TRIGGER photo_search_markers_au AFTER UPDATE OF subj_uid, marker_invalid ON markers BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = new.file_uid); END;
+CREATE TRIGGER photo_search_markers_ad AFTER DELETE ON markers BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT photo_id FROM files WHERE file_uid = old.file_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT photo_id FROM files WHERE file_uid = old.file_uid); END;
+CREATE TRIGGER photo_search_subjects_au AFTER UPDATE OF subj_name, subj_alias ON subjects BEGIN DELETE FROM photo_search WHERE rowid IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_uid = f.file_uid WHERE m.subj_uid = new.subj_uid); INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src WHERE photo_id IN (SELECT f.photo_id FROM files f JOIN markers m ON m.file_uid = f.file_uid WHERE m.subj_uid = new.subj_uid); END;
+
+INSERT INTO photo_search(rowid, title, description, keywords, notes, ocr, labels, subjects, places) SELECT * FROM photo_search_src;
@@ -0,0 +1,15 @@
+-- Add tombstones for rows deleted permanently, so that clients syncing changes can delete them as well.
+DROP TRIGGER IF EXISTS tombstones_photos_ad;
+DROP TRIGGER IF EXISTS tombstones_files_ad;
+DROP TRIGGER IF EXISTS tombstones_albums_ad;
+DROP TRIGGER IF EXISTS tombstones_photos_albums_ad;
+DROP TRIGGER IF EXISTS tombstones_labels_ad;
+DROP TRIGGER IF EXISTS tombstones_photos_labels_ad;
+
+-- Timestamps use the same format as the ORM, so that they can be compared.
+CREATE TRIGGER tombstones_photos_ad AFTER DELETE ON photos BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;
+CREATE TRIGGER tombstones_files_ad AFTER DELETE ON files BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('files', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;
+CREATE TRIGGER tombstones_albums_ad AFTER DELETE ON albums BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('albums', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;
+CREATE TRIGGER tombstones_photos_albums_ad AFTER DELETE ON photos_albums BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_albums', old.photo_uid || ',' || old.album_uid, strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;
+CREATE TRIGGER tombstones_labels_ad AFTER DELETE ON labels BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('labels', CAST(old.id AS TEXT), strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;
+CREATE TRIGGER tombstones_photos_labels_ad AFTER DELETE ON photos_labels BEGIN INSERT INTO tombstones (row_table, row_key, created_at) VALUES ('photos_labels', old.photo_id || ',' || old.label_id, strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')); END;
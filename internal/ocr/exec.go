@@ -0,0 +1,73 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultBin is the default command-line OCR tool.
+const DefaultBin = "tesseract"
+
+// Exec runs a local command-line tool compatible with tesseract, e.g. "tesseract image.jpg stdout -l eng tsv".
+type Exec struct {
+	bin      string
+	language string
+	timeout  time.Duration
+}
+
+// NewExec returns a new exec engine instance.
+func NewExec(bin, language string, timeout time.Duration) *Exec {
+	if bin == "" {
+		bin = DefaultBin
+	}
+
+	return &Exec{bin: bin, language: language, timeout: timeout}
+}
+
+// Name returns the engine name.
+func (e *Exec) Name() string {
+	return EngineExec
+}
+
+// Args returns the command arguments for the image file.
+func (e *Exec) Args(fileName string) []string {
+	args := []string{fileName, "stdout"}
+
+	if e.language != "" {
+		args = append(args, "-l", e.language)
+	}
+
+	return append(args, "tsv")
+}
+
+// Detect returns the text lines found in the image file.
+func (e *Exec) Detect(fileName string) (Results, error) {
+	ctx := context.Background()
+
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, e.bin, e.Args(fileName)...)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return Results{}, fmt.Errorf("%s, %s", err, msg)
+		}
+
+		return Results{}, err
+	}
+
+	return ParseTsv(out.String(), e.language)
+}
@@ -0,0 +1,27 @@
+package ocr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExec_Args(t *testing.T) {
+	assert.Equal(t, []string{"foo.jpg", "stdout", "tsv"}, NewExec("", "", 0).Args("foo.jpg"))
+	assert.Equal(t, []string{"foo.jpg", "stdout", "-l", "deu", "tsv"}, NewExec("", "deu", 0).Args("foo.jpg"))
+}
+
+func TestExec_Detect(t *testing.T) {
+	t.Run("tsv", func(t *testing.T) {
+		results, err := NewExec("testdata/tesseract.sh", "eng", time.Second).Detect("foo.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello World\nPhotoPrism", results.Text())
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := NewExec("/path/to/missing-ocr-bin", "", time.Second).Detect("foo.jpg")
+
+		assert.Error(t, err)
+	})
+}
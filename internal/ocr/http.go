@@ -0,0 +1,105 @@
+package ocr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultUrl is the default OCR service endpoint.
+const DefaultUrl = "http://localhost:8009/ocr"
+
+// Http sends image file names to a remote OCR service, e.g. a sidecar container.
+type Http struct {
+	url     string
+	retries int
+	client  *http.Client
+}
+
+// NewHttp returns a new HTTP engine instance.
+func NewHttp(serviceUrl string, timeout time.Duration, retries int) *Http {
+	if serviceUrl == "" {
+		serviceUrl = DefaultUrl
+	}
+
+	if retries < 0 {
+		retries = 0
+	}
+
+	// NOTE: Timeout specifies a time limit for requests made by
+	// this Client. The timeout includes connection time, any
+	// redirects, and reading the response body. The timer remains
+	// running after Get, Head, Post, or Do return and will
+	// interrupt reading of the Response.Body.
+	return &Http{url: serviceUrl, retries: retries, client: &http.Client{Timeout: timeout}}
+}
+
+// Name returns the engine name.
+func (e *Http) Name() string {
+	return EngineHttp
+}
+
+// Detect returns the text regions found in the image file, retrying failed requests.
+//
+// The service may either respond with plain text, which is treated as a single region
+// covering the whole image, or with a JSON array of results as defined by Result.
+func (e *Http) Detect(fileName string) (results Results, err error) {
+	for i := 0; i <= e.retries; i++ {
+		if i > 0 {
+			time.Sleep(time.Duration(i) * 100 * time.Millisecond)
+		}
+
+		if results, err = e.request(fileName); err == nil {
+			return results, nil
+		}
+
+		log.Debugf("ocr: %s (attempt %d)", err, i+1)
+	}
+
+	return Results{}, err
+}
+
+// request performs a single OCR request.
+func (e *Http) request(fileName string) (Results, error) {
+	reqUrl := e.url
+
+	if strings.Contains(reqUrl, "?") {
+		reqUrl += "&f=" + url.QueryEscape(fileName)
+	} else {
+		reqUrl += "?f=" + url.QueryEscape(fileName)
+	}
+
+	resp, err := e.client.Get(reqUrl)
+
+	if err != nil {
+		return Results{}, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Results{}, fmt.Errorf("service returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return Results{}, err
+	}
+
+	if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return FullImage(string(body), ""), nil
+	}
+
+	results := Results{}
+
+	if err = json.Unmarshal(body, &results); err != nil {
+		return Results{}, err
+	}
+
+	return results, nil
+}
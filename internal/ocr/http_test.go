@@ -0,0 +1,79 @@
+package ocr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttp_Detect(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("text in " + r.URL.Query().Get("f")))
+		}))
+		defer srv.Close()
+
+		results, err := NewHttp(srv.URL+"/ocr", time.Second, 0).Detect("/tmp/a b.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, FullImage("text in /tmp/a b.jpg", ""), results)
+	})
+	t.Run("json", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"text":"Hello","x":0.1,"y":0.2,"w":0.3,"h":0.05,"lang":"en","confidence":0.9}]`))
+		}))
+		defer srv.Close()
+
+		results, err := NewHttp(srv.URL, time.Second, 0).Detect("foo.jpg")
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "Hello", results[0].Text)
+		assert.Equal(t, float32(0.2), results[0].Y)
+		assert.Equal(t, "en", results[0].Lang)
+		assert.Equal(t, float32(0.9), results[0].Confidence)
+	})
+	t.Run("retry", func(t *testing.T) {
+		calls := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer srv.Close()
+
+		results, err := NewHttp(srv.URL, time.Second, 2).Detect("foo.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", results.Text())
+		assert.Equal(t, 2, calls)
+	})
+	t.Run("error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		results, err := NewHttp(srv.URL, time.Second, 1).Detect("foo.jpg")
+
+		assert.Error(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+		}))
+		defer srv.Close()
+
+		_, err := NewHttp(srv.URL, 50*time.Millisecond, 0).Detect("foo.jpg")
+
+		assert.Error(t, err)
+	})
+}
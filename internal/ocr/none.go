@@ -0,0 +1,19 @@
+package ocr
+
+// None is a no-op engine used when text recognition is disabled.
+type None struct{}
+
+// NewNone returns a new no-op engine instance.
+func NewNone() *None {
+	return &None{}
+}
+
+// Name returns the engine name.
+func (e *None) Name() string {
+	return EngineNone
+}
+
+// Detect always returns an empty result.
+func (e *None) Detect(fileName string) (Results, error) {
+	return Results{}, nil
+}
@@ -0,0 +1,74 @@
+/*
+Package ocr provides pluggable text recognition engines for indexing.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package ocr
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Supported engine names.
+const (
+	EngineHttp = "http"
+	EngineExec = "exec"
+	EngineNone = "none"
+)
+
+// Engine recognizes text in image files.
+type Engine interface {
+	// Name returns the engine name.
+	Name() string
+	// Detect returns the text regions found in the image file.
+	Detect(fileName string) (Results, error)
+}
+
+// Options represents engine settings.
+type Options struct {
+	Engine   string
+	Url      string
+	Timeout  time.Duration
+	Retries  int
+	Bin      string
+	Language string
+}
+
+// New returns the engine matching the options, or the no-op engine if the name is unknown.
+func New(opt Options) Engine {
+	switch strings.ToLower(strings.TrimSpace(opt.Engine)) {
+	case EngineHttp:
+		return NewHttp(opt.Url, opt.Timeout, opt.Retries)
+	case EngineExec:
+		return NewExec(opt.Bin, opt.Language, opt.Timeout)
+	case EngineNone, "":
+		return NewNone()
+	default:
+		log.Warnf("ocr: unknown engine %s, text recognition disabled", opt.Engine)
+		return NewNone()
+	}
+}
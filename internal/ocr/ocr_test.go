@@ -0,0 +1,44 @@
+package ocr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("http", func(t *testing.T) {
+		e := New(Options{Engine: "HTTP"})
+		assert.Equal(t, EngineHttp, e.Name())
+	})
+	t.Run("exec", func(t *testing.T) {
+		e := New(Options{Engine: EngineExec})
+		assert.Equal(t, EngineExec, e.Name())
+	})
+	t.Run("none", func(t *testing.T) {
+		e := New(Options{})
+		assert.Equal(t, EngineNone, e.Name())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		e := New(Options{Engine: "foo"})
+		assert.Equal(t, EngineNone, e.Name())
+	})
+}
+
+func TestNone_Detect(t *testing.T) {
+	results, err := NewNone().Detect("testdata/foo.jpg")
+
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestResults_Text(t *testing.T) {
+	r := Results{{Text: " Hello World "}, {Text: ""}, {Text: "PhotoPrism"}}
+
+	assert.Equal(t, "Hello World\nPhotoPrism", r.Text())
+}
+
+func TestFullImage(t *testing.T) {
+	assert.Empty(t, FullImage("  ", "eng"))
+	assert.Equal(t, Results{{Text: "foo", W: 1, H: 1, Lang: "eng"}}, FullImage("foo\n", "eng"))
+}
@@ -0,0 +1,42 @@
+package ocr
+
+import (
+	"strings"
+)
+
+// Result represents a text region found in an image.
+// The bounding box is relative to the image size, so X, Y, W and H are between 0 and 1.
+type Result struct {
+	Text       string  `json:"text"`
+	X          float32 `json:"x"`
+	Y          float32 `json:"y"`
+	W          float32 `json:"w"`
+	H          float32 `json:"h"`
+	Lang       string  `json:"lang"`
+	Confidence float32 `json:"confidence"`
+}
+
+// Results represents a list of text regions.
+type Results []Result
+
+// Text returns the text of all regions, one per line.
+func (r Results) Text() string {
+	lines := make([]string, 0, len(r))
+
+	for _, res := range r {
+		if s := strings.TrimSpace(res.Text); s != "" {
+			lines = append(lines, s)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// FullImage returns a single region covering the whole image, or no region if the text is empty.
+func FullImage(text, lang string) Results {
+	if text = strings.TrimSpace(text); text == "" {
+		return Results{}
+	}
+
+	return Results{{Text: text, X: 0, Y: 0, W: 1, H: 1, Lang: lang}}
+}
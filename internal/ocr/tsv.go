@@ -0,0 +1,117 @@
+package ocr
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tsvLine collects the words of a single text line.
+type tsvLine struct {
+	words                  []string
+	left, top, right, down int
+	conf                   float64
+	confCount              int
+}
+
+// ParseTsv parses the tab separated output of tesseract and returns a result for each text line.
+func ParseTsv(data, lang string) (Results, error) {
+	var width, height int
+	var order []string
+
+	lines := make(map[string]*tsvLine)
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		cols := strings.Split(scanner.Text(), "\t")
+
+		// Expected columns: level, page_num, block_num, par_num, line_num, word_num,
+		// left, top, width, height, conf, text
+		if len(cols) < 11 {
+			continue
+		}
+
+		level, err := strconv.Atoi(cols[0])
+
+		// Skip header.
+		if err != nil {
+			continue
+		}
+
+		var box [4]int
+
+		for i := range box {
+			if box[i], err = strconv.Atoi(cols[6+i]); err != nil {
+				return Results{}, fmt.Errorf("invalid tsv box %q", cols[6+i])
+			}
+		}
+
+		switch level {
+		case 1:
+			width, height = box[2], box[3]
+		case 5:
+			text := ""
+
+			if len(cols) > 11 {
+				text = strings.TrimSpace(cols[11])
+			}
+
+			if text == "" {
+				continue
+			}
+
+			key := strings.Join(cols[1:5], ":")
+			l, ok := lines[key]
+
+			if !ok {
+				l = &tsvLine{left: box[0], top: box[1], right: box[0] + box[2], down: box[1] + box[3]}
+				lines[key] = l
+				order = append(order, key)
+			}
+
+			l.words = append(l.words, text)
+			l.left = min(l.left, box[0])
+			l.top = min(l.top, box[1])
+			l.right = max(l.right, box[0]+box[2])
+			l.down = max(l.down, box[1]+box[3])
+
+			if conf, err := strconv.ParseFloat(cols[10], 64); err == nil && conf >= 0 {
+				l.conf += conf
+				l.confCount++
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return Results{}, err
+	}
+
+	if width <= 0 || height <= 0 {
+		return Results{}, nil
+	}
+
+	results := make(Results, 0, len(order))
+
+	for _, key := range order {
+		l := lines[key]
+
+		res := Result{
+			Text: strings.Join(l.words, " "),
+			X:    float32(l.left) / float32(width),
+			Y:    float32(l.top) / float32(height),
+			W:    float32(l.right-l.left) / float32(width),
+			H:    float32(l.down-l.top) / float32(height),
+			Lang: lang,
+		}
+
+		if l.confCount > 0 {
+			res.Confidence = float32(l.conf / float64(l.confCount) / 100)
+		}
+
+		results = append(results, res)
+	}
+
+	return results, nil
+}
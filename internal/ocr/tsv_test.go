@@ -0,0 +1,37 @@
+package ocr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTsv(t *testing.T) {
+	t.Run("page", func(t *testing.T) {
+		data, err := os.ReadFile("testdata/page.tsv")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := ParseTsv(string(data), "eng")
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, Result{Text: "Hello World", X: 0.1, Y: 0.1, W: 0.4, H: 0.08, Lang: "eng", Confidence: 0.94}, results[0])
+		assert.Equal(t, "PhotoPrism", results[1].Text)
+		assert.Equal(t, float32(0.8), results[1].Confidence)
+	})
+	t.Run("empty", func(t *testing.T) {
+		results, err := ParseTsv("", "")
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseTsv("5\t1\t1\t1\t1\t1\tx\t0\t0\t0\t90\tfoo", "")
+
+		assert.Error(t, err)
+	})
+}
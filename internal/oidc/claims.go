@@ -0,0 +1,77 @@
+package oidc
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Audience represents the "aud" claim, which may either be a string or an array of strings.
+type Audience []string
+
+// UnmarshalJSON decodes a single audience string or an array of strings.
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+
+	var list []string
+
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+
+	*a = list
+
+	return nil
+}
+
+// Contains tests if the audience includes the client id.
+func (a Audience) Contains(clientID string) bool {
+	for _, s := range a {
+		if s == clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Claims represents the verified ID token claims of an authenticated user.
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          Audience `json:"aud"`
+	Expiry            int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	Nonce             string   `json:"nonce,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     bool     `json:"email_verified,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+}
+
+// UserName returns the preferred username, or the local part of the email address if not set.
+func (c Claims) UserName() string {
+	if s := strings.TrimSpace(c.PreferredUsername); s != "" {
+		return s
+	} else if i := strings.Index(c.Email, "@"); i > 0 {
+		return c.Email[:i]
+	}
+
+	return ""
+}
+
+// InGroup tests if the user is a member of the group, ignoring case.
+func (c Claims) InGroup(group string) bool {
+	for _, g := range c.Groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+
+	return false
+}
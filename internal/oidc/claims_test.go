@@ -0,0 +1,49 @@
+package oidc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudience_UnmarshalJSON(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		var c Claims
+
+		if err := json.Unmarshal([]byte(`{"aud": "photoprism"}`), &c); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Audience{"photoprism"}, c.Audience)
+		assert.True(t, c.Audience.Contains("photoprism"))
+	})
+	t.Run("Array", func(t *testing.T) {
+		var c Claims
+
+		if err := json.Unmarshal([]byte(`{"aud": ["other", "photoprism"]}`), &c); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, c.Audience.Contains("photoprism"))
+		assert.False(t, c.Audience.Contains("unknown"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var c Claims
+		assert.Error(t, json.Unmarshal([]byte(`{"aud": 123}`), &c))
+	})
+}
+
+func TestClaims_UserName(t *testing.T) {
+	assert.Equal(t, "jane", Claims{PreferredUsername: " jane ", Email: "doe@example.com"}.UserName())
+	assert.Equal(t, "doe", Claims{Email: "doe@example.com"}.UserName())
+	assert.Equal(t, "", Claims{Subject: "123"}.UserName())
+}
+
+func TestClaims_InGroup(t *testing.T) {
+	c := Claims{Groups: []string{"Admins", "family"}}
+
+	assert.True(t, c.InGroup("admins"))
+	assert.True(t, c.InGroup("Family"))
+	assert.False(t, c.InGroup("friends"))
+}
@@ -0,0 +1,191 @@
+package oidc
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tokens represents a successful token endpoint response.
+type Tokens struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Client performs the authorization code flow with PKCE against an identity provider.
+type Client struct {
+	opt      Options
+	http     *http.Client
+	provider *Provider
+	keys     map[string]*rsa.PublicKey
+	mutex    sync.Mutex
+}
+
+// NewClient returns a new client after fetching the provider discovery document.
+func NewClient(opt Options) (*Client, error) {
+	if opt.ClientID == "" {
+		return nil, errors.New("oidc: client id is empty")
+	} else if opt.RedirectUrl == "" {
+		return nil, errors.New("oidc: redirect url is empty")
+	}
+
+	c := &Client{opt: opt, http: opt.httpClient()}
+
+	p, err := Discover(c.http, opt.Issuer)
+
+	if err != nil {
+		return nil, err
+	} else if !p.SupportsPKCE() {
+		return nil, fmt.Errorf("oidc: provider does not support %s code challenges", ChallengeMethod)
+	}
+
+	c.provider = p
+
+	return c, nil
+}
+
+// Provider returns the identity provider endpoints.
+func (c *Client) Provider() Provider {
+	return *c.provider
+}
+
+// AuthUrl returns the provider URL the user is redirected to for authentication.
+func (c *Client) AuthUrl(state, nonce, verifier string) string {
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", c.opt.ClientID)
+	v.Set("redirect_uri", c.opt.RedirectUrl)
+	v.Set("scope", c.opt.Scope())
+	v.Set("state", state)
+	v.Set("nonce", nonce)
+	v.Set("code_challenge", Challenge(verifier))
+	v.Set("code_challenge_method", ChallengeMethod)
+
+	sep := "?"
+
+	if strings.Contains(c.provider.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return c.provider.AuthorizationEndpoint + sep + v.Encode()
+}
+
+// Exchange redeems the authorization code and PKCE verifier for tokens.
+func (c *Client) Exchange(code, verifier string) (tokens Tokens, err error) {
+	if code == "" {
+		return tokens, errors.New("oidc: authorization code is empty")
+	}
+
+	v := url.Values{}
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	v.Set("redirect_uri", c.opt.RedirectUrl)
+	v.Set("code_verifier", verifier)
+	v.Set("client_id", c.opt.ClientID)
+
+	req, err := http.NewRequest(http.MethodPost, c.provider.TokenEndpoint, strings.NewReader(v.Encode()))
+
+	if err != nil {
+		return tokens, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	// Confidential clients authenticate with HTTP Basic auth, see RFC 6749, Section 2.3.1.
+	if c.opt.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.opt.ClientID), url.QueryEscape(c.opt.ClientSecret))
+	}
+
+	resp, err := c.http.Do(req)
+
+	if err != nil {
+		return tokens, fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if err != nil {
+		return tokens, fmt.Errorf("oidc: %s", err)
+	} else if resp.StatusCode != http.StatusOK {
+		return tokens, fmt.Errorf("oidc: token request failed with status %d", resp.StatusCode)
+	} else if err = json.Unmarshal(body, &tokens); err != nil {
+		return tokens, fmt.Errorf("oidc: invalid token response (%s)", err)
+	} else if tokens.IDToken == "" {
+		return tokens, errors.New("oidc: token response contains no id token")
+	}
+
+	return tokens, nil
+}
+
+// signingKeys returns the cached provider keys, they are fetched again if refresh is true.
+func (c *Client) signingKeys(refresh bool) (map[string]*rsa.PublicKey, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.keys != nil && !refresh {
+		return c.keys, nil
+	}
+
+	keys, err := FetchKeys(c.http, c.provider.JwksUri)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.keys = keys
+
+	return keys, nil
+}
+
+// Verify checks the signature, issuer, audience, expiry, and nonce of an ID token and returns its claims.
+func (c *Client) Verify(idToken, nonce string) (claims Claims, err error) {
+	keys, err := c.signingKeys(false)
+
+	if err != nil {
+		return claims, err
+	}
+
+	claims, err = ParseToken(idToken, keys)
+
+	// Fetch keys again in case they have been rotated.
+	if err == ErrUnknownKey {
+		if keys, err = c.signingKeys(true); err != nil {
+			return claims, err
+		}
+
+		claims, err = ParseToken(idToken, keys)
+	}
+
+	if err != nil {
+		return claims, err
+	}
+
+	now := time.Now().Unix()
+
+	switch {
+	case claims.Issuer != c.provider.Issuer:
+		return claims, fmt.Errorf("oidc: invalid issuer %s", claims.Issuer)
+	case !claims.Audience.Contains(c.opt.ClientID):
+		return claims, errors.New("oidc: token was issued for another client")
+	case claims.Expiry < now-60:
+		return claims, errors.New("oidc: token has expired")
+	case claims.Nonce != nonce:
+		return claims, errors.New("oidc: invalid nonce")
+	case claims.Subject == "":
+		return claims, errors.New("oidc: subject is empty")
+	}
+
+	return claims, nil
+}
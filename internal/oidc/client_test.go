@@ -0,0 +1,178 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRedirect = "http://localhost:2342/api/v1/oidc/redirect"
+
+func TestDiscover(t *testing.T) {
+	t.Run("MockProvider", func(t *testing.T) {
+		m, err := NewMockProvider("photoprism", Claims{Subject: "jane"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer m.Close()
+
+		p, err := Discover(http.DefaultClient, m.Issuer()+"/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.URL+"/token", p.TokenEndpoint)
+		assert.True(t, p.SupportsPKCE())
+	})
+	t.Run("IssuerMismatch", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, Provider{Issuer: "https://evil.example.com"})
+		}))
+
+		defer s.Close()
+
+		_, err := Discover(http.DefaultClient, s.URL)
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		s := httptest.NewServer(http.NotFoundHandler())
+
+		defer s.Close()
+
+		_, err := Discover(http.DefaultClient, s.URL)
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := Discover(http.DefaultClient, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestClient(t *testing.T) {
+	m, err := NewMockProvider("photoprism", Claims{Subject: "jane", PreferredUsername: "jane", Groups: []string{"family"}})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer m.Close()
+
+	c, err := NewClient(Options{Issuer: m.Issuer(), ClientID: "photoprism", ClientSecret: "secret", RedirectUrl: testRedirect})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		verifier := NewVerifier()
+		authUrl := c.AuthUrl("state123", "nonce123", verifier)
+
+		assert.True(t, strings.HasPrefix(authUrl, m.URL+"/authorize?"))
+		assert.Contains(t, authUrl, "code_challenge="+Challenge(verifier))
+
+		redirect, err := m.Authorize(authUrl)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "state123", redirect.Query().Get("state"))
+
+		tokens, err := c.Exchange(redirect.Query().Get("code"), verifier)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		claims, err := c.Verify(tokens.IDToken, "nonce123")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "jane", claims.Subject)
+		assert.True(t, claims.InGroup("family"))
+	})
+	t.Run("InvalidVerifier", func(t *testing.T) {
+		redirect, err := m.Authorize(c.AuthUrl("state", "nonce", NewVerifier()))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Exchange(redirect.Query().Get("code"), NewVerifier())
+		assert.Error(t, err)
+	})
+	t.Run("InvalidNonce", func(t *testing.T) {
+		verifier := NewVerifier()
+		redirect, err := m.Authorize(c.AuthUrl("state", "nonce", verifier))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tokens, err := c.Exchange(redirect.Query().Get("code"), verifier)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Verify(tokens.IDToken, "other")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSignature", func(t *testing.T) {
+		other, err := NewMockProvider("photoprism", Claims{Subject: "jane"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer other.Close()
+
+		token, err := SignToken(Claims{Issuer: m.URL, Subject: "jane", Audience: Audience{"photoprism"}}, other.key, MockKeyID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Verify(token, "")
+		assert.EqualError(t, err, "oidc: invalid token signature")
+	})
+	t.Run("OtherClient", func(t *testing.T) {
+		other, err := NewClient(Options{Issuer: m.Issuer(), ClientID: "other", RedirectUrl: testRedirect})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		verifier := NewVerifier()
+		redirect, err := m.Authorize(c.AuthUrl("state", "nonce", verifier))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tokens, err := c.Exchange(redirect.Query().Get("code"), verifier)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = other.Verify(tokens.IDToken, "nonce")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(Options{Issuer: "http://localhost:1", RedirectUrl: testRedirect})
+	assert.Error(t, err)
+
+	_, err = NewClient(Options{Issuer: "http://localhost:1", ClientID: "photoprism"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DiscoveryPath is the well-known path of the provider configuration document relative to the issuer URL.
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// Provider represents the endpoints of an identity provider as published in its discovery document.
+type Provider struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserInfoEndpoint      string   `json:"userinfo_endpoint"`
+	JwksUri               string   `json:"jwks_uri"`
+	CodeChallengeMethods  []string `json:"code_challenge_methods_supported"`
+	SigningAlgs           []string `json:"id_token_signing_alg_values_supported"`
+}
+
+// Discover fetches the discovery document of the identity provider with the specified issuer URL.
+func Discover(client *http.Client, issuer string) (*Provider, error) {
+	issuer = strings.TrimRight(issuer, "/")
+
+	if issuer == "" {
+		return nil, fmt.Errorf("oidc: issuer url is empty")
+	}
+
+	resp, err := client.Get(issuer + DiscoveryPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	p := &Provider{}
+
+	if err = json.Unmarshal(body, p); err != nil {
+		return nil, fmt.Errorf("oidc: invalid discovery document (%s)", err)
+	}
+
+	// The issuer must exactly match the URL used for discovery, see OpenID Connect Discovery 1.0, Section 4.3.
+	if strings.TrimRight(p.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc: issuer mismatch, expected %s but got %s", issuer, p.Issuer)
+	} else if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JwksUri == "" {
+		return nil, fmt.Errorf("oidc: discovery document is incomplete")
+	}
+
+	return p, nil
+}
+
+// SupportsPKCE tests if the provider announced support for S256 code challenges.
+// Providers that do not announce any method are assumed to support it.
+func (p *Provider) SupportsPKCE() bool {
+	if len(p.CodeChallengeMethods) == 0 {
+		return true
+	}
+
+	for _, m := range p.CodeChallengeMethods {
+		if m == ChallengeMethod {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,187 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+)
+
+// SigningAlg is the only supported ID token signature algorithm.
+const SigningAlg = "RS256"
+
+// ErrUnknownKey is returned if a token was signed with a key that is not in the key set.
+var ErrUnknownKey = errors.New("oidc: unknown signing key")
+
+// jwtHeader represents the header of a JSON Web Token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+}
+
+// jsonWebKey represents a public RSA key in a JSON Web Key Set.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// publicKey returns the RSA public key.
+func (k jsonWebKey) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+}
+
+// FetchKeys returns the public RSA signing keys of the provider by key id.
+func FetchKeys(client *http.Client, jwksUri string) (map[string]*rsa.PublicKey, error) {
+	resp, err := client.Get(jwksUri)
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: fetching keys failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s", err)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err = json.Unmarshal(body, &set); err != nil {
+		return nil, fmt.Errorf("oidc: invalid key set (%s)", err)
+	}
+
+	result := make(map[string]*rsa.PublicKey, len(set.Keys))
+
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		if key, err := k.publicKey(); err != nil {
+			log.Debugf("oidc: %s (parse key %s)", err, k.Kid)
+		} else {
+			result[k.Kid] = key
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("oidc: no rsa signing keys found")
+	}
+
+	return result, nil
+}
+
+// ParseToken verifies the RS256 signature of a compact JSON Web Token with one of the keys
+// and decodes its claims.
+func ParseToken(token string, keys map[string]*rsa.PublicKey) (claims Claims, err error) {
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return claims, errors.New("oidc: malformed token")
+	}
+
+	var header jwtHeader
+
+	if b, err := base64.RawURLEncoding.DecodeString(parts[0]); err != nil {
+		return claims, errors.New("oidc: malformed token header")
+	} else if err = json.Unmarshal(b, &header); err != nil {
+		return claims, errors.New("oidc: malformed token header")
+	}
+
+	if header.Alg != SigningAlg {
+		return claims, fmt.Errorf("oidc: unsupported signature algorithm %s", header.Alg)
+	}
+
+	key, ok := keys[header.Kid]
+
+	// Use the only key if the token does not specify one.
+	if !ok && header.Kid == "" && len(keys) == 1 {
+		for _, k := range keys {
+			key, ok = k, true
+		}
+	}
+
+	if !ok {
+		return claims, ErrUnknownKey
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return claims, errors.New("oidc: malformed token signature")
+	}
+
+	h := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, h[:], sig); err != nil {
+		return claims, errors.New("oidc: invalid token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if err != nil {
+		return claims, errors.New("oidc: malformed token payload")
+	} else if err = json.Unmarshal(payload, &claims); err != nil {
+		return claims, fmt.Errorf("oidc: malformed token claims (%s)", err)
+	}
+
+	return claims, nil
+}
+
+// SignToken returns a compact JSON Web Token signed with RS256, e.g. for testing.
+func SignToken(claims interface{}, key *rsa.PrivateKey, kid string) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: SigningAlg, Kid: kid})
+
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+
+	if err != nil {
+		return "", err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	h := sha256.Sum256([]byte(signed))
+
+	sig, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, h[:])
+
+	if err != nil {
+		return "", err
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
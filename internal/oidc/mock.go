@@ -0,0 +1,186 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// MockKeyID is the key id of the mock provider signing key.
+const MockKeyID = "mock"
+
+// MockProvider is a local identity provider for testing the login flow without external services.
+// Authorization requests are approved immediately for the configured user claims.
+type MockProvider struct {
+	*httptest.Server
+	ClientID string
+	Claims   Claims
+	key      *rsa.PrivateKey
+	codes    map[string]mockGrant
+	mutex    sync.Mutex
+}
+
+// mockGrant represents an issued authorization code.
+type mockGrant struct {
+	Nonce     string
+	Challenge string
+	Redirect  string
+}
+
+// NewMockProvider starts a new mock identity provider that issues tokens for the client id and claims.
+// Call Close when done.
+func NewMockProvider(clientID string, claims Claims) (*MockProvider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MockProvider{ClientID: clientID, Claims: claims, key: key, codes: make(map[string]mockGrant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiscoveryPath, m.discovery)
+	mux.HandleFunc("/authorize", m.authorize)
+	mux.HandleFunc("/token", m.token)
+	mux.HandleFunc("/keys", m.keys)
+
+	m.Server = httptest.NewServer(mux)
+
+	return m, nil
+}
+
+// Issuer returns the issuer URL.
+func (m *MockProvider) Issuer() string {
+	return m.URL
+}
+
+// SetClaims changes the claims of the user that is logged in.
+func (m *MockProvider) SetClaims(claims Claims) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.Claims = claims
+}
+
+// Authorize simulates a successful login in the browser and returns the URL the provider redirects to.
+func (m *MockProvider) Authorize(authUrl string) (*url.URL, error) {
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Get(authUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	return resp.Location()
+}
+
+func (m *MockProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, Provider{
+		Issuer:                m.URL,
+		AuthorizationEndpoint: m.URL + "/authorize",
+		TokenEndpoint:         m.URL + "/token",
+		JwksUri:               m.URL + "/keys",
+		CodeChallengeMethods:  []string{ChallengeMethod},
+		SigningAlgs:           []string{SigningAlg},
+	})
+}
+
+func (m *MockProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	if q.Get("client_id") != m.ClientID || q.Get("code_challenge_method") != ChallengeMethod {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	code := RandomString(16)
+
+	m.mutex.Lock()
+	m.codes[code] = mockGrant{Nonce: q.Get("nonce"), Challenge: q.Get("code_challenge"), Redirect: q.Get("redirect_uri")}
+	m.mutex.Unlock()
+
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+
+	if err != nil {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+		return
+	}
+
+	v := redirect.Query()
+	v.Set("code", code)
+	v.Set("state", q.Get("state"))
+	redirect.RawQuery = v.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (m *MockProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	m.mutex.Lock()
+	grant, ok := m.codes[code]
+	delete(m.codes, code)
+	claims := m.Claims
+	m.mutex.Unlock()
+
+	if !ok || grant.Redirect != r.PostForm.Get("redirect_uri") || Challenge(r.PostForm.Get("code_verifier")) != grant.Challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+
+	claims.Issuer = m.URL
+	claims.Audience = Audience{m.ClientID}
+	claims.IssuedAt = now.Unix()
+	claims.Expiry = now.Add(time.Hour).Unix()
+	claims.Nonce = grant.Nonce
+
+	idToken, err := SignToken(claims, m.key, MockKeyID)
+
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Tokens{AccessToken: RandomString(16), TokenType: "Bearer", IDToken: idToken, ExpiresIn: 3600})
+}
+
+func (m *MockProvider) keys(w http.ResponseWriter, r *http.Request) {
+	pub := m.key.PublicKey
+
+	writeJSON(w, http.StatusOK, map[string][]jsonWebKey{"keys": {{
+		Kty: "RSA",
+		Kid: MockKeyID,
+		Use: "sig",
+		Alg: SigningAlg,
+		N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debugf("oidc: %s", err)
+	}
+}
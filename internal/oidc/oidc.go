@@ -0,0 +1,74 @@
+/*
+Package oidc implements OpenID Connect single sign-on with the authorization code flow and PKCE.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package oidc
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// DefaultScopes are requested if no other scopes are configured.
+var DefaultScopes = []string{"openid", "profile", "email"}
+
+// Options represents the identity provider and client settings.
+type Options struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectUrl  string
+	Scopes       []string
+	Timeout      time.Duration
+}
+
+// Scope returns the space-separated list of requested scopes, always including "openid".
+func (o Options) Scope() string {
+	scopes := o.Scopes
+
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+
+	for _, s := range scopes {
+		if s == "openid" {
+			return strings.Join(scopes, " ")
+		}
+	}
+
+	return strings.Join(append([]string{"openid"}, scopes...), " ")
+}
+
+// httpClient returns a new HTTP client with the configured timeout.
+func (o Options) httpClient() *http.Client {
+	if o.Timeout <= 0 {
+		return &http.Client{Timeout: 15 * time.Second}
+	}
+
+	return &http.Client{Timeout: o.Timeout}
+}
@@ -0,0 +1,13 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions_Scope(t *testing.T) {
+	assert.Equal(t, "openid profile email", Options{}.Scope())
+	assert.Equal(t, "openid email groups", Options{Scopes: []string{"email", "groups"}}.Scope())
+	assert.Equal(t, "profile openid", Options{Scopes: []string{"profile", "openid"}}.Scope())
+}
@@ -0,0 +1,33 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// ChallengeMethod is the PKCE code challenge method, see RFC 7636.
+const ChallengeMethod = "S256"
+
+// RandomString returns a random URL-safe string with the specified number of random bytes,
+// e.g. for use as state, nonce, or PKCE code verifier.
+func RandomString(size int) string {
+	b := make([]byte, size)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// NewVerifier returns a new PKCE code verifier with 43 characters.
+func NewVerifier() string {
+	return RandomString(32)
+}
+
+// Challenge returns the S256 code challenge for a PKCE code verifier.
+func Challenge(verifier string) string {
+	h := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
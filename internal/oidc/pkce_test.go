@@ -0,0 +1,19 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewVerifier(t *testing.T) {
+	v := NewVerifier()
+
+	assert.Len(t, v, 43)
+	assert.NotEqual(t, v, NewVerifier())
+}
+
+func TestChallenge(t *testing.T) {
+	// Example from RFC 7636, Appendix B.
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
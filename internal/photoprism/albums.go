@@ -8,6 +8,7 @@ import (
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // BackupAlbums creates a YAML file backup of all albums.
@@ -102,3 +103,27 @@ func RestoreAlbums(backupPath string, force bool) (count int, result error) {
 
 	return count, result
 }
+
+// importAlbum finds or creates an album with the title and returns its UID,
+// the description is only set if the album does not have one yet.
+func importAlbum(title, description, location, ownerUID string) (string, error) {
+	m := entity.NewAlbum(title, entity.AlbumDefault)
+
+	if err := m.Find(); err == nil {
+		if m.AlbumDescription == "" && description != "" {
+			return m.AlbumUID, m.Update("AlbumDescription", description)
+		}
+
+		return m.AlbumUID, nil
+	}
+
+	m.AlbumDescription = description
+	m.AlbumLocation = txt.Clip(location, txt.ClipDefault)
+	m.SetOwner(ownerUID)
+
+	if err := m.Create(); err != nil {
+		return "", err
+	}
+
+	return m.AlbumUID, nil
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/dustin/go-humanize/english"
 
@@ -147,6 +148,15 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 		}
 	}
 
+	// Remove tombstones that clients no longer need for syncing.
+	if opt.Dry {
+		// Do nothing.
+	} else if n, err := entity.DeleteTombstones(time.Now().Add(-entity.TombstoneRetention)); err != nil {
+		log.Errorf("cleanup: %s (remove tombstones)", err)
+	} else if n > 0 {
+		log.Infof("cleanup: removed %s", english.Plural(n, "tombstone", "tombstones"))
+	}
+
 	// Only update counts if anything was deleted.
 	if len(deleted) > 0 {
 		// Update precalculated photo and file counts.
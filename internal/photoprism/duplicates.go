@@ -0,0 +1,353 @@
+package photoprism
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+// Duplicate group types.
+const (
+	DuplicateTypeDuplicate = "duplicate"
+	DuplicateTypeBurst     = "burst"
+)
+
+// Actions that can be performed when accepting a duplicate group suggestion.
+const (
+	DuplicateActionStack   = "stack"
+	DuplicateActionArchive = "archive"
+)
+
+// DuplicatesOptions represents near-duplicate and burst detection options.
+type DuplicatesOptions struct {
+	Distance      int           // Maximum perceptual hash distance of near-duplicates.
+	BurstDistance int           // Maximum perceptual hash distance of burst shots.
+	BurstInterval time.Duration // Maximum time between burst shots.
+}
+
+// DuplicatesOptionsDefault returns the default near-duplicate and burst detection options.
+func DuplicatesOptionsDefault() DuplicatesOptions {
+	return DuplicatesOptions{
+		Distance:      search.DuplicateDistance,
+		BurstDistance: search.SimilarDistance,
+		BurstInterval: 2 * time.Second,
+	}
+}
+
+// DuplicatePhoto represents a photo in a near-duplicate or burst group.
+type DuplicatePhoto struct {
+	UID        string    `json:"UID"`
+	FileUID    string    `json:"FileUID"`
+	TakenAt    time.Time `json:"TakenAt"`
+	Quality    int       `json:"Quality"`
+	Resolution int       `json:"Resolution"`
+	Width      int       `json:"Width"`
+	Height     int       `json:"Height"`
+	Distance   int       `json:"Distance"`
+}
+
+// DuplicateGroup represents photos that are near-duplicates or burst shots of each other,
+// with the photo that is recommended to keep.
+type DuplicateGroup struct {
+	Type   string           `json:"Type"`
+	Keeper DuplicatePhoto   `json:"Keeper"`
+	Photos []DuplicatePhoto `json:"Photos"`
+}
+
+// UIDs returns the UIDs of the photos that are not recommended to keep.
+func (g DuplicateGroup) UIDs() []string {
+	result := make([]string, len(g.Photos))
+
+	for i := range g.Photos {
+		result[i] = g.Photos[i].UID
+	}
+
+	return result
+}
+
+// Contains tests if the photo with the specified UID belongs to the group.
+func (g DuplicateGroup) Contains(photoUID string) bool {
+	if g.Keeper.UID == photoUID {
+		return true
+	}
+
+	for _, p := range g.Photos {
+		if p.UID == photoUID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DuplicateGroups represents a list of near-duplicate and burst groups.
+type DuplicateGroups []DuplicateGroup
+
+// Find returns the group the photo with the specified UID belongs to.
+func (g DuplicateGroups) Find(photoUID string) (DuplicateGroup, bool) {
+	for i := range g {
+		if g[i].Contains(photoUID) {
+			return g[i], true
+		}
+	}
+
+	return DuplicateGroup{}, false
+}
+
+// Duplicates represents a worker that detects near-duplicates and burst shots.
+type Duplicates struct {
+	conf *config.Config
+}
+
+// NewDuplicates returns a new Duplicates worker.
+func NewDuplicates(conf *config.Config) *Duplicates {
+	instance := &Duplicates{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Find returns groups of near-duplicates and burst shots found in the index.
+func (w *Duplicates) Find(opt DuplicatesOptions) (DuplicateGroups, error) {
+	start := time.Now()
+
+	hashes, err := search.PerceptualHashes()
+
+	if err != nil {
+		return DuplicateGroups{}, err
+	}
+
+	groups := GroupDuplicates(hashes, opt)
+
+	log.Debugf("duplicates: found %s in %s [%s]", english.Plural(len(groups), "group", "groups"), english.Plural(len(hashes), "photo", "photos"), time.Since(start))
+
+	return groups, nil
+}
+
+// Accept accepts a group suggestion, keeping the photo with the specified UID, which may differ from the
+// recommended keeper, and stacking or archiving the other photos in the group.
+func (w *Duplicates) Accept(g DuplicateGroup, keeperUID, action string) (keeper entity.Photo, others entity.Photos, err error) {
+	if action != DuplicateActionStack && action != DuplicateActionArchive {
+		return keeper, others, fmt.Errorf("duplicates: invalid action %s", action)
+	} else if !g.Contains(keeperUID) {
+		return keeper, others, fmt.Errorf("duplicates: %s is not in group", keeperUID)
+	}
+
+	for _, uid := range append(g.UIDs(), g.Keeper.UID) {
+		p, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			return keeper, others, err
+		}
+
+		if uid == keeperUID {
+			keeper = p
+		} else {
+			others = append(others, p)
+		}
+	}
+
+	switch action {
+	case DuplicateActionStack:
+		log.Infof("duplicates: stacking %s with %s", english.Plural(len(others), "photo", "photos"), keeper.PhotoUID)
+
+		if others, err = keeper.Stack(others); err != nil {
+			return keeper, others, err
+		}
+
+		keeper.SetStack(entity.IsStacked)
+	case DuplicateActionArchive:
+		log.Infof("duplicates: archiving %s similar to %s", english.Plural(len(others), "photo", "photos"), keeper.PhotoUID)
+
+		for _, p := range others {
+			if err := p.Archive(); err != nil {
+				return keeper, others, err
+			}
+		}
+	}
+
+	return keeper, others, nil
+}
+
+// GroupDuplicates groups photos with similar perceptual hashes that are near-duplicates, regardless of
+// when they were taken, or that were taken in a burst, and recommends the photo with the best quality
+// and the highest resolution as keeper.
+func GroupDuplicates(hashes []search.PerceptualHash, opt DuplicatesOptions) DuplicateGroups {
+	// Skip photos that have been manually unstacked or flagged as deleted.
+	candidates := make([]search.PerceptualHash, 0, len(hashes))
+
+	for _, h := range hashes {
+		if h.PhotoStack != entity.IsUnstacked && h.PhotoQuality >= 0 {
+			candidates = append(candidates, h)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].TakenAt.Before(candidates[j].TakenAt)
+	})
+
+	n := len(candidates)
+	parent := make([]int, n)
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+
+		return parent[i]
+	}
+
+	union := func(i, j int) {
+		if a, b := find(i), find(j); a != b {
+			parent[b] = a
+		}
+	}
+
+	// Find near-duplicates: if two hashes differ in at most d bits, at least one
+	// of d+1 bands must be identical, so only photos in the same bucket are compared.
+	if opt.Distance >= 0 && opt.Distance < phash.Bits {
+		bands := opt.Distance + 1
+		width := phash.Bits / bands
+
+		for b := 0; b < bands; b++ {
+			shift := uint(b * width)
+			bits := width
+
+			if b == bands-1 {
+				bits = phash.Bits - b*width
+			}
+
+			mask := uint64(1)<<uint(bits) - 1
+			buckets := make(map[uint64][]int)
+
+			for i, c := range candidates {
+				key := (uint64(c.PHash) >> shift) & mask
+				buckets[key] = append(buckets[key], i)
+			}
+
+			for _, bucket := range buckets {
+				for x := 0; x < len(bucket); x++ {
+					for y := x + 1; y < len(bucket); y++ {
+						i, j := bucket[x], bucket[y]
+
+						if phash.Distance(candidates[i].PHash, candidates[j].PHash) <= opt.Distance {
+							union(i, j)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Find burst shots that were taken within the burst interval.
+	if opt.BurstInterval > 0 {
+		for i := 0; i < n; i++ {
+			if candidates[i].TakenAt.IsZero() {
+				continue
+			}
+
+			for j := i + 1; j < n && candidates[j].TakenAt.Sub(candidates[i].TakenAt) <= opt.BurstInterval; j++ {
+				if phash.Distance(candidates[i].PHash, candidates[j].PHash) <= opt.BurstDistance {
+					union(i, j)
+				}
+			}
+		}
+	}
+
+	// Collect groups with more than one photo.
+	members := make(map[int][]search.PerceptualHash)
+
+	for i := range candidates {
+		root := find(i)
+		members[root] = append(members[root], candidates[i])
+	}
+
+	groups := make(DuplicateGroups, 0, len(members))
+
+	for _, m := range members {
+		if len(m) < 2 {
+			continue
+		}
+
+		groups = append(groups, newDuplicateGroup(m, opt))
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Keeper.TakenAt.Equal(groups[j].Keeper.TakenAt) {
+			return groups[i].Keeper.UID < groups[j].Keeper.UID
+		}
+
+		return groups[i].Keeper.TakenAt.After(groups[j].Keeper.TakenAt)
+	})
+
+	return groups
+}
+
+// newDuplicateGroup returns a new group with the recommended keeper.
+func newDuplicateGroup(m []search.PerceptualHash, opt DuplicatesOptions) DuplicateGroup {
+	sort.SliceStable(m, func(i, j int) bool {
+		switch {
+		case m[i].PhotoQuality != m[j].PhotoQuality:
+			return m[i].PhotoQuality > m[j].PhotoQuality
+		case m[i].PhotoResolution != m[j].PhotoResolution:
+			return m[i].PhotoResolution > m[j].PhotoResolution
+		case m[i].Pixels() != m[j].Pixels():
+			return m[i].Pixels() > m[j].Pixels()
+		default:
+			return m[i].PhotoID < m[j].PhotoID
+		}
+	})
+
+	keeper := m[0]
+
+	result := DuplicateGroup{
+		Type:   DuplicateTypeDuplicate,
+		Keeper: newDuplicatePhoto(keeper, 0),
+		Photos: make([]DuplicatePhoto, 0, len(m)-1),
+	}
+
+	for _, h := range m[1:] {
+		dist := phash.Distance(keeper.PHash, h.PHash)
+
+		if dist > opt.Distance {
+			result.Type = DuplicateTypeBurst
+		}
+
+		result.Photos = append(result.Photos, newDuplicatePhoto(h, dist))
+	}
+
+	sort.SliceStable(result.Photos, func(i, j int) bool {
+		return result.Photos[i].Distance < result.Photos[j].Distance
+	})
+
+	return result
+}
+
+// newDuplicatePhoto returns a new group member.
+func newDuplicatePhoto(h search.PerceptualHash, dist int) DuplicatePhoto {
+	return DuplicatePhoto{
+		UID:        h.PhotoUID,
+		FileUID:    h.FileUID,
+		TakenAt:    h.TakenAt,
+		Quality:    h.PhotoQuality,
+		Resolution: h.PhotoResolution,
+		Width:      h.FileWidth,
+		Height:     h.FileHeight,
+		Distance:   dist,
+	}
+}
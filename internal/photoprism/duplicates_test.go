@@ -0,0 +1,68 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/phash"
+)
+
+func TestGroupDuplicates(t *testing.T) {
+	taken := time.Date(2021, 6, 12, 10, 30, 0, 0, time.UTC)
+
+	hashes := []search.PerceptualHash{
+		{PhotoID: 1, PhotoUID: "pr1", PhotoQuality: 3, PhotoResolution: 12, TakenAt: taken, PHash: phash.Hash(0xc3a5e1f08c3b1d2e)},
+		{PhotoID: 2, PhotoUID: "pr2", PhotoQuality: 4, PhotoResolution: 2, TakenAt: taken.AddDate(1, 0, 0), PHash: phash.Hash(0xc3a5e1f08c3b1d2f)},
+		{PhotoID: 3, PhotoUID: "pr3", PhotoQuality: 3, PhotoResolution: 12, TakenAt: taken.Add(time.Hour), PHash: phash.Hash(0x00000000000000ff)},
+		{PhotoID: 4, PhotoUID: "pr4", PhotoQuality: 3, PhotoResolution: 16, TakenAt: taken.Add(time.Hour + time.Second), PHash: phash.Hash(0x0000000000003fff)},
+		{PhotoID: 5, PhotoUID: "pr5", PhotoQuality: 3, PhotoResolution: 16, TakenAt: taken.Add(2 * time.Hour), PHash: phash.Hash(0xffffffff00000000)},
+		{PhotoID: 6, PhotoUID: "pr6", PhotoQuality: 3, PhotoResolution: 16, PhotoStack: entity.IsUnstacked, TakenAt: taken, PHash: phash.Hash(0xc3a5e1f08c3b1d2e)},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		groups := GroupDuplicates(hashes, DuplicatesOptionsDefault())
+
+		if !assert.Len(t, groups, 2) {
+			return
+		}
+
+		// Near-duplicates taken a year apart, the better quality wins.
+		assert.Equal(t, DuplicateTypeDuplicate, groups[0].Type)
+		assert.Equal(t, "pr2", groups[0].Keeper.UID)
+		assert.Equal(t, []string{"pr1"}, groups[0].UIDs())
+		assert.Equal(t, 1, groups[0].Photos[0].Distance)
+		assert.True(t, groups[0].Contains("pr1"))
+		assert.True(t, groups[0].Contains("pr2"))
+		assert.False(t, groups[0].Contains("pr6"))
+
+		// Burst shots taken a second apart.
+		assert.Equal(t, DuplicateTypeBurst, groups[1].Type)
+		assert.Equal(t, "pr4", groups[1].Keeper.UID)
+		assert.Equal(t, []string{"pr3"}, groups[1].UIDs())
+		assert.Equal(t, 6, groups[1].Photos[0].Distance)
+
+		if g, ok := groups.Find("pr3"); assert.True(t, ok) {
+			assert.Equal(t, "pr4", g.Keeper.UID)
+		}
+
+		_, ok := groups.Find("pr5")
+		assert.False(t, ok)
+	})
+	t.Run("NoBursts", func(t *testing.T) {
+		opt := DuplicatesOptionsDefault()
+		opt.BurstInterval = 0
+
+		groups := GroupDuplicates(hashes, opt)
+
+		if assert.Len(t, groups, 1) {
+			assert.Equal(t, "pr2", groups[0].Keeper.UID)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, GroupDuplicates(nil, DuplicatesOptionsDefault()))
+	})
+}
@@ -101,10 +101,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	filesImported := 0
 
 	indexOpt := IndexOptions{
-		Path:    "/",
-		Rescan:  true,
-		Stack:   true,
-		Convert: imp.conf.Settings().Index.Convert && imp.conf.SidecarWritable(),
+		Path:     "/",
+		Rescan:   true,
+		Stack:    true,
+		Convert:  imp.conf.Settings().Index.Convert && imp.conf.SidecarWritable(),
+		OwnerUID: opt.OwnerUID,
 	}
 
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
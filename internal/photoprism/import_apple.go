@@ -0,0 +1,285 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/applephotos"
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// AppleResult represents the outcome of an Apple Photos library import.
+type AppleResult struct {
+	Photos    int
+	Albums    int
+	Favorites int
+	Faces     int
+	Missing   []string
+	NotFound  []string
+}
+
+// ImportApplePhotos imports the originals and edited versions of an Apple Photos library and applies
+// the metadata from its database, e.g. albums, favorites, titles, keywords, and named faces.
+//
+// Files are staged in the import path of the options, which should be an empty temporary folder.
+// Assets in the trash are skipped, and hidden assets are flagged as private.
+func ImportApplePhotos(imp *Import, lib *applephotos.Library, opt ImportOptions) (result AppleResult, err error) {
+	if imp == nil {
+		return result, fmt.Errorf("apple: importer is nil")
+	} else if lib == nil {
+		return result, fmt.Errorf("apple: library is nil")
+	} else if opt.Path == "" {
+		return result, fmt.Errorf("apple: import path is empty")
+	}
+
+	// Remember original file hashes, as files may be moved or converted while importing.
+	hashes := make(map[*applephotos.Asset]string, len(lib.Assets))
+
+	for _, a := range lib.Assets {
+		if a.Trashed {
+			continue
+		}
+
+		fileName := lib.OriginalName(a)
+
+		if !fs.FileExists(fileName) {
+			result.Missing = append(result.Missing, fs.RelName(fileName, lib.Path))
+			continue
+		}
+
+		dir := filepath.Join(opt.Path, a.UUID)
+
+		if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+			return result, err
+		}
+
+		if err = stageFile(fileName, filepath.Join(dir, a.Name())); err != nil {
+			return result, err
+		}
+
+		// Edited versions are staged with a related file name so that they are stacked with the original.
+		if renderName := lib.RenderName(a); renderName != "" {
+			if err = stageFile(renderName, filepath.Join(dir, appleEditedName(a.Name(), filepath.Ext(renderName)))); err != nil {
+				return result, err
+			}
+		}
+
+		hashes[a] = fs.Hash(fileName)
+	}
+
+	imp.Start(opt)
+
+	// Create albums.
+	albums := make(map[*applephotos.Album]string, len(lib.Albums))
+
+	for _, a := range lib.Albums {
+		if uid, err := importAlbum(a.Title, "", "", opt.OwnerUID); err != nil {
+			log.Errorf("apple: %s (create album %s)", err, sanitize.Log(a.Title))
+		} else {
+			albums[a] = uid
+			result.Albums++
+		}
+	}
+
+	// Apply metadata to the imported photos.
+	for _, a := range lib.Assets {
+		hash, ok := hashes[a]
+
+		if !ok {
+			continue
+		}
+
+		file, err := entity.FirstFileByHash(hash)
+
+		if err != nil || file.PhotoUID == "" {
+			result.NotFound = append(result.NotFound, fs.RelName(lib.OriginalName(a), lib.Path))
+			continue
+		}
+
+		photo, err := query.PhotoByUID(file.PhotoUID)
+
+		if err != nil {
+			result.NotFound = append(result.NotFound, fs.RelName(lib.OriginalName(a), lib.Path))
+			continue
+		}
+
+		if err = applePhoto(&photo, a); err != nil {
+			log.Errorf("apple: %s (update %s)", err, sanitize.Log(a.Name()))
+		} else if a.Favorite {
+			result.Favorites++
+		}
+
+		var albumUIDs []string
+
+		for _, album := range a.Albums {
+			if uid, ok := albums[album]; ok {
+				albumUIDs = append(albumUIDs, uid)
+			}
+		}
+
+		if err = entity.AddPhotoToAlbums(photo.PhotoUID, albumUIDs); err != nil {
+			log.Errorf("apple: %s (add %s to albums)", err, sanitize.Log(a.Name()))
+		}
+
+		if len(a.Faces) > 0 {
+			if primary, err := entity.PrimaryFile(photo.PhotoUID); err != nil {
+				log.Warnf("apple: %s (find primary file of %s)", err, sanitize.Log(a.Name()))
+			} else if n, err := appleFaces(primary, a.Faces); err != nil {
+				log.Errorf("apple: %s (add faces to %s)", err, sanitize.Log(a.Name()))
+			} else {
+				result.Faces += n
+			}
+		}
+
+		result.Photos++
+	}
+
+	// Update people and photo counts.
+	if result.Faces > 0 {
+		if err = entity.UpdateCounts(); err != nil {
+			log.Warnf("apple: %s (update counts)", err)
+		}
+	}
+
+	return result, nil
+}
+
+// applePhoto sets the favorite and private flags, title, description, and keywords of a photo.
+func applePhoto(photo *entity.Photo, a *applephotos.Asset) error {
+	if a.Favorite && !photo.PhotoFavorite {
+		if err := photo.SetFavorite(true); err != nil {
+			return err
+		}
+	}
+
+	photo.SetTitle(a.Title, entity.SrcMeta)
+	photo.SetDescription(a.Description, entity.SrcMeta)
+
+	if a.Hidden {
+		photo.PhotoPrivate = true
+	}
+
+	if err := photo.Updates(entity.Values{
+		"PhotoTitle":       photo.PhotoTitle,
+		"TitleSrc":         photo.TitleSrc,
+		"PhotoDescription": photo.PhotoDescription,
+		"DescriptionSrc":   photo.DescriptionSrc,
+		"PhotoPrivate":     photo.PhotoPrivate,
+	}); err != nil {
+		return err
+	}
+
+	if len(a.Keywords) > 0 {
+		photo.GetDetails().SetKeywords(strings.Join(a.Keywords, ", "), entity.SrcMeta)
+
+		if err := photo.SaveDetails(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appleFaces adds face markers for named people and returns the number of faces.
+func appleFaces(file *entity.File, faces []applephotos.Face) (count int, err error) {
+	markers := file.Markers()
+
+	if markers == nil {
+		return 0, nil
+	}
+
+	for _, f := range faces {
+		x, y, w, h := f.Rect(file.FileWidth, file.FileHeight)
+
+		if w == 0 || h == 0 {
+			continue
+		}
+
+		area := crop.NewArea(f.Name, float32(x), float32(y), float32(w), float32(h))
+		marker := entity.NewMarker(*file, area, "", entity.SrcMeta, entity.MarkerFace, int(w*float64(file.FileWidth)), 100)
+
+		if marker == nil {
+			continue
+		}
+
+		// Name existing markers at the same position, e.g. if the face was detected while indexing.
+		if existing := appleMarker(*markers, *marker); existing != nil {
+			if changed, err := existing.SetName(f.Name, entity.SrcMeta); err != nil {
+				log.Errorf("apple: %s (set name %s)", err, sanitize.Log(f.Name))
+			} else if !changed {
+				continue
+			} else if err = existing.Save(); err != nil {
+				log.Errorf("apple: %s (save marker %s)", err, sanitize.Log(existing.MarkerUID))
+			} else {
+				count++
+			}
+
+			continue
+		}
+
+		marker.MarkerName = sanitize.Name(f.Name)
+		marker.SubjSrc = entity.SrcMeta
+
+		// Find or create the subject.
+		if marker.Subject() == nil {
+			continue
+		}
+
+		markers.Append(*marker)
+		count++
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	if _, err = file.SaveMarkers(); err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// appleMarker returns the existing face marker at the same position, if any.
+func appleMarker(markers entity.Markers, marker entity.Marker) *entity.Marker {
+	for i := range markers {
+		if markers[i].MarkerType == entity.MarkerFace && markers[i].OverlapPercent(marker) > face.OverlapThreshold {
+			return &markers[i]
+		}
+	}
+
+	return nil
+}
+
+// appleEditedName returns the name of the edited version, so that it is related to the original.
+func appleEditedName(name, ext string) string {
+	base := fs.StripExt(name)
+
+	switch {
+	case !strings.EqualFold(filepath.Ext(name), ext):
+		// Same base name, e.g. "IMG_1234.jpeg" for "IMG_1234.HEIC".
+		return base + ext
+	case strings.HasPrefix(strings.ToUpper(base), "IMG_") && len(base) > 4:
+		// Apple naming scheme, e.g. "IMG_E1234.JPG" for "IMG_1234.JPG".
+		return base[:4] + "E" + base[4:] + filepath.Ext(name)
+	default:
+		// Stacked with the original if sequences are stacked.
+		return base + " (1)" + ext
+	}
+}
+
+// stageFile creates a hard link to the file, or a copy if links are not supported.
+func stageFile(src, dest string) error {
+	if err := os.Link(src, dest); err == nil {
+		return nil
+	}
+
+	return fs.Copy(src, dest)
+}
@@ -0,0 +1,47 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/applephotos"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestImportApplePhotos(t *testing.T) {
+	t.Run("NoImporter", func(t *testing.T) {
+		_, err := ImportApplePhotos(nil, &applephotos.Library{}, ImportOptionsMove(t.TempDir()))
+		assert.Error(t, err)
+	})
+	t.Run("NoLibrary", func(t *testing.T) {
+		_, err := ImportApplePhotos(&Import{}, nil, ImportOptionsMove(t.TempDir()))
+		assert.Error(t, err)
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		_, err := ImportApplePhotos(&Import{}, &applephotos.Library{}, ImportOptionsMove(""))
+		assert.Error(t, err)
+	})
+}
+
+func TestApplePhoto(t *testing.T) {
+	photo := entity.PhotoFixtures.Get("Photo02")
+
+	a := &applephotos.Asset{Title: "Beach", Description: "Sunset at the beach", Favorite: true, Keywords: []string{"Holiday"}}
+
+	if err := applePhoto(&photo, a); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, photo.PhotoFavorite)
+	assert.Equal(t, "Beach", photo.PhotoTitle)
+	assert.Equal(t, entity.SrcMeta, photo.TitleSrc)
+	assert.Equal(t, "Sunset at the beach", photo.PhotoDescription)
+}
+
+func TestAppleEditedName(t *testing.T) {
+	assert.Equal(t, "IMG_1234.jpeg", appleEditedName("IMG_1234.HEIC", ".jpeg"))
+	assert.Equal(t, "IMG_E1234.JPG", appleEditedName("IMG_1234.JPG", ".jpg"))
+	assert.Equal(t, "IMG_E1234.MOV", appleEditedName("IMG_1234.MOV", ".mov"))
+	assert.Equal(t, "Beach (1).jpeg", appleEditedName("Beach.jpeg", ".jpeg"))
+}
@@ -7,6 +7,8 @@ type ImportOptions struct {
 	RemoveDotFiles         bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	OwnerUID               string
+	LinkUID                string
 }
 
 // ImportOptionsCopy returns import options for copying files to originals (read-only).
@@ -0,0 +1,141 @@
+package photoprism
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/takeout"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// TakeoutResult represents the outcome of a Google Takeout import.
+type TakeoutResult struct {
+	Photos    int
+	Albums    int
+	Favorites int
+	Unmatched []string
+	NotFound  []string
+}
+
+// ImportTakeout imports the media files of a Google Takeout export and applies the metadata
+// from their JSON files, e.g. albums, favorites, descriptions, and people.
+func ImportTakeout(imp *Import, archive *takeout.Archive, opt ImportOptions) (result TakeoutResult, err error) {
+	if imp == nil {
+		return result, fmt.Errorf("takeout: importer is nil")
+	} else if archive == nil {
+		return result, fmt.Errorf("takeout: archive is nil")
+	}
+
+	result.Unmatched = archive.Unmatched
+
+	// Remember file hashes, as files may be moved or converted while importing.
+	hashes := make(map[string]string, len(archive.Photos))
+
+	for _, p := range archive.Photos {
+		if hash := fs.Hash(p.FileName); hash != "" {
+			hashes[p.FileName] = hash
+		}
+	}
+
+	opt.Path = archive.Root
+
+	imp.Start(opt)
+
+	// Create albums.
+	albums := make(map[*takeout.Album]string, len(archive.Albums))
+
+	for _, a := range archive.Albums {
+		if uid, err := takeoutAlbum(a, opt.OwnerUID); err != nil {
+			log.Errorf("takeout: %s (create album %s)", err, sanitize.Log(a.Title))
+		} else {
+			albums[a] = uid
+			result.Albums++
+		}
+	}
+
+	// Apply metadata to the imported photos.
+	for _, p := range archive.Photos {
+		hash, ok := hashes[p.FileName]
+
+		if !ok {
+			result.NotFound = append(result.NotFound, p.FileName)
+			continue
+		}
+
+		file, err := entity.FirstFileByHash(hash)
+
+		if err != nil || file.PhotoUID == "" {
+			result.NotFound = append(result.NotFound, p.FileName)
+			continue
+		}
+
+		photo, err := query.PhotoByUID(file.PhotoUID)
+
+		if err != nil {
+			result.NotFound = append(result.NotFound, p.FileName)
+			continue
+		}
+
+		if p.Matched() {
+			if err = takeoutPhoto(&photo, p.Meta); err != nil {
+				log.Errorf("takeout: %s (update %s)", err, sanitize.Log(p.FileName))
+			} else if p.Meta.Favorited {
+				result.Favorites++
+			}
+		}
+
+		if uid, ok := albums[p.Album]; ok {
+			if err = entity.AddPhotoToAlbums(photo.PhotoUID, []string{uid}); err != nil {
+				log.Errorf("takeout: %s (add %s to album)", err, sanitize.Log(p.FileName))
+			}
+		}
+
+		result.Photos++
+	}
+
+	return result, nil
+}
+
+// takeoutAlbum finds or creates an album and returns its UID.
+func takeoutAlbum(a *takeout.Album, ownerUID string) (string, error) {
+	if a == nil {
+		return "", fmt.Errorf("album is nil")
+	}
+
+	return importAlbum(a.Title, meta.SanitizeDescription(a.Description), a.Location, ownerUID)
+}
+
+// takeoutPhoto sets the favorite flag, description, and keywords of a photo.
+func takeoutPhoto(photo *entity.Photo, data meta.GPhoto) error {
+	if data.Favorited && !photo.PhotoFavorite {
+		if err := photo.SetFavorite(true); err != nil {
+			return err
+		}
+	}
+
+	if desc := data.SanitizedDescription(); desc != "" {
+		photo.SetDescription(desc, entity.SrcMeta)
+
+		if err := photo.Updates(entity.Values{
+			"PhotoDescription": photo.PhotoDescription,
+			"DescriptionSrc":   photo.DescriptionSrc,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// People tags are added as keywords, as there are no face markers to assign them to.
+	if names := data.PeopleNames(); len(names) > 0 {
+		photo.GetDetails().SetKeywords(strings.Join(names, ", "), entity.SrcMeta)
+
+		if err := photo.SaveDetails(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
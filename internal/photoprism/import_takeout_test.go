@@ -0,0 +1,63 @@
+package photoprism
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/takeout"
+)
+
+func TestImportTakeout(t *testing.T) {
+	t.Run("NoImporter", func(t *testing.T) {
+		_, err := ImportTakeout(nil, &takeout.Archive{}, ImportOptionsCopy(""))
+		assert.Error(t, err)
+	})
+	t.Run("NoArchive", func(t *testing.T) {
+		_, err := ImportTakeout(&Import{}, nil, ImportOptionsCopy(""))
+		assert.Error(t, err)
+	})
+}
+
+func TestTakeoutAlbum(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		a := &takeout.Album{GAlbum: meta.GAlbum{Title: "Takeout Trip", Description: "Summer"}}
+
+		uid, err := takeoutAlbum(a, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, uid)
+
+		// Existing albums are reused.
+		existing, err := takeoutAlbum(a, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, uid, existing)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		_, err := takeoutAlbum(nil, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestTakeoutPhoto(t *testing.T) {
+	photo := entity.PhotoFixtures.Get("Photo01")
+
+	data := meta.GPhoto{Description: "Takeout description", Favorited: true, People: []meta.GPerson{{Name: "Jane Doe"}}}
+
+	if err := takeoutPhoto(&photo, data); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, photo.PhotoFavorite)
+	assert.Contains(t, strings.ToLower(photo.GetDetails().Keywords), "jane")
+}
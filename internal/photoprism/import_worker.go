@@ -93,12 +93,17 @@ func ImportWorker(jobs <-chan ImportJob) {
 				log.Infof("import: %s", err)
 
 				// Try to add duplicates to selected album(s) as well, see #991.
+				// Duplicates uploaded with a link are recorded like new photos, so that they can be reviewed.
 				if fileHash := f.Hash(); fileHash == "" {
 					// Do nothing.
 				} else if file, err := entity.FirstFileByHash(fileHash); err != nil {
 					// Do nothing.
 				} else if err := entity.AddPhotoToAlbums(file.PhotoUID, opt.Albums); err != nil {
 					log.Warn(err)
+				} else if opt.LinkUID == "" || f != related.Main {
+					// Do nothing.
+				} else if err := entity.AddLinkUpload(opt.LinkUID, file.PhotoUID, originalName); err != nil {
+					log.Warn(err)
 				}
 
 				// Remove duplicates to save storage.
@@ -182,6 +187,13 @@ func ImportWorker(jobs <-chan ImportJob) {
 					if err := entity.AddPhotoToAlbums(photoUID, opt.Albums); err != nil {
 						log.Warn(err)
 					}
+
+					// Record photos uploaded with a link, they are added to its album unless moderation is required.
+					if opt.LinkUID == "" {
+						// Do nothing.
+					} else if err := entity.AddLinkUpload(opt.LinkUID, photoUID, originalName); err != nil {
+						log.Warn(err)
+					}
 				}
 			} else {
 				log.Warnf("import: found no main file for %s, conversion to jpeg may have failed", fs.RelName(destMainFileName, imp.originalsPath()))
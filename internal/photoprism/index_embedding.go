@@ -0,0 +1,57 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/clip"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// EmbeddingEnabled tests if a semantic search model is configured.
+func (ind *Index) EmbeddingEnabled() bool {
+	return ind.clipModel != nil && ind.clipModel.Name() != clip.EngineNone
+}
+
+// EmbeddingOutdated tests if the photo has no embedding of the configured model yet.
+func (ind *Index) EmbeddingOutdated(photoID uint) bool {
+	if !ind.EmbeddingEnabled() {
+		return false
+	} else if m := entity.FindPhotoEmbedding(photoID); m == nil || m.EmbeddingModel != ind.conf.ClipModel() {
+		return true
+	}
+
+	return false
+}
+
+// Embedding returns the semantic search embedding of a media file, or an error if it could not be computed.
+func (ind *Index) Embedding(jpeg *MediaFile) (clip.Result, error) {
+	if !ind.EmbeddingEnabled() {
+		return clip.Result{}, nil
+	}
+
+	start := time.Now()
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbPath(), thumb.Tile224)
+
+	if err != nil {
+		log.Debugf("index: %s in %s (embedding)", err, sanitize.Log(jpeg.BaseName()))
+		return clip.Result{}, err
+	}
+
+	result, err := ind.clipModel.Image(thumbName)
+
+	if err != nil {
+		log.Warnf("index: %s in %s (embedding %s)", err, sanitize.Log(jpeg.BaseName()), ind.clipModel.Name())
+		return clip.Result{}, err
+	}
+
+	if model := ind.conf.ClipModel(); result.Model != model {
+		log.Warnf("index: embedding model %s does not match the configured model %s", sanitize.Log(result.Model), sanitize.Log(model))
+	}
+
+	log.Debugf("index: computed %s embedding of %s [%s]", result.Model, sanitize.Log(jpeg.BaseName()), time.Since(start))
+
+	return result, nil
+}
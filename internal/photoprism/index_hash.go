@@ -0,0 +1,35 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// PerceptualHash returns the pHash and dHash of a media file, computed from its existing thumbnail.
+func (ind *Index) PerceptualHash(jpeg *MediaFile) (pHash, dHash phash.Hash, err error) {
+	start := time.Now()
+
+	img, err := jpeg.Resample(Config().ThumbPath(), thumb.Tile224)
+
+	if err != nil {
+		log.Debugf("index: %s in %s (perceptual hash)", err, sanitize.Log(jpeg.BaseName()))
+		return 0, 0, err
+	}
+
+	if pHash, err = phash.PHash(img); err != nil {
+		log.Debugf("index: %s in %s (phash)", err, sanitize.Log(jpeg.BaseName()))
+		return 0, 0, err
+	}
+
+	if dHash, err = phash.DHash(img); err != nil {
+		log.Debugf("index: %s in %s (dhash)", err, sanitize.Log(jpeg.BaseName()))
+		return 0, 0, err
+	}
+
+	log.Debugf("index: computed perceptual hash of %s [%s]", sanitize.Log(jpeg.BaseName()), time.Since(start))
+
+	return pHash, dHash, nil
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/clip"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/ocr"
 	"github.com/photoprism/photoprism/internal/query"
 
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -51,6 +53,8 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	photo := entity.NewPhoto(o.Stack)
 	metaData := meta.NewData()
 	labels := classify.Labels{}
+	ocrResults := ocr.Results{}
+	ocrDone := false
 	stripSequence := Config().Settings().StackSequences() && o.Stack
 
 	fileRoot, fileBase, filePath, fileName := m.PathNameInfo(stripSequence)
@@ -161,7 +165,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		} else if photoQuery = entity.UnscopedDb().First(&photo, "photo_path = ? AND photo_name = ? AND photo_stack > -1", filePath, fileBase); photoQuery.Error == nil {
 			// Found.
 			fileStacked = true
-		} else if photoQuery = entity.UnscopedDb().First(&photo, "id IN (SELECT photo_id FROM files WHERE file_name = LIKE ? AND file_root = ? AND file_sidecar = 0 AND file_missing = 0) AND photo_path = ? AND photo_stack > -1", fs.StripKnownExt(fileName)+".%", entity.RootOriginals, filePath); photoQuery.Error == nil {
+		} else if photoQuery = entity.UnscopedDb().First(&photo, "id IN (SELECT photo_id FROM files WHERE file_name = LIKE ? AND file_root = ? AND file_sidecar = FALSE AND file_missing = FALSE) AND photo_path = ? AND photo_stack > -1", fs.StripKnownExt(fileName)+".%", entity.RootOriginals, filePath); photoQuery.Error == nil {
 			// Found.
 			fileStacked = true
 		}
@@ -252,6 +256,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			photo.PhotoStack = entity.IsStackable
 		}
 
+		// New pictures are owned by the user who uploaded or imported them, if any.
+		photo.SetOwner(o.OwnerUID)
+
 		if Config().BackupYaml() {
 			if yamlName := fs.FormatYaml.FindFirst(m.FileName(), []string{Config().SidecarPath(), fs.HiddenPath}, Config().OriginalsPath(), stripSequence); yamlName != "" {
 				if err := photo.LoadFromYaml(yamlName); err != nil {
@@ -284,7 +291,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	// Flag first JPEG as primary file for this photo.
 	if !file.FilePrimary {
 		if photoExists {
-			if res := entity.UnscopedDb().Where("photo_id = ? AND file_primary = 1 AND file_type in ('jpg','heif') AND file_error = ''", photo.ID).First(&primaryFile); res.Error != nil {
+			if res := entity.UnscopedDb().Where("photo_id = ? AND file_primary = TRUE AND file_type in ('jpg','heif') AND file_error = ''", photo.ID).First(&primaryFile); res.Error != nil {
 				file.FilePrimary = m.IsJpeg() || m.IsHEIF()
 			}
 		} else {
@@ -324,16 +331,23 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		photo.DeletedAt = nil
 	}
 
-	if o.OcrOnly && (!photoExists || !fileExists || !file.FilePrimary || file.FileError != "") {
+	if o.OcrOnly && (!photoExists || !fileExists || !file.FilePrimary || file.FileError != "" || !ind.OcrEnabled()) {
 		result.Status = IndexSkipped
 		return result
-	} else if file.FilePrimary {
-		text := ind.Ocr(m)
-		details.SetNotes(text, entity.SrcAuto)
-		if o.OcrOnly {
-			photo.SaveDetails()
+	} else if file.FilePrimary && ind.OcrEnabled() {
+		// Keep existing text regions if text recognition failed.
+		if textResults, err := ind.Ocr(m); err != nil {
+			// Do nothing.
+		} else if o.OcrOnly {
+			if err := entity.ReplacePhotoTexts(photo.ID, PhotoTexts(photo, file.FileUID, textResults)); err != nil {
+				log.Errorf("index: %s in %s (save text)", err, logName)
+			}
+
 			result.Status = IndexSkipped
 			return result
+		} else {
+			ocrResults = textResults
+			ocrDone = true
 		}
 	}
 
@@ -385,6 +399,15 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 		}
 	}
 
+	// Compute the semantic search embedding of new and changed primary files, and if the model has changed.
+	embedding := clip.Result{}
+
+	if file.FilePrimary && (fileChanged || ind.EmbeddingOutdated(photo.ID)) {
+		if r, err := ind.Embedding(m); err == nil {
+			embedding = r
+		}
+	}
+
 	// Handle file types.
 	switch {
 	case m.IsJpeg():
@@ -402,6 +425,13 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 			}
 		}
 
+		// Compute perceptual hashes to find visually similar photos.
+		if fileChanged || !file.HasPerceptualHash() {
+			if pHash, dHash, err := ind.PerceptualHash(m); err == nil {
+				file.SetPerceptualHash(pHash, dHash)
+			}
+		}
+
 		if metaData := m.MetaData(); metaData.Error == nil {
 			file.FileCodec = metaData.Codec
 			file.SetProjection(metaData.Projection)
@@ -823,13 +853,25 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName, photoUID
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
+	if ocrDone {
+		if err := entity.ReplacePhotoTexts(photo.ID, PhotoTexts(photo, file.FileUID, ocrResults)); err != nil {
+			log.Errorf("index: %s in %s (save text)", err, logName)
+		}
+	}
+
+	if len(embedding.Embedding) > 0 {
+		if err := entity.NewPhotoEmbedding(photo, file.FileUID, embedding.Model, embedding.Embedding).Save(); err != nil {
+			log.Errorf("index: %s in %s (save embedding)", err, logName)
+		}
+	}
+
 	downloadedAs := fileName
 
 	if originalName != "" {
 		downloadedAs = originalName
 	}
 
-	if err := query.SetDownloadFileID(downloadedAs, file.ID); err != nil {
+	if err := query.SetDownloadFileID(downloadedAs, file.ID, file.FileHash); err != nil {
 		log.Errorf("index: %s in %s (set download id)", err, logName)
 	}
 
@@ -6,9 +6,11 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/clip"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/ocr"
 )
 
 func TestIndex_MediaFile(t *testing.T) {
@@ -23,10 +25,10 @@ func TestIndex_MediaFile(t *testing.T) {
 
 		tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
 		nd := nsfw.New(conf.NSFWModelPath())
-		fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+		fn := face.NewNet(face.NewBuiltin(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, ocr.NewNone(), clip.NewNone(), convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 		mediaFile, err := NewMediaFile("testdata/flash.jpg")
 
@@ -58,10 +60,10 @@ func TestIndex_MediaFile(t *testing.T) {
 
 		tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
 		nd := nsfw.New(conf.NSFWModelPath())
-		fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+		fn := face.NewNet(face.NewBuiltin(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, ocr.NewNone(), clip.NewNone(), convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/blue-go-video.mp4")
 		if err != nil {
@@ -80,10 +82,10 @@ func TestIndex_MediaFile(t *testing.T) {
 
 		tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
 		nd := nsfw.New(conf.NSFWModelPath())
-		fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+		fn := face.NewNet(face.NewBuiltin(), conf.DisableTensorFlow())
 		convert := NewConvert(conf)
 
-		ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+		ind := NewIndex(conf, tf, nd, fn, ocr.NewNone(), clip.NewNone(), convert, NewFiles(), NewPhotos())
 		indexOpt := IndexOptionsAll()
 
 		result := ind.MediaFile(nil, indexOpt, "blue-go-video.mp4", "")
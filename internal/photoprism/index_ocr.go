@@ -1,17 +1,27 @@
 package photoprism
 
 import (
-	"io"
-	"net/http"
-	"net/url"
+	"fmt"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ocr"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
-// Ocr return text ocr result
-func (ind *Index) Ocr(jpeg *MediaFile) string {
+// OcrEnabled tests if a text recognition engine is configured, so that existing text regions
+// are only replaced with the results of an engine that actually ran.
+func (ind *Index) OcrEnabled() bool {
+	return ind.ocrEngine != nil && ind.ocrEngine.Name() != ocr.EngineNone
+}
+
+// Ocr returns the text regions found in a media file, or an error if text recognition failed.
+func (ind *Index) Ocr(jpeg *MediaFile) (ocr.Results, error) {
+	if !ind.OcrEnabled() {
+		return ocr.Results{}, fmt.Errorf("text recognition is disabled")
+	}
+
 	start := time.Now()
 
 	size := thumb.Fit1280
@@ -20,25 +30,32 @@ func (ind *Index) Ocr(jpeg *MediaFile) string {
 
 	if err != nil {
 		log.Debugf("index: %s in %s (ocr)", err, sanitize.Log(jpeg.BaseName()))
-		return ""
+		return ocr.Results{}, err
 	}
 
-	resp, err := http.Get("http://localhost:8009/ocr?f=" + url.QueryEscape(thumbName))
+	results, err := ind.ocrEngine.Detect(thumbName)
 
 	if err != nil {
-		log.Debugf("index: %s in %s (ocr)", err, sanitize.Log(jpeg.BaseName()))
-		return ""
+		log.Warnf("index: %s in %s (ocr %s)", err, sanitize.Log(jpeg.BaseName()), ind.ocrEngine.Name())
+		return ocr.Results{}, err
 	}
 
-	defer resp.Body.Close()
+	log.Infof("index: found %d text regions in %s [%s]", len(results), sanitize.Log(jpeg.BaseName()), time.Since(start))
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		log.Debugf("index: %s in %s (ocr)", err, sanitize.Log(jpeg.BaseName()))
-		return ""
-	}
+	return results, nil
+}
 
-	log.Infof("index: ocr for %s [%s]", sanitize.Log(jpeg.BaseName()), time.Since(start))
+// PhotoTexts converts text recognition results into text region entities.
+func PhotoTexts(photo entity.Photo, fileUID string, results ocr.Results) entity.PhotoTexts {
+	texts := make(entity.PhotoTexts, 0, len(results))
+
+	for _, r := range results {
+		if r.Text == "" {
+			continue
+		}
+
+		texts = append(texts, entity.NewPhotoText(photo, fileUID, r.X, r.Y, r.W, r.H, r.Lang, r.Confidence, r.Text))
+	}
 
-	return string(body)
+	return texts
 }
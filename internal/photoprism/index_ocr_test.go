@@ -0,0 +1,31 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ocr"
+)
+
+func TestIndex_OcrEnabled(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		ind := &Index{ocrEngine: ocr.NewNone()}
+
+		assert.False(t, ind.OcrEnabled())
+
+		_, err := ind.Ocr(nil)
+
+		assert.Error(t, err)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		ind := &Index{}
+
+		assert.False(t, ind.OcrEnabled())
+	})
+	t.Run("Exec", func(t *testing.T) {
+		ind := &Index{ocrEngine: ocr.New(ocr.Options{Engine: ocr.EngineExec})}
+
+		assert.True(t, ind.OcrEnabled())
+	})
+}
@@ -8,6 +8,7 @@ type IndexOptions struct {
 	FacesOnly  bool
 	LabelsOnly bool
 	OcrOnly    bool
+	OwnerUID   string
 }
 
 func (o *IndexOptions) SkipUnchanged() bool {
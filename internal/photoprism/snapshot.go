@@ -0,0 +1,292 @@
+package photoprism
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// SnapshotVersion is incremented when the snapshot schema changes in a way that clients must know about.
+const SnapshotVersion = "2"
+
+// SnapshotTable represents a database table included in client snapshots.
+type SnapshotTable struct {
+	Name      string
+	Model     interface{}
+	Delta     string // Condition for rows changed since the last sync, "?" is replaced with the timestamp.
+	DeltaOnly bool   // Only included in delta snapshots.
+}
+
+// SnapshotTables lists the tables included in client snapshots.
+//
+// Rows that have been soft deleted are included with their deletion timestamp. Photo labels are
+// also included for every changed photo so that clients can replace them. Delta snapshots contain
+// tombstones with the table name and key of rows that have been deleted permanently, so that
+// clients can delete them as well.
+var SnapshotTables = []SnapshotTable{
+	{Name: entity.Photo{}.TableName(), Model: &entity.Photo{}, Delta: "updated_at > ? OR deleted_at > ?"},
+	{Name: entity.File{}.TableName(), Model: &entity.File{}, Delta: "updated_at > ? OR deleted_at > ?"},
+	{Name: entity.Album{}.TableName(), Model: &entity.Album{}, Delta: "updated_at > ? OR deleted_at > ?"},
+	{Name: entity.PhotoAlbum{}.TableName(), Model: &entity.PhotoAlbum{}, Delta: "updated_at > ?"},
+	{Name: entity.Label{}.TableName(), Model: &entity.Label{}, Delta: "updated_at > ? OR deleted_at > ?"},
+	{Name: entity.PhotoLabel{}.TableName(), Model: &entity.PhotoLabel{}, Delta: "updated_at > ? OR photo_id IN (SELECT id FROM photos WHERE updated_at > ? OR deleted_at > ?)"},
+	{Name: entity.Tombstone{}.TableName(), Model: &entity.Tombstone{}, Delta: "created_at >= ?", DeltaOnly: true},
+}
+
+// SnapshotInfo contains information about a created snapshot.
+type SnapshotInfo struct {
+	FileName  string
+	Since     time.Time
+	CreatedAt time.Time
+	Rows      map[string]int
+}
+
+// Delta tests if the snapshot only contains changes.
+func (info SnapshotInfo) Delta() bool {
+	return !info.Since.IsZero()
+}
+
+// Snapshot represents a worker that exports the index to a SQLite database file for clients.
+type Snapshot struct {
+	conf *config.Config
+}
+
+// NewSnapshot returns a new Snapshot worker.
+func NewSnapshot(conf *config.Config) *Snapshot {
+	instance := &Snapshot{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Create exports the index to a new SQLite database file in the temp path. If since is not zero and within
+// the tombstone retention, only rows changed after this time are included. The caller must remove the file when done.
+func (w *Snapshot) Create(since time.Time) (info SnapshotInfo, err error) {
+	// Tombstones of older deletions may already have been removed, so clients need a full snapshot.
+	if !since.IsZero() && since.Before(time.Now().Add(-entity.TombstoneRetention)) {
+		since = time.Time{}
+	}
+
+	// Rows changed while the snapshot is created are included again with the next delta.
+	info = SnapshotInfo{
+		Since:     since.UTC(),
+		CreatedAt: time.Now().UTC(),
+		Rows:      make(map[string]int, len(SnapshotTables)),
+	}
+
+	if err = os.MkdirAll(w.conf.TempPath(), os.ModePerm); err != nil {
+		return info, err
+	}
+
+	f, err := os.CreateTemp(w.conf.TempPath(), "sync-*.db")
+
+	if err != nil {
+		return info, err
+	}
+
+	info.FileName = f.Name()
+
+	if err = f.Close(); err != nil {
+		_ = os.Remove(info.FileName)
+		return info, err
+	}
+
+	if err = w.export(&info); err != nil {
+		_ = os.Remove(info.FileName)
+		return info, err
+	}
+
+	return info, nil
+}
+
+// export writes the snapshot tables to the database file.
+func (w *Snapshot) export(info *SnapshotInfo) error {
+	db, err := gorm.Open(entity.SQLite3, info.FileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	db.LogMode(false)
+
+	for _, t := range SnapshotTables {
+		if t.DeltaOnly && !info.Delta() {
+			continue
+		}
+
+		if err = db.AutoMigrate(t.Model).Error; err != nil {
+			return fmt.Errorf("snapshot: %s (create %s)", err, t.Name)
+		}
+
+		n, err := w.copyTable(db.DB(), t, info.Since)
+
+		if err != nil {
+			return fmt.Errorf("snapshot: %s (copy %s)", err, t.Name)
+		}
+
+		info.Rows[t.Name] = n
+	}
+
+	since := ""
+
+	if info.Delta() {
+		since = info.Since.Format(time.RFC3339)
+	}
+
+	stmts := []string{
+		"CREATE TABLE sync_info (name TEXT PRIMARY KEY, value TEXT)",
+		fmt.Sprintf("INSERT INTO sync_info VALUES ('version', '%s')", SnapshotVersion),
+		fmt.Sprintf("INSERT INTO sync_info VALUES ('created_at', '%s')", info.CreatedAt.Format(time.RFC3339)),
+		fmt.Sprintf("INSERT INTO sync_info VALUES ('since', '%s')", since),
+	}
+
+	for _, s := range stmts {
+		if err = db.Exec(s).Error; err != nil {
+			return fmt.Errorf("snapshot: %s (sync info)", err)
+		}
+	}
+
+	return nil
+}
+
+// copyTable copies the rows of a table that exist in the index to the snapshot database.
+func (w *Snapshot) copyTable(dst *sql.DB, t SnapshotTable, since time.Time) (n int, err error) {
+	dstCols, err := snapshotColumns(dst, t.Name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	q := entity.UnscopedDb().Table(t.Name)
+
+	if !since.IsZero() {
+		args := make([]interface{}, strings.Count(t.Delta, "?"))
+
+		for i := range args {
+			// Timestamps may be stored with second precision only.
+			args[i] = since.Truncate(time.Second)
+		}
+
+		q = q.Where(t.Delta, args...)
+	}
+
+	rows, err := q.Rows()
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Only copy columns that exist in both databases.
+	var names, placeholders []string
+	var indexes []int
+
+	for i, col := range cols {
+		if dstCols[col] {
+			names = append(names, fmt.Sprintf("%q", col))
+			placeholders = append(placeholders, "?")
+			indexes = append(indexes, i)
+		}
+	}
+
+	if len(indexes) == 0 {
+		return 0, fmt.Errorf("no matching columns")
+	}
+
+	tx, err := dst.Begin()
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.Prepare(fmt.Sprintf("INSERT INTO %q (%s) VALUES (%s)", t.Name, strings.Join(names, ", "), strings.Join(placeholders, ", ")))
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer stmt.Close()
+
+	values := make([]interface{}, len(cols))
+	ptrs := make([]interface{}, len(cols))
+	args := make([]interface{}, len(indexes))
+
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(ptrs...); err != nil {
+			return n, err
+		}
+
+		for i, j := range indexes {
+			// MySQL returns text columns as bytes, which SQLite would store as blobs.
+			if b, ok := values[j].([]byte); ok {
+				args[i] = string(b)
+			} else {
+				args[i] = values[j]
+			}
+		}
+
+		if _, err = stmt.Exec(args...); err != nil {
+			return n, err
+		}
+
+		n++
+	}
+
+	if err = rows.Err(); err != nil {
+		return n, err
+	}
+
+	return n, tx.Commit()
+}
+
+// snapshotColumns returns the column names of a snapshot table.
+func snapshotColumns(db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.Query(fmt.Sprintf("SELECT * FROM %q LIMIT 0", table))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]bool, len(cols))
+
+	for _, col := range cols {
+		result[col] = true
+	}
+
+	return result, nil
+}
@@ -0,0 +1,128 @@
+package photoprism
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSnapshot_Create(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("Full", func(t *testing.T) {
+		info, err := NewSnapshot(conf).Create(time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(info.FileName)
+
+		assert.False(t, info.Delta())
+		assert.FileExists(t, info.FileName)
+		assert.Greater(t, info.Rows["photos"], 0)
+		assert.Greater(t, info.Rows["files"], 0)
+		assert.Greater(t, info.Rows["albums"], 0)
+		assert.Greater(t, info.Rows["labels"], 0)
+
+		db, err := sql.Open("sqlite3", info.FileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer db.Close()
+
+		var count int
+		var version string
+
+		assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM photos").Scan(&count))
+		assert.Equal(t, info.Rows["photos"], count)
+		assert.NoError(t, db.QueryRow("SELECT value FROM sync_info WHERE name = 'version'").Scan(&version))
+		assert.Equal(t, SnapshotVersion, version)
+
+		// Tombstones are only included in delta snapshots.
+		assert.Error(t, db.QueryRow("SELECT COUNT(*) FROM tombstones").Scan(&count))
+	})
+	t.Run("Delta", func(t *testing.T) {
+		info, err := NewSnapshot(conf).Create(time.Now().Add(time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(info.FileName)
+
+		assert.True(t, info.Delta())
+		assert.Equal(t, 0, info.Rows["photos"])
+		assert.Equal(t, 0, info.Rows["photos_labels"])
+		assert.Equal(t, 0, info.Rows["tombstones"])
+	})
+	t.Run("Expired", func(t *testing.T) {
+		info, err := NewSnapshot(conf).Create(time.Now().Add(-2 * entity.TombstoneRetention))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(info.FileName)
+
+		// Tombstones may already have been removed, so the snapshot must be complete.
+		assert.False(t, info.Delta())
+		assert.Greater(t, info.Rows["photos"], 0)
+	})
+	t.Run("Tombstones", func(t *testing.T) {
+		since := time.Now()
+		photo := entity.PhotoFixtures.Get("Photo04")
+		label := entity.FirstOrCreateLabel(entity.NewLabel("Snapshot Tombstone", 0))
+
+		if label == nil {
+			t.Fatal("label must not be nil")
+		}
+
+		m := entity.NewPhotoLabel(photo.ID, label.ID, 10, "manual")
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		info, err := NewSnapshot(conf).Create(since)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(info.FileName)
+
+		assert.Greater(t, info.Rows["tombstones"], 0)
+	})
+	t.Run("UniqueFiles", func(t *testing.T) {
+		a, err := NewSnapshot(conf).Create(time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(a.FileName)
+
+		b, err := NewSnapshot(conf).Create(time.Time{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(b.FileName)
+
+		assert.NotEqual(t, a.FileName, b.FileName)
+	})
+}
@@ -38,7 +38,7 @@ func AlbumCoverByUID(uid string) (file entity.File, err error) {
 			return file, err
 		} else if len(photos) > 0 {
 			for _, photo := range photos {
-				if err := Db().Where("photo_uid = ? AND file_primary = 1", photo.PhotoUID).First(&file).Error; err != nil {
+				if err := Db().Where("photo_uid = ? AND file_primary = TRUE", photo.PhotoUID).First(&file).Error; err != nil {
 					return file, err
 				} else {
 					return file, nil
@@ -60,9 +60,9 @@ func AlbumCoverByUID(uid string) (file entity.File, err error) {
 		return file, fmt.Errorf("no cover found")
 	}
 
-	if err := Db().Where("pa.album_uid = ? and files.file_primary = 1 AND files.file_missing = 0 AND files.file_type in ('jpg', 'heif') AND files.deleted_at IS NULL", uid).
-		Joins("JOIN photos_albums pa ON pa.photo_uid = files.photo_uid AND pa.hidden = 0").
-		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = 0 AND photos.+deleted_at IS NULL").
+	if err := Db().Where("pa.album_uid = ? and files.file_primary = TRUE AND files.file_missing = FALSE AND files.file_type in ('jpg', 'heif') AND files.deleted_at IS NULL", uid).
+		Joins("JOIN photos_albums pa ON pa.photo_uid = files.photo_uid AND pa.hidden = FALSE").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.photo_private = FALSE AND photos.+deleted_at IS NULL").
 		Order("photos.taken_at DESC").
 		First(&file).Error; err != nil {
 		return file, err
@@ -85,6 +85,13 @@ func UpdateAlbumDates() error {
 			 GROUP BY photo_path) AS p ON albums.album_path = p.photo_path
 		SET albums.album_year = YEAR(taken_max), albums.album_month = MONTH(taken_max), albums.album_day = DAY(taken_max)
 		WHERE albums.album_type = 'folder' AND albums.album_path IS NOT NULL AND p.taken_max IS NOT NULL`).Error
+	case Postgres:
+		return UnscopedDb().Exec(`UPDATE albums
+		SET album_year = EXTRACT(YEAR FROM p.taken_max), album_month = EXTRACT(MONTH FROM p.taken_max), album_day = EXTRACT(DAY FROM p.taken_max)
+		FROM (SELECT photo_path, MAX(taken_at_local) AS taken_max
+			 FROM photos WHERE taken_src = 'meta' AND photos.photo_quality >= 3 AND photos.deleted_at IS NULL
+			 GROUP BY photo_path) AS p
+		WHERE albums.album_path = p.photo_path AND albums.album_type = 'folder' AND p.taken_max IS NOT NULL`).Error
 	default:
 		return nil
 	}
@@ -97,7 +104,7 @@ func UpdateMissingAlbumEntries() error {
 
 	switch DbDialect() {
 	default:
-		return UnscopedDb().Exec(`UPDATE photos_albums SET missing = 1 WHERE photo_uid IN
+		return UnscopedDb().Exec(`UPDATE photos_albums SET missing = TRUE WHERE photo_uid IN
 		(SELECT photo_uid FROM photos WHERE deleted_at IS NOT NULL OR photo_quality < 0)`).Error
 	}
 }
@@ -106,7 +113,7 @@ func UpdateMissingAlbumEntries() error {
 func AlbumEntryFound(uid string) error {
 	switch DbDialect() {
 	default:
-		return UnscopedDb().Exec(`UPDATE photos_albums SET missing = 0 WHERE photo_uid = ?`, uid).Error
+		return UnscopedDb().Exec(`UPDATE photos_albums SET missing = FALSE WHERE photo_uid = ?`, uid).Error
 	}
 }
 
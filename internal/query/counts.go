@@ -34,8 +34,8 @@ func (c *Counts) Refresh() {
 		Take(c)
 
 	Db().Table("photos").
-		Select("SUM(photo_type = 'video' AND photo_quality >= 0 AND photo_private = 0) AS videos, SUM(photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = 0) AS review, SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw','live') AND photo_private = 0 AND photo_quality >= 0) AS photos, SUM(photo_favorite = 1 AND photo_quality >= 0) AS favorites, SUM(photo_private = 1 AND photo_quality >= 0) AS private").
-		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+		Select("SUM(CASE WHEN photo_type = 'video' AND photo_quality >= 0 AND photo_private = FALSE THEN 1 ELSE 0 END) AS videos, SUM(CASE WHEN photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = FALSE THEN 1 ELSE 0 END) AS review, SUM(CASE WHEN photo_quality = -1 THEN 1 ELSE 0 END) AS hidden, SUM(CASE WHEN photo_type IN ('image','raw','live') AND photo_private = FALSE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS photos, SUM(CASE WHEN photo_favorite = TRUE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS favorites, SUM(CASE WHEN photo_private = TRUE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS private").
+		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE AND (file_missing = TRUE OR file_error <> ''))").
 		Where("deleted_at IS NULL").
 		Take(c)
 
@@ -43,11 +43,11 @@ func (c *Counts) Refresh() {
 		Select("MAX(photo_count) as label_max_photos, COUNT(*) AS labels").
 		Where("photo_count > 0").
 		Where("deleted_at IS NULL").
-		Where("(label_priority >= 0 || label_favorite = 1)").
+		Where("(label_priority >= 0 OR label_favorite = TRUE)").
 		Take(c)
 
 	Db().Table("albums").
-		Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, SUM(album_type = ?) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumFolder).
+		Select("SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS albums, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS moments, SUM(CASE WHEN album_type = ? THEN 1 ELSE 0 END) AS folders", entity.AlbumDefault, entity.AlbumMoment, entity.AlbumFolder).
 		Where("deleted_at IS NULL").
 		Take(c)
 
@@ -60,13 +60,13 @@ func (c *Counts) Refresh() {
 		Take(c)
 
 	Db().Table("places").
-		Select("SUM(photo_count > 0) AS places").
+		Select("SUM(CASE WHEN photo_count > 0 THEN 1 ELSE 0 END) AS places").
 		Where("id != 'zz'").
 		Take(c)
 
 	Db().Table("photos").
-		Select("SUM(photo_type = 'video' AND photo_quality >= 0 AND photo_private = 0) AS videos, SUM(photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = 0) AS review, SUM(photo_quality = -1) AS hidden, SUM(photo_type IN ('image','raw','live') AND photo_private = 0 AND photo_quality >= 0) AS photos, SUM(photo_favorite = 1 AND photo_quality >= 0) AS favorites, SUM(photo_private = 1 AND photo_quality >= 0) AS private").
-		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND (file_missing = 1 OR file_error <> ''))").
+		Select("SUM(CASE WHEN photo_type = 'video' AND photo_quality >= 0 AND photo_private = FALSE THEN 1 ELSE 0 END) AS videos, SUM(CASE WHEN photo_type IN ('image','raw','live') AND photo_quality < 3 AND photo_quality >= 0 AND photo_private = FALSE THEN 1 ELSE 0 END) AS review, SUM(CASE WHEN photo_quality = -1 THEN 1 ELSE 0 END) AS hidden, SUM(CASE WHEN photo_type IN ('image','raw','live') AND photo_private = FALSE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS photos, SUM(CASE WHEN photo_favorite = TRUE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS favorites, SUM(CASE WHEN photo_private = TRUE AND photo_quality >= 0 THEN 1 ELSE 0 END) AS private").
+		Where("photos.id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE AND (file_missing = TRUE OR file_error <> ''))").
 		Where("deleted_at IS NULL").
 		Take(c)
 }
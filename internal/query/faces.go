@@ -49,8 +49,8 @@ func MatchFaceMarkers() (affected int64, err error) {
 	}
 
 	for _, f := range faces {
-		if res := Db().Exec(`update markers set subj_uid=?, marker_review=0 
-					where marker_invalid=0 and face_id=? and subj_src=? and subj_uid<>?`,
+		if res := Db().Exec(`update markers set subj_uid=?, marker_review=FALSE 
+					where marker_invalid=FALSE and face_id=? and subj_src=? and subj_uid<>?`,
 			f.SubjUID, f.ID, entity.SrcAuto, f.SubjUID); res.Error != nil {
 			return affected, err
 		} else if res.RowsAffected > 0 {
@@ -107,7 +107,7 @@ func CountNewFaceMarkers(size, score int, since time.Time) (n int) {
 
 	q := Db().Model(&entity.Markers{}).
 		Where("marker_type = ?", entity.MarkerFace).
-		Where("face_id = '' AND marker_invalid = 0 AND embeddings_json <> ''")
+		Where("face_id = '' AND marker_invalid = FALSE AND embeddings_json <> ''")
 
 	if size > 0 {
 		q = q.Where("size >= ?", size)
@@ -43,7 +43,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 	switch DbDialect() {
 	case MySQL:
 		concat = "CONCAT(a.path, '/%')"
-	case SQLite3:
+	case SQLite3, Postgres:
 		concat = "a.path || '/%'"
 	default:
 		return results, fmt.Errorf("unknown sql dialect: %s", DbDialect())
@@ -55,7 +55,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 		OR photos.photo_path IN (
 			SELECT a.path FROM folders a WHERE a.folder_uid IN (?) UNION
 			SELECT b.path FROM folders a JOIN folders b ON b.path LIKE %s WHERE a.folder_uid IN (?))
-		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid IN (?))
+		OR photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = FALSE AND album_uid IN (?))
 		OR files.file_uid IN (SELECT file_uid FROM %s m WHERE m.subj_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON pl.label_id = l.id AND l.deleted_at IS NULL WHERE l.label_uid IN (?))
 		OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN categories c ON c.label_id = pl.label_id JOIN labels lc ON lc.id = c.category_id AND lc.deleted_at IS NULL WHERE lc.label_uid IN (?))`,
@@ -65,7 +65,7 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 		Select("files.*").
 		Joins("JOIN photos ON photos.id = files.photo_id").
 		Where("photos.deleted_at IS NULL").
-		Where("files.file_missing = 0").
+		Where("files.file_missing = FALSE").
 		Where(where, f.Photos, f.Places, f.Files, f.Files, f.Files, f.Albums, f.Subjects, f.Labels, f.Labels).
 		Group("files.id")
 
@@ -74,15 +74,15 @@ func SelectedFiles(f form.Selection, o FileSelection) (results entity.Files, err
 	}
 
 	if o.PrimaryOnly {
-		s = s.Where("file_primary = 1")
+		s = s.Where("file_primary = TRUE")
 	}
 
 	if !o.Sidecar {
-		s = s.Where("file_sidecar = 0")
+		s = s.Where("file_sidecar = FALSE")
 	}
 
 	if !o.Video {
-		s = s.Where("file_video = 0")
+		s = s.Where("file_video = FALSE")
 	}
 
 	if o.SizeLimit > 0 {
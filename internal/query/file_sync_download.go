@@ -7,8 +7,8 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
-// SetDownloadFileID updates the local file id for remote downloads.
-func SetDownloadFileID(filename string, fileId uint) error {
+// SetDownloadFileID updates the local file id and hash for remote downloads.
+func SetDownloadFileID(filename string, fileId uint, fileHash string) error {
 	if len(filename) == 0 {
 		return errors.New("sync: cannot update, filename empty")
 	}
@@ -19,8 +19,8 @@ func SetDownloadFileID(filename string, fileId uint) error {
 	}
 
 	result := Db().Model(entity.FileSync{}).
-		Where("remote_name = ? AND status = ? AND file_id = 0", filename, entity.FileSyncDownloaded).
-		Update("file_id", fileId)
+		Where("remote_name = ? AND status = ? AND (file_id = 0 OR file_id = ?)", filename, entity.FileSyncDownloaded, fileId).
+		Updates(entity.Values{"file_id": fileId, "file_hash": fileHash})
 
 	return result.Error
 }
@@ -8,13 +8,13 @@ import (
 
 func TestSetDownloadFileID(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		err := SetDownloadFileID("exampleFileName.jpg", 1000000)
+		err := SetDownloadFileID("exampleFileName.jpg", 1000000, "2cad9168fa6acc5c5c2965ddf6ec465ca42fd818")
 		if err != nil {
 			t.Fatal(err)
 		}
 	})
 	t.Run("filename empty", func(t *testing.T) {
-		err := SetDownloadFileID("", 1000000)
+		err := SetDownloadFileID("", 1000000, "")
 		if err == nil {
 			t.Fatal()
 		}
@@ -30,3 +30,23 @@ func FileSyncs(accountId uint, status string, limit int) (result []entity.FileSy
 
 	return result, nil
 }
+
+// FileSyncsChanged returns synced files of an account that have been modified locally and must be uploaded again.
+func FileSyncsChanged(accountId uint, limit int) (result []entity.FileSync, err error) {
+	s := Db().Table("files_sync").Select("files_sync.*").
+		Joins("JOIN files ON files.id = files_sync.file_id AND files.file_missing = FALSE").
+		Where("files_sync.account_id = ?", accountId).
+		Where("files_sync.status = ? OR (files_sync.status IN (?) AND files_sync.file_hash <> '' AND files_sync.file_hash <> files.file_hash)",
+			entity.FileSyncChanged, []string{entity.FileSyncUploaded, entity.FileSyncDownloaded}).
+		Order("files_sync.remote_name ASC")
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(0)
+	}
+
+	if err := s.Preload("File").Find(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
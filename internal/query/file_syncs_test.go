@@ -31,3 +31,26 @@ func TestFileSyncs(t *testing.T) {
 		}
 	})
 }
+
+func TestFileSyncsChanged(t *testing.T) {
+	t.Run("Changed", func(t *testing.T) {
+		r, err := FileSyncsChanged(uint(1000001), 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, r, 1)
+		assert.Equal(t, "/20200706-092527-Landscape-Changed-2020.jpg", r[0].RemoteName)
+		assert.NotNil(t, r[0].File)
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		r, err := FileSyncsChanged(uint(1000000), 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, r)
+	})
+}
@@ -0,0 +1,54 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// OwnedUIDs returns the UIDs of the specified photos, albums, or labels that belong to the user.
+// Items without an owner are included as well if library is true.
+func OwnedUIDs(tableName, uidCol string, uids []string, ownerUID string, library bool) (result []string, err error) {
+	if len(uids) == 0 || ownerUID == "" {
+		return []string{}, nil
+	}
+
+	stmt := UnscopedDb().Table(tableName).Where(uidCol+" IN (?)", uids)
+
+	if library {
+		stmt = stmt.Where("owner_uid IN (?)", []string{ownerUID, ""})
+	} else {
+		stmt = stmt.Where("owner_uid = ?", ownerUID)
+	}
+
+	err = stmt.Pluck(uidCol, &result).Error
+
+	return result, err
+}
+
+// OwnedPhotoUIDs returns the UIDs of the specified photos that the user may change.
+func OwnedPhotoUIDs(uids []string, ownerUID string, library bool) ([]string, error) {
+	return OwnedUIDs("photos", "photo_uid", uids, ownerUID, library)
+}
+
+// OwnedAlbumUIDs returns the UIDs of the specified albums that the user may change.
+func OwnedAlbumUIDs(uids []string, ownerUID string, library bool) ([]string, error) {
+	return OwnedUIDs("albums", "album_uid", uids, ownerUID, library)
+}
+
+// OwnedLabelUIDs returns the UIDs of the specified labels that the user may change.
+func OwnedLabelUIDs(uids []string, ownerUID string, library bool) ([]string, error) {
+	return OwnedUIDs("labels", "label_uid", uids, ownerUID, library)
+}
+
+// ViewablePhotoUIDs returns the UIDs of the specified photos that are visible in the search scope.
+func ViewablePhotoUIDs(uids []string, scope form.SearchScope) (result []string, err error) {
+	if len(uids) == 0 {
+		return []string{}, nil
+	}
+
+	stmt := search.ScopePhotos(UnscopedDb().Table("photos").Where("photos.photo_uid IN (?)", uids), scope)
+
+	err = stmt.Pluck("photos.photo_uid", &result).Error
+
+	return result, err
+}
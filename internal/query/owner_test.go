@@ -0,0 +1,92 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestOwnedPhotoUIDs(t *testing.T) {
+	uids := []string{"pt9jtdre2lvl0y18", "pt9jtdre2lvl0yh7"}
+
+	t.Run("Owner", func(t *testing.T) {
+		result, err := OwnedPhotoUIDs(uids, "uqxc08w3d0ej2283", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0y18"}, result)
+	})
+	t.Run("Library", func(t *testing.T) {
+		result, err := OwnedPhotoUIDs(uids, "uqxc08w3d0ej2283", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, uids, result)
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		result, err := OwnedPhotoUIDs(uids, "uqxetse3cy5eo9z2", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+	t.Run("NoOwner", func(t *testing.T) {
+		result, err := OwnedPhotoUIDs(uids, "", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestOwnedAlbumUIDs(t *testing.T) {
+	result, err := OwnedAlbumUIDs([]string{"at9lxuqxpogaaba9", "at9lxuqxpogaaba8"}, "uqxc08w3d0ej2283", false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"at9lxuqxpogaaba9"}, result)
+}
+
+func TestViewablePhotoUIDs(t *testing.T) {
+	uids := []string{"pt9jtdre2lvl0y18", "pt9jtdre2lvl0y12"}
+
+	t.Run("Unrestricted", func(t *testing.T) {
+		result, err := ViewablePhotoUIDs(uids, form.SearchScope{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, uids, result)
+	})
+	t.Run("Library", func(t *testing.T) {
+		result, err := ViewablePhotoUIDs(uids, form.SearchScope{Owner: "uqxetse3cy5eo9z2", Library: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, result, "pt9jtdre2lvl0y12")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		result, err := ViewablePhotoUIDs(nil, form.SearchScope{Owner: "uqxetse3cy5eo9z2"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
@@ -76,7 +76,7 @@ func PhotoPreloadByUID(photoUID string) (photo entity.Photo, err error) {
 func PhotosMissing(limit int, offset int) (entities entity.Photos, err error) {
 	err = Db().
 		Select("photos.*").
-		Where("id NOT IN (SELECT photo_id FROM files WHERE file_missing = 0 AND file_root = '/' AND deleted_at IS NULL)").
+		Where("id NOT IN (SELECT photo_id FROM files WHERE file_missing = FALSE AND file_root = '/' AND deleted_at IS NULL)").
 		Where("photos.photo_type <> ?", entity.TypeText).
 		Group("photos.id").
 		Limit(limit).Offset(offset).Find(&entities).Error
@@ -126,7 +126,7 @@ func FixPrimaries() error {
 	var photos entity.Photos
 
 	// Remove primary file flag from broken or missing files.
-	if err := Db().Exec(`update files set file_primary=0 where file_error <>'' or file_missing=1`).Error; err != nil {
+	if err := Db().Exec(`update files set file_primary=FALSE where file_error <>'' or file_missing=TRUE`).Error; err != nil {
 		return err
 	}
 
@@ -134,7 +134,7 @@ func FixPrimaries() error {
 	if err := UnscopedDb().
 		Raw(`SELECT * FROM photos 
 			WHERE deleted_at IS NULL 
-			AND id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1)`).
+			AND id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE)`).
 		Find(&photos).Error; err != nil {
 		return err
 	}
@@ -166,11 +166,11 @@ func FlagHiddenPhotos() error {
 	start := time.Now()
 
 	res := Db().Table("photos").
-		Where("id NOT IN (SELECT photo_id FROM files WHERE file_primary = 1 AND file_missing = 0 AND file_error = '' AND deleted_at IS NULL)").
+		Where("id NOT IN (SELECT photo_id FROM files WHERE file_primary = TRUE AND file_missing = FALSE AND file_error = '' AND deleted_at IS NULL)").
 		Update("photo_quality", -1)
 
 	switch DbDialect() {
-	case MySQL:
+	case MySQL, Postgres:
 		if res.RowsAffected > 0 {
 			log.Infof("index: flagged %s as hidden or missing [%s]", english.Plural(int(res.RowsAffected), "photo", "photos"), time.Since(start))
 		}
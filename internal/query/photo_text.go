@@ -0,0 +1,15 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoTextsByUID returns the text regions found in a photo, sorted by position.
+func PhotoTextsByUID(photoUID string) (result entity.PhotoTexts, err error) {
+	err = UnscopedDb().
+		Where("photo_uid = ?", photoUID).
+		Order("y, x, id").
+		Find(&result).Error
+
+	return result, err
+}
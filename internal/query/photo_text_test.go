@@ -0,0 +1,30 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoTextsByUID(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		result, err := PhotoTextsByUID("pt9jtdre2lvl0yh7")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, "Welcome to Berlin", result[0].TextContent)
+		assert.Equal(t, "B PP 2022", result[1].TextContent)
+	})
+	t.Run("none", func(t *testing.T) {
+		result, err := PhotoTextsByUID("pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
@@ -0,0 +1,63 @@
+package remote
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/remote/local"
+	"github.com/photoprism/photoprism/internal/remote/s3"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Client is implemented by all remote service clients that support file synchronization.
+type Client interface {
+	Files(dir string) (fs.FileInfos, error)
+	Directories(root string, recursive bool, timeout time.Duration) (fs.FileInfos, error)
+	Download(from, to string, force bool) error
+	DownloadDir(from, to string, recursive, force bool) []error
+	CreateDir(dir string) error
+	Upload(from, to string) error
+	Delete(path string) error
+}
+
+// Supported tests if clients are available for the service type.
+func Supported(serviceType string) bool {
+	switch serviceType {
+	case ServiceWebDAV, ServiceS3, ServiceSFTP, ServiceLocal:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewClient returns a client for the service type and URL of the account.
+func NewClient(a Account, timeout webdav.Timeout) (Client, error) {
+	switch a.AccType {
+	case ServiceWebDAV:
+		return webdav.New(a.AccURL, a.AccUser, a.AccPass, timeout), nil
+	case ServiceS3:
+		return s3.New(a.AccURL, a.AccKey, a.AccUser, a.AccPass, webdav.Durations[timeout])
+	case ServiceSFTP:
+		if client, err := sftp.New(a.AccURL, a.AccKey, a.AccUser, a.AccPass, a.AccHostKey, webdav.Durations[timeout]); err != nil {
+			return nil, err
+		} else {
+			return client, nil
+		}
+	case ServiceLocal:
+		return local.New(a.AccURL)
+	default:
+		return nil, fmt.Errorf("%s accounts are not supported", a.AccType)
+	}
+}
+
+// CloseClient closes the connection of clients that keep one open, e.g. for SFTP.
+func CloseClient(client Client) error {
+	if c, ok := client.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
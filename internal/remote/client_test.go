@@ -0,0 +1,38 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported(ServiceWebDAV))
+	assert.True(t, Supported(ServiceS3))
+	assert.True(t, Supported(ServiceSFTP))
+	assert.True(t, Supported(ServiceLocal))
+	assert.False(t, Supported(ServiceFacebook))
+	assert.False(t, Supported(""))
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("Local", func(t *testing.T) {
+		client, err := NewClient(Account{AccType: ServiceLocal, AccURL: t.TempDir()}, "")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, client)
+		assert.NoError(t, CloseClient(client))
+	})
+	t.Run("WebDAV", func(t *testing.T) {
+		client, err := NewClient(Account{AccType: ServiceWebDAV, AccURL: "http://dummy-webdav/"}, "")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		client, err := NewClient(Account{AccType: ServiceFacebook}, "")
+
+		assert.EqualError(t, err, "facebook accounts are not supported")
+		assert.Nil(t, client)
+	})
+}
@@ -3,18 +3,23 @@ package remote
 import (
 	"errors"
 	"net/url"
+	"path/filepath"
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/remote/local"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 type Account struct {
-	AccName string
-	AccURL  string
-	AccType string
-	AccKey  string
-	AccUser string
-	AccPass string
+	AccName    string
+	AccURL     string
+	AccType    string
+	AccKey     string
+	AccUser    string
+	AccPass    string
+	AccHostKey string
 }
 
 func Discover(rawUrl, user, pass string) (result Account, err error) {
@@ -22,6 +27,11 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		return result, errors.New("service URL is empty")
 	}
 
+	// Local folders, e.g. on a mounted USB disk.
+	if strings.HasPrefix(rawUrl, "file:") || strings.HasPrefix(rawUrl, "/") {
+		return discoverLocal(rawUrl)
+	}
+
 	u, err := url.Parse(rawUrl)
 
 	if err != nil {
@@ -46,6 +56,11 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		u.User = url.UserPassword(result.AccUser, result.AccPass)
 	}
 
+	// SSH servers don't speak HTTP, so they are probed separately.
+	if u.Scheme == "sftp" || u.Scheme == "ssh" {
+		return discoverSftp(u, result)
+	}
+
 	// Set default scheme
 	if u.Scheme == "" {
 		u.Scheme = "https"
@@ -74,3 +89,48 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 
 	return result, errors.New("could not connect")
 }
+
+// discoverLocal checks if a local folder exists.
+func discoverLocal(rawUrl string) (result Account, err error) {
+	root, err := local.Root(rawUrl)
+
+	if err != nil {
+		return result, err
+	} else if !fs.PathExists(root) {
+		return result, errors.New("folder not found")
+	}
+
+	result.AccName = filepath.Base(root)
+	result.AccType = ServiceLocal
+	result.AccURL = (&url.URL{Scheme: "file", Path: root}).String()
+
+	return result, nil
+}
+
+// discoverSftp checks if an SSH server is listening on the host and port of the service URL
+// and pins its host key, so that it can be verified on subsequent connections.
+func discoverSftp(u *url.URL, result Account) (Account, error) {
+	if !SshOk(sftp.Addr(u)) {
+		return result, errors.New("could not connect")
+	}
+
+	u.Scheme = "sftp"
+	u.User = nil
+
+	if hostKey, err := sftp.HostKey(u.String()); err != nil {
+		return result, err
+	} else {
+		result.AccHostKey = hostKey
+	}
+
+	if w := txt.Keywords(u.Hostname()); len(w) > 0 {
+		result.AccName = strings.Title(w[0])
+	} else {
+		result.AccName = u.Hostname()
+	}
+
+	result.AccType = ServiceSFTP
+	result.AccURL = u.String()
+
+	return result, nil
+}
@@ -1,9 +1,15 @@
 package remote
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
 )
 
 func TestDiscover(t *testing.T) {
@@ -69,4 +75,87 @@ func TestDiscover(t *testing.T) {
 		assert.Equal(t, "", r.AccUser)
 		assert.Equal(t, "", r.AccPass)
 	})
+	t.Run("local", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "Backup")
+
+		if err := os.Mkdir(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := Discover(dir, "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Backup", r.AccName)
+		assert.Equal(t, "local", r.AccType)
+		assert.Equal(t, "file://"+dir, r.AccURL)
+
+		r, err = Discover("file://"+dir, "", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "local", r.AccType)
+	})
+	t.Run("local not found", func(t *testing.T) {
+		_, err := Discover("/xxx/missing", "", "")
+
+		assert.EqualError(t, err, "folder not found")
+	})
+	t.Run("sftp", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer l.Close()
+
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := ssh.NewSignerFromKey(key)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		config := &ssh.ServerConfig{NoClientAuth: true}
+		config.AddHostKey(signer)
+
+		go func() {
+			for {
+				conn, err := l.Accept()
+
+				if err != nil {
+					return
+				}
+
+				go func() {
+					_, _, _, _ = ssh.NewServerConn(conn, config)
+					_ = conn.Close()
+				}()
+			}
+		}()
+
+		r, err := Discover("ssh://admin@"+l.Addr().String()+"/photos", "", "photoprism")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sftp", r.AccType)
+		assert.Equal(t, "sftp://"+l.Addr().String()+"/photos", r.AccURL)
+		assert.Equal(t, "admin", r.AccUser)
+		assert.Equal(t, "photoprism", r.AccPass)
+		assert.Equal(t, ssh.FingerprintSHA256(signer.PublicKey()), r.AccHostKey)
+	})
+	t.Run("sftp not listening", func(t *testing.T) {
+		_, err := Discover("sftp://127.0.0.1:1/photos", "", "")
+
+		assert.EqualError(t, err, "could not connect")
+	})
 }
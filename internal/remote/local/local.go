@@ -0,0 +1,237 @@
+/*
+Package local implements syncing with folders on the local file system, e.g. a mounted USB disk.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package local
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Global log instance.
+var log = event.Log
+
+// Client represents a client for a local folder, remote file names are relative to it.
+type Client struct {
+	root string
+}
+
+// Root returns the folder path of a "file://" service URL or of a plain absolute path.
+func Root(rawUrl string) (string, error) {
+	if strings.HasPrefix(rawUrl, "file:") {
+		u, err := url.Parse(rawUrl)
+
+		if err != nil {
+			return "", err
+		}
+
+		rawUrl = u.Path
+	}
+
+	if !filepath.IsAbs(rawUrl) {
+		return "", fmt.Errorf("local: %s is not an absolute path", sanitize.Log(rawUrl))
+	}
+
+	return filepath.Clean(rawUrl), nil
+}
+
+// New creates a new client for an existing local folder.
+func New(rawUrl string) (Client, error) {
+	root, err := Root(rawUrl)
+
+	if err != nil {
+		return Client{}, err
+	} else if !fs.PathExists(root) {
+		return Client{}, fmt.Errorf("local: folder %s not found", sanitize.Log(root))
+	}
+
+	return Client{root: root}, nil
+}
+
+// abs returns the absolute path of a remote file name, which cannot be outside the folder.
+func (c Client) abs(name string) string {
+	return filepath.Join(c.root, filepath.Clean(string(os.PathSeparator)+name))
+}
+
+// readDir returns the folder contents, sorted by name.
+func (c Client) readDir(dir string) (result fs.FileInfos, err error) {
+	entries, err := os.ReadDir(c.abs(dir))
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, entry := range entries {
+		// Skip hidden files and folders.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		info, err := entry.Info()
+
+		if err != nil {
+			continue
+		}
+
+		result = append(result, fs.NewFileInfo(info, dir))
+	}
+
+	return result, nil
+}
+
+// Files returns all files in a directory.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	infos, err := c.readDir(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range infos {
+		if !info.Dir {
+			result = append(result, info)
+		}
+	}
+
+	return result, nil
+}
+
+// Directories returns all subdirectories in a path.
+func (c Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	infos, err := c.readDir(root)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range infos {
+		if !info.Dir {
+			continue
+		}
+
+		result = append(result, info)
+
+		if recursive {
+			subDirs, err := c.Directories(info.Abs, true, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Download copies a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	// Skip if file already exists.
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("local: download skipped, %s already exists", sanitize.Log(to))
+	} else if err == nil {
+		_ = os.Remove(to)
+	}
+
+	if err := fs.Copy(c.abs(from), to); err != nil {
+		log.Errorf("local: %s", sanitize.Log(err.Error()))
+		return fmt.Errorf("local: failed copying %s", sanitize.Log(from))
+	}
+
+	return nil
+}
+
+// DownloadDir copies all files from a remote to a local directory.
+func (c Client) DownloadDir(from, to string, recursive, force bool) (errs []error) {
+	files, err := c.Files(from)
+
+	if err != nil {
+		return append(errs, err)
+	}
+
+	for _, file := range files {
+		dest := to + string(os.PathSeparator) + file.Abs
+
+		if _, err = os.Stat(dest); err == nil {
+			// File already exists.
+			msg := fmt.Errorf("local: %s already exists", sanitize.Log(dest))
+			log.Warn(msg)
+			errs = append(errs, msg)
+			continue
+		}
+
+		if err = c.Download(file.Abs, dest, force); err != nil {
+			// Failed to copy file.
+			errs = append(errs, err)
+			log.Error(err)
+			continue
+		}
+	}
+
+	if !recursive {
+		return errs
+	}
+
+	dirs, err := c.Directories(from, false, 0)
+
+	for _, dir := range dirs {
+		errs = append(errs, c.DownloadDir(dir.Abs, to, true, force)...)
+	}
+
+	return errs
+}
+
+// CreateDir recursively creates directories if they don't exist.
+func (c Client) CreateDir(dir string) error {
+	return os.MkdirAll(c.abs(dir), os.ModePerm)
+}
+
+// Upload copies a single file to the folder, replacing an existing file.
+func (c Client) Upload(from, to string) error {
+	dest := c.abs(to)
+
+	if err := os.Remove(dest); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return fs.Copy(from, dest)
+}
+
+// Delete deletes a single file or directory.
+func (c Client) Delete(name string) error {
+	if p := c.abs(name); p == c.root {
+		return fmt.Errorf("local: cannot delete sync folder")
+	} else {
+		return os.RemoveAll(p)
+	}
+}
@@ -0,0 +1,112 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testFolder(t *testing.T) string {
+	dir := t.TempDir()
+
+	for name, data := range map[string]string{
+		"2021/cat.jpg":       "cat",
+		"2021/dog.jpg":       "dog",
+		"2022/Holiday/a.jpg": "sea",
+		"readme.txt":         "hello",
+		".hidden/x.jpg":      "x",
+	} {
+		fileName := filepath.Join(dir, name)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		} else if err := os.WriteFile(fileName, []byte(data), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestRoot(t *testing.T) {
+	root, err := Root("file:///mnt/usb/backup/")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/mnt/usb/backup", root)
+
+	root, err = Root("/mnt/usb")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/mnt/usb", root)
+
+	_, err = Root("backup")
+
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("/foo/bar/missing")
+	assert.Error(t, err)
+
+	c, err := New("file://" + t.TempDir())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, c.root)
+}
+
+func TestClient(t *testing.T) {
+	dir := testFolder(t)
+	c, err := New(dir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Files", func(t *testing.T) {
+		files, err := c.Files("/2021")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2021/cat.jpg", "/2021/dog.jpg"}, files.Abs())
+	})
+	t.Run("Directories", func(t *testing.T) {
+		dirs, err := c.Directories("/", true, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2021", "/2022", "/2022/Holiday"}, dirs.Abs())
+	})
+	t.Run("Upload", func(t *testing.T) {
+		src := filepath.Join(t.TempDir(), "bird.jpg")
+
+		if err := os.WriteFile(src, []byte("bird"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, c.CreateDir("/2023"))
+		assert.NoError(t, c.Upload(src, "/2023/bird.jpg"))
+		assert.FileExists(t, filepath.Join(dir, "2023", "bird.jpg"))
+	})
+	t.Run("Download", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "cat.jpg")
+
+		assert.NoError(t, c.Download("/2021/cat.jpg", dest, false))
+		assert.Error(t, c.Download("/2021/cat.jpg", dest, false))
+		assert.NoError(t, c.Download("/2021/cat.jpg", dest, true))
+		assert.Error(t, c.Download("/../../etc/missing", dest+".missing", false))
+	})
+	t.Run("DownloadDir", func(t *testing.T) {
+		assert.Empty(t, c.DownloadDir("/2022", t.TempDir(), true, false))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, c.Delete("/2021/dog.jpg"))
+		assert.NoFileExists(t, filepath.Join(dir, "2021", "dog.jpg"))
+		assert.Error(t, c.Delete("/"))
+		assert.Error(t, c.Delete("/.."))
+	})
+}
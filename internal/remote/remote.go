@@ -28,12 +28,18 @@ Additional information can be found in our Developer Guide:
 package remote
 
 import (
+	"bufio"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
 const (
 	ServiceWebDAV    = "webdav"
+	ServiceS3        = "s3"
+	ServiceSFTP      = "sftp"
+	ServiceLocal     = "local"
 	ServiceFacebook  = "facebook"
 	ServiceTwitter   = "twitter"
 	ServiceFlickr    = "flickr"
@@ -71,3 +77,23 @@ func HttpOk(method, rawUrl string) bool {
 
 	return false
 }
+
+// SshOk tests if an SSH server is listening on the specified address.
+func SshOk(addr string) bool {
+	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+
+	if err != nil {
+		return false
+	}
+
+	defer conn.Close()
+
+	// Servers send their identification string first, see RFC 4253.
+	if err = conn.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return false
+	}
+
+	banner, err := bufio.NewReader(conn).ReadString('\n')
+
+	return err == nil && strings.HasPrefix(banner, "SSH-")
+}
@@ -0,0 +1,443 @@
+/*
+Package s3 implements syncing with S3-compatible object storage, e.g. Amazon S3 or MinIO.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Global log instance.
+var log = event.Log
+
+// DefaultRegion is used if no region is configured and none can be derived from the endpoint.
+const DefaultRegion = "us-east-1"
+
+// MaxRequestDuration is the maximum request duration e.g. for recursive retrieval of large bucket structures.
+const MaxRequestDuration = 30 * time.Minute
+
+// Client represents a client for a bucket in S3-compatible object storage. Objects are addressed
+// path-style, so the service URL must contain the bucket name and optionally a key prefix,
+// e.g. "https://s3.eu-central-1.amazonaws.com/bucket/photos".
+type Client struct {
+	endpoint  *url.URL
+	bucket    string
+	prefix    string
+	region    string
+	accessKey string
+	secretKey string
+	timeout   time.Duration
+	client    *http.Client
+}
+
+// New creates a new S3 client for the bucket in the service URL.
+func New(rawUrl, region, accessKey, secretKey string, timeout time.Duration) (Client, error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return Client{}, err
+	} else if u.Host == "" {
+		return Client{}, fmt.Errorf("s3: invalid service url")
+	}
+
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+
+	// The first path segment is the bucket name, the rest an optional key prefix.
+	parts := strings.SplitN(strings.Trim(u.Path, "/"), "/", 2)
+
+	if parts[0] == "" {
+		return Client{}, fmt.Errorf("s3: bucket name missing in service url")
+	}
+
+	result := Client{
+		endpoint:  &url.URL{Scheme: u.Scheme, Host: u.Host},
+		bucket:    parts[0],
+		region:    Region(u.Host, region),
+		accessKey: accessKey,
+		secretKey: secretKey,
+		timeout:   timeout,
+		client:    &http.Client{},
+	}
+
+	if len(parts) > 1 && parts[1] != "" {
+		result.prefix = strings.Trim(parts[1], "/") + "/"
+	}
+
+	return result, nil
+}
+
+// Region returns the configured region, or the region that is part of an AWS endpoint host name.
+func Region(host, region string) string {
+	if region != "" {
+		return region
+	}
+
+	// For example, s3.eu-central-1.amazonaws.com or s3-eu-west-1.amazonaws.com.
+	if h := strings.Split(host, ":")[0]; strings.HasSuffix(h, ".amazonaws.com") {
+		s := strings.TrimSuffix(h, ".amazonaws.com")
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "s3."), "s3-")
+
+		if s != "" && s != "s3" && !strings.Contains(s, ".") {
+			return s
+		}
+	}
+
+	return DefaultRegion
+}
+
+// key returns the object key for a remote file name.
+func (c Client) key(name string) string {
+	return c.prefix + strings.TrimLeft(name, "/")
+}
+
+// name returns the remote file name for an object key.
+func (c Client) name(key string) string {
+	return "/" + strings.TrimPrefix(key, c.prefix)
+}
+
+// objectUrl returns the URL of an object or of the bucket if the key is empty.
+func (c Client) objectUrl(key string, query url.Values) *url.URL {
+	u := *c.endpoint
+	u.Path = "/" + c.bucket
+
+	if key != "" {
+		u.Path += "/" + key
+	}
+
+	u.RawPath = encodePath(u.Path)
+	u.RawQuery = query.Encode()
+
+	return &u
+}
+
+// do sends a signed request and returns the response if it was successful.
+func (c Client) do(method string, u *url.URL, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequest(method, u.String(), body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		req.ContentLength = size
+	}
+
+	c.sign(req, time.Now().UTC())
+
+	resp, err := c.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+
+	return resp, nil
+}
+
+// responseError returns the error message of an unsuccessful response.
+func responseError(resp *http.Response) error {
+	var result struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+
+	if data, err := io.ReadAll(io.LimitReader(resp.Body, 4096)); err == nil && xml.Unmarshal(data, &result) == nil && result.Code != "" {
+		return fmt.Errorf("s3: %s (%s)", strings.ToLower(result.Code), result.Message)
+	}
+
+	return fmt.Errorf("s3: request failed with status %d", resp.StatusCode)
+}
+
+// listResult represents a ListObjectsV2 response.
+type listResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+}
+
+// list returns the objects and common prefixes directly below a directory.
+func (c Client) list(dir string) (files, dirs fs.FileInfos, err error) {
+	prefix := c.key(dir)
+
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	token := ""
+
+	for {
+		q := url.Values{}
+		q.Set("list-type", "2")
+		q.Set("delimiter", "/")
+		q.Set("prefix", prefix)
+
+		if token != "" {
+			q.Set("continuation-token", token)
+		}
+
+		resp, err := c.do(http.MethodGet, c.objectUrl("", q), nil, 0)
+
+		if err != nil {
+			return files, dirs, err
+		}
+
+		var result listResult
+
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+
+		if err != nil {
+			return files, dirs, err
+		}
+
+		for _, obj := range result.Contents {
+			// Skip folder placeholder objects.
+			if strings.HasSuffix(obj.Key, "/") {
+				continue
+			}
+
+			name := c.name(obj.Key)
+			files = append(files, fs.FileInfo{Name: path.Base(name), Abs: name, Size: obj.Size, Date: obj.LastModified})
+		}
+
+		for _, p := range result.CommonPrefixes {
+			name := strings.TrimSuffix(c.name(p.Prefix), "/")
+			dirs = append(dirs, fs.FileInfo{Name: path.Base(name), Abs: name, Dir: true})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return files, dirs, nil
+		}
+
+		token = result.NextContinuationToken
+	}
+}
+
+// Files returns all files in a directory.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("s3: %s (panic while listing files)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	result, _, err = c.list(dir)
+
+	return result, err
+}
+
+// Directories returns all subdirectories in a path.
+func (c Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	if timeout == 0 {
+		timeout = c.timeout
+	}
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if timeout > 0 && time.Now().Sub(start) >= timeout {
+		log.Warnf("s3: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	_, dirs, err := c.list(root)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, dir := range dirs {
+		result = append(result, dir)
+
+		if recursive && (timeout < time.Second || time.Now().Sub(start) < timeout) {
+			subDirs, err := c.fetchDirs(dir.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Download downloads a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	// Skip if file already exists.
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("s3: download skipped, %s already exists", sanitize.Log(to))
+	}
+
+	dir := path.Dir(to)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create local storage path.
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("s3: cannot create folder %s (%s)", sanitize.Log(dir), err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("s3: %s is not a folder", sanitize.Log(dir))
+	}
+
+	resp, err := c.do(http.MethodGet, c.objectUrl(c.key(from), nil), nil, 0)
+
+	if err != nil {
+		log.Errorf("s3: %s", sanitize.Log(err.Error()))
+		return fmt.Errorf("s3: failed downloading %s", sanitize.Log(from))
+	}
+
+	defer resp.Body.Close()
+
+	file, err := os.Create(to)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(file, resp.Body); err != nil {
+		_ = file.Close()
+		_ = os.Remove(to)
+		return fmt.Errorf("s3: failed downloading %s (%s)", sanitize.Log(from), err)
+	}
+
+	return file.Close()
+}
+
+// DownloadDir downloads all files from a remote to a local directory.
+func (c Client) DownloadDir(from, to string, recursive, force bool) (errs []error) {
+	files, err := c.Files(from)
+
+	if err != nil {
+		return append(errs, err)
+	}
+
+	for _, file := range files {
+		dest := to + string(os.PathSeparator) + file.Abs
+
+		if _, err = os.Stat(dest); err == nil {
+			// File already exists.
+			msg := fmt.Errorf("s3: %s already exists", sanitize.Log(dest))
+			log.Warn(msg)
+			errs = append(errs, msg)
+			continue
+		}
+
+		if err = c.Download(file.Abs, dest, force); err != nil {
+			// Failed to download file.
+			errs = append(errs, err)
+			log.Error(err)
+			continue
+		}
+	}
+
+	if !recursive {
+		return errs
+	}
+
+	dirs, err := c.Directories(from, false, MaxRequestDuration)
+
+	for _, dir := range dirs {
+		errs = append(errs, c.DownloadDir(dir.Abs, to, true, force)...)
+	}
+
+	return errs
+}
+
+// CreateDir does nothing, as object storage has no directories; they are implied by object keys.
+func (c Client) CreateDir(dir string) error {
+	return nil
+}
+
+// Upload uploads a single file to the bucket.
+func (c Client) Upload(from, to string) error {
+	file, err := os.Open(from)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	info, err := file.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(http.MethodPut, c.objectUrl(c.key(to), nil), file, info.Size())
+
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// Delete deletes a single file from the bucket.
+func (c Client) Delete(name string) error {
+	if strings.Trim(name, "/") == "" {
+		return errors.New("s3: cannot delete bucket root")
+	}
+
+	resp, err := c.do(http.MethodDelete, c.objectUrl(c.key(name), nil), nil, 0)
+
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
@@ -0,0 +1,235 @@
+package s3
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testBucket = "photos"
+	testKey    = "minio"
+	testSecret = "minio123"
+)
+
+// testServer returns a minimal in-memory stand-in for S3-compatible object storage, like MinIO.
+func testServer(t *testing.T, objects map[string]string) *httptest.Server {
+	var mu sync.Mutex
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential="+testKey+"/") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>"))
+			return
+		}
+
+		key := strings.TrimPrefix(r.URL.Path, "/"+testBucket)
+		key = strings.TrimPrefix(key, "/")
+
+		switch r.Method {
+		case http.MethodGet:
+			if key != "" {
+				if data, ok := objects[key]; ok {
+					_, _ = w.Write([]byte(data))
+				} else {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte("<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>"))
+				}
+				return
+			}
+
+			prefix := r.URL.Query().Get("prefix")
+			result := listResult{}
+			seen := make(map[string]bool)
+
+			var keys []string
+
+			for k := range objects {
+				keys = append(keys, k)
+			}
+
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				if !strings.HasPrefix(k, prefix) {
+					continue
+				}
+
+				rel := strings.TrimPrefix(k, prefix)
+
+				if i := strings.Index(rel, "/"); i >= 0 {
+					p := prefix + rel[:i+1]
+
+					if !seen[p] {
+						seen[p] = true
+						result.CommonPrefixes = append(result.CommonPrefixes, struct {
+							Prefix string `xml:"Prefix"`
+						}{p})
+					}
+
+					continue
+				}
+
+				result.Contents = append(result.Contents, struct {
+					Key          string    `xml:"Key"`
+					LastModified time.Time `xml:"LastModified"`
+					Size         int64     `xml:"Size"`
+				}{k, time.Date(2022, 1, 1, 12, 0, 0, 0, time.UTC), int64(len(objects[k]))})
+			}
+
+			_ = xml.NewEncoder(w).Encode(result)
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[key] = string(data)
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestNew(t *testing.T) {
+	t.Run("prefix", func(t *testing.T) {
+		c, err := New("http://localhost:9000/photos/backup/", "", testKey, testSecret, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "photos", c.bucket)
+		assert.Equal(t, "backup/", c.prefix)
+		assert.Equal(t, DefaultRegion, c.region)
+		assert.Equal(t, "backup/2022/cat.jpg", c.key("/2022/cat.jpg"))
+		assert.Equal(t, "/2022/cat.jpg", c.name("backup/2022/cat.jpg"))
+	})
+	t.Run("no bucket", func(t *testing.T) {
+		_, err := New("http://localhost:9000/", "", testKey, testSecret, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestRegion(t *testing.T) {
+	assert.Equal(t, "eu-central-1", Region("s3.eu-central-1.amazonaws.com", ""))
+	assert.Equal(t, "eu-west-1", Region("s3-eu-west-1.amazonaws.com", ""))
+	assert.Equal(t, DefaultRegion, Region("s3.amazonaws.com", ""))
+	assert.Equal(t, DefaultRegion, Region("localhost:9000", ""))
+	assert.Equal(t, "fr-par", Region("s3.fr-par.scw.cloud", "fr-par"))
+}
+
+func TestUriEncode(t *testing.T) {
+	assert.Equal(t, "Photos%202022", uriEncode("Photos 2022"))
+	assert.Equal(t, "a~b_c-d.jpg", uriEncode("a~b_c-d.jpg"))
+	assert.Equal(t, "/photos/2022/Caf%C3%A9.jpg", encodePath("/photos/2022/Café.jpg"))
+	assert.Equal(t, "list-type=2&prefix=a%2Fb%2F", encodeQuery(map[string][]string{"prefix": {"a/b/"}, "list-type": {"2"}}))
+}
+
+func TestClient(t *testing.T) {
+	objects := map[string]string{
+		"backup/2021/cat.jpg":       "cat",
+		"backup/2021/dog.jpg":       "dog",
+		"backup/2022/Holiday/a.jpg": "sea",
+		"backup/readme.txt":         "hello",
+		"other/ignored.jpg":         "x",
+	}
+
+	srv := testServer(t, objects)
+	defer srv.Close()
+
+	c, err := New(srv.URL+"/"+testBucket+"/backup", "", testKey, testSecret, time.Minute)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Files", func(t *testing.T) {
+		files, err := c.Files("/2021")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, files, 2) {
+			assert.Equal(t, "cat.jpg", files[0].Name)
+			assert.Equal(t, "/2021/cat.jpg", files[0].Abs)
+			assert.Equal(t, int64(3), files[0].Size)
+		}
+	})
+	t.Run("Directories", func(t *testing.T) {
+		dirs, err := c.Directories("/", false, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2021", "/2022"}, dirs.Abs())
+
+		dirs, err = c.Directories("/", true, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/2021", "/2022", "/2022/Holiday"}, dirs.Abs())
+	})
+	t.Run("Upload", func(t *testing.T) {
+		tempFile := filepath.Join(t.TempDir(), "bird.jpg")
+
+		if err := os.WriteFile(tempFile, []byte("bird"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, c.CreateDir("/2023"))
+		assert.NoError(t, c.Upload(tempFile, "/2023/bird.jpg"))
+		assert.Equal(t, "bird", objects["backup/2023/bird.jpg"])
+	})
+	t.Run("Download", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "2021", "cat.jpg")
+
+		assert.NoError(t, c.Download("/2021/cat.jpg", dest, false))
+
+		data, err := os.ReadFile(dest)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "cat", string(data))
+		assert.Error(t, c.Download("/2021/cat.jpg", dest, false))
+		assert.Error(t, c.Download("/2021/missing.jpg", dest+".missing", false))
+	})
+	t.Run("DownloadDir", func(t *testing.T) {
+		errs := c.DownloadDir("/2022", t.TempDir(), true, false)
+		assert.Empty(t, errs)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.NoError(t, c.Delete("/2021/dog.jpg"))
+		assert.NotContains(t, objects, "backup/2021/dog.jpg")
+		assert.Error(t, c.Delete("/"))
+	})
+	t.Run("AccessDenied", func(t *testing.T) {
+		denied, err := New(srv.URL+"/"+testBucket, "", "", "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = denied.Files("/")
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "accessdenied")
+		}
+	})
+}
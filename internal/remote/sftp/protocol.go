@@ -0,0 +1,270 @@
+package sftp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// SFTP version 3 packet types, see https://datatracker.ietf.org/doc/html/draft-ietf-secsh-filexfer-02.
+const (
+	fxpInit     = 1
+	fxpVersion  = 2
+	fxpOpen     = 3
+	fxpClose    = 4
+	fxpRead     = 5
+	fxpWrite    = 6
+	fxpOpendir  = 11
+	fxpReaddir  = 12
+	fxpRemove   = 13
+	fxpMkdir    = 14
+	fxpRmdir    = 15
+	fxpStat     = 17
+	fxpStatus   = 101
+	fxpHandle   = 102
+	fxpData     = 103
+	fxpName     = 104
+	fxpAttrs    = 105
+	fxpVersion3 = 3
+)
+
+// Status codes.
+const (
+	fxOk          = 0
+	fxEOF         = 1
+	fxNoSuchFile  = 2
+	fxPermDenied  = 3
+	fxFailure     = 4
+	fxUnsupported = 8
+)
+
+// Open flags.
+const (
+	fxfRead  = 0x01
+	fxfWrite = 0x02
+	fxfCreat = 0x08
+	fxfTrunc = 0x10
+)
+
+// Attribute flags.
+const (
+	attrSize        = 0x00000001
+	attrUidGid      = 0x00000002
+	attrPermissions = 0x00000004
+	attrAcModTime   = 0x00000008
+	attrExtended    = 0x80000000
+)
+
+// File type bits of the permissions attribute.
+const (
+	modeType = 0170000
+	modeDir  = 0040000
+	modeReg  = 0100000
+)
+
+// maxPacket is the maximum packet size that must be supported by all servers.
+const maxPacket = 34000
+
+// chunkSize is the size of file data in read and write requests.
+const chunkSize = 32768
+
+// statusError represents an error status returned by the server.
+type statusError struct {
+	Code uint32
+	Msg  string
+}
+
+func (e *statusError) Error() string {
+	if e.Msg != "" {
+		return fmt.Sprintf("sftp: %s (status %d)", e.Msg, e.Code)
+	}
+
+	return fmt.Sprintf("sftp: request failed with status %d", e.Code)
+}
+
+// isStatus tests if err is a status error with the specified code.
+func isStatus(err error, code uint32) bool {
+	var s *statusError
+	return errors.As(err, &s) && s.Code == code
+}
+
+// attrs represents file attributes.
+type attrs struct {
+	Size  uint64
+	Mode  uint32
+	Mtime uint32
+}
+
+// IsDir tests if the attributes belong to a directory.
+func (a attrs) IsDir() bool {
+	return a.Mode&modeType == modeDir
+}
+
+// IsRegular tests if the attributes belong to a regular file.
+func (a attrs) IsRegular() bool {
+	return a.Mode&modeType == modeReg
+}
+
+// ModTime returns the modification time.
+func (a attrs) ModTime() time.Time {
+	return time.Unix(int64(a.Mtime), 0).UTC()
+}
+
+// FileMode returns the attributes as os.FileMode.
+func (a attrs) FileMode() os.FileMode {
+	mode := os.FileMode(a.Mode & 0777)
+
+	if a.IsDir() {
+		mode |= os.ModeDir
+	}
+
+	return mode
+}
+
+// buffer builds and parses packet payloads.
+type buffer struct {
+	b   []byte
+	err error
+}
+
+func (b *buffer) byte(v byte) *buffer {
+	b.b = append(b.b, v)
+	return b
+}
+
+func (b *buffer) uint32(v uint32) *buffer {
+	b.b = binary.BigEndian.AppendUint32(b.b, v)
+	return b
+}
+
+func (b *buffer) uint64(v uint64) *buffer {
+	b.b = binary.BigEndian.AppendUint64(b.b, v)
+	return b
+}
+
+func (b *buffer) string(v string) *buffer {
+	return b.bytes([]byte(v))
+}
+
+func (b *buffer) bytes(v []byte) *buffer {
+	b.uint32(uint32(len(v)))
+	b.b = append(b.b, v...)
+	return b
+}
+
+// attrs appends file attributes, only the permissions are set if not zero.
+func (b *buffer) attrs(a attrs) *buffer {
+	if a.Mode == 0 {
+		return b.uint32(0)
+	}
+
+	return b.uint32(attrPermissions).uint32(a.Mode)
+}
+
+func (b *buffer) readUint32() uint32 {
+	if b.err != nil || len(b.b) < 4 {
+		b.err = io.ErrUnexpectedEOF
+		return 0
+	}
+
+	v := binary.BigEndian.Uint32(b.b)
+	b.b = b.b[4:]
+	return v
+}
+
+func (b *buffer) readUint64() uint64 {
+	if b.err != nil || len(b.b) < 8 {
+		b.err = io.ErrUnexpectedEOF
+		return 0
+	}
+
+	v := binary.BigEndian.Uint64(b.b)
+	b.b = b.b[8:]
+	return v
+}
+
+func (b *buffer) readBytes() []byte {
+	n := b.readUint32()
+
+	if b.err != nil || uint32(len(b.b)) < n {
+		b.err = io.ErrUnexpectedEOF
+		return nil
+	}
+
+	v := b.b[:n]
+	b.b = b.b[n:]
+	return v
+}
+
+func (b *buffer) readString() string {
+	return string(b.readBytes())
+}
+
+func (b *buffer) readAttrs() (a attrs) {
+	flags := b.readUint32()
+
+	if flags&attrSize != 0 {
+		a.Size = b.readUint64()
+	}
+
+	if flags&attrUidGid != 0 {
+		b.readUint32()
+		b.readUint32()
+	}
+
+	if flags&attrPermissions != 0 {
+		a.Mode = b.readUint32()
+	}
+
+	if flags&attrAcModTime != 0 {
+		b.readUint32()
+		a.Mtime = b.readUint32()
+	}
+
+	if flags&attrExtended != 0 {
+		for n := b.readUint32(); n > 0 && b.err == nil; n-- {
+			b.readString()
+			b.readString()
+		}
+	}
+
+	return a
+}
+
+// writePacket writes a packet with the specified type and payload.
+func writePacket(w io.Writer, packetType byte, payload []byte) error {
+	header := binary.BigEndian.AppendUint32(nil, uint32(len(payload)+1))
+	header = append(header, packetType)
+
+	if _, err := w.Write(append(header, payload...)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readPacket reads a packet and returns its type and payload.
+func readPacket(r io.Reader) (byte, *buffer, error) {
+	var header [5]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[:4])
+
+	if length < 1 || length > 4*maxPacket {
+		return 0, nil, fmt.Errorf("sftp: invalid packet length %d", length)
+	}
+
+	payload := make([]byte, length-1)
+
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+
+	return header[4], &buffer{b: payload}, nil
+}
@@ -0,0 +1,668 @@
+/*
+Package sftp implements syncing with SSH servers using the SSH File Transfer Protocol.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package sftp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Global log instance.
+var log = event.Log
+
+// DefaultPort is the default SSH port.
+const DefaultPort = "22"
+
+// DialTimeout is the maximum time for establishing a connection.
+const DialTimeout = 30 * time.Second
+
+// MaxRequestDuration is the maximum request duration e.g. for recursive retrieval of large remote directory structures.
+const MaxRequestDuration = 30 * time.Minute
+
+// Client represents an SFTP connection, remote file names are relative to the path in the service URL.
+type Client struct {
+	conn    *ssh.Client
+	session *ssh.Session
+	w       io.WriteCloser
+	r       io.Reader
+	root    string
+	timeout time.Duration
+	nextID  uint32
+	mutex   sync.Mutex
+}
+
+// Addr returns the host and port of a service URL like "sftp://nas.local/backup".
+func Addr(u *url.URL) string {
+	if u.Port() == "" {
+		return net.JoinHostPort(u.Hostname(), DefaultPort)
+	}
+
+	return u.Host
+}
+
+// errHostKey aborts the handshake once the server host key has been received.
+var errHostKey = errors.New("host key received")
+
+// HostKey returns the SHA256 fingerprint of the host key presented by the SSH server in the
+// service URL, so that it can be pinned and verified on subsequent connections.
+func HostKey(rawUrl string) (string, error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return "", err
+	} else if u.Hostname() == "" {
+		return "", fmt.Errorf("sftp: invalid service url")
+	}
+
+	var fingerprint string
+
+	config := &ssh.ClientConfig{
+		User: u.User.Username(),
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			fingerprint = ssh.FingerprintSHA256(key)
+			return errHostKey
+		},
+		Timeout: DialTimeout,
+	}
+
+	if conn, err := ssh.Dial("tcp", Addr(u), config); err == nil {
+		_ = conn.Close()
+	} else if fingerprint == "" {
+		return "", fmt.Errorf("sftp: %s", err)
+	}
+
+	return fingerprint, nil
+}
+
+// New connects to the SSH server in the service URL. Clients authenticate with the password,
+// the private key in keyFile, or both. The password is also used as passphrase for encrypted keys.
+// The server must present the pinned hostKey fingerprint, or a key listed in ~/.ssh/known_hosts
+// if no fingerprint is given.
+func New(rawUrl, keyFile, user, pass, hostKey string, timeout time.Duration) (*Client, error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return nil, err
+	} else if u.Hostname() == "" {
+		return nil, fmt.Errorf("sftp: invalid service url")
+	}
+
+	if user == "" {
+		user = u.User.Username()
+	}
+
+	var auth []ssh.AuthMethod
+
+	if keyFile != "" {
+		if signer, err := privateKey(keyFile, pass); err != nil {
+			return nil, err
+		} else {
+			auth = append(auth, ssh.PublicKeys(signer))
+		}
+	}
+
+	if pass != "" {
+		auth = append(auth, ssh.Password(pass))
+	}
+
+	callback, err := hostKeyCallback(hostKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: callback,
+		Timeout:         DialTimeout,
+	}
+
+	conn, err := ssh.Dial("tcp", Addr(u), config)
+
+	if err != nil {
+		return nil, fmt.Errorf("sftp: %s", err)
+	}
+
+	c := &Client{
+		conn:    conn,
+		root:    u.Path,
+		timeout: timeout,
+	}
+
+	if c.root == "" {
+		c.root = "."
+	}
+
+	if err := c.init(); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// privateKey reads a private key file, pass is used if it is encrypted.
+func privateKey(keyFile, pass string) (ssh.Signer, error) {
+	data, err := os.ReadFile(keyFile)
+
+	if err != nil {
+		return nil, fmt.Errorf("sftp: cannot read key file %s", sanitize.Log(keyFile))
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+
+	if _, ok := err.(*ssh.PassphraseMissingError); ok && pass != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(pass))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("sftp: invalid key file %s (%s)", sanitize.Log(keyFile), err)
+	}
+
+	return signer, nil
+}
+
+// hostKeyCallback verifies host keys against the pinned fingerprint, or the known_hosts file of the
+// current user if no fingerprint is given. Unknown hosts are rejected.
+func hostKeyCallback(hostKey string) (ssh.HostKeyCallback, error) {
+	if hostKey != "" {
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if fingerprint := ssh.FingerprintSHA256(key); fingerprint != hostKey {
+				return fmt.Errorf("host key mismatch for %s, expected %s but got %s", sanitize.Log(hostname), hostKey, fingerprint)
+			}
+
+			return nil
+		}, nil
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		if fileName := filepath.Join(home, ".ssh", "known_hosts"); fs.FileExists(fileName) {
+			if callback, err := knownhosts.New(fileName); err == nil {
+				return callback, nil
+			} else {
+				return nil, fmt.Errorf("sftp: %s", err)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("sftp: host key cannot be verified without pinned fingerprint or known_hosts file")
+}
+
+// init starts the sftp subsystem and negotiates the protocol version.
+func (c *Client) init() (err error) {
+	if c.session, err = c.conn.NewSession(); err != nil {
+		return err
+	}
+
+	if c.w, err = c.session.StdinPipe(); err != nil {
+		return err
+	}
+
+	if c.r, err = c.session.StdoutPipe(); err != nil {
+		return err
+	}
+
+	if err = c.session.RequestSubsystem("sftp"); err != nil {
+		return fmt.Errorf("sftp: subsystem not available (%s)", err)
+	}
+
+	if err = writePacket(c.w, fxpInit, new(buffer).uint32(fxpVersion3).b); err != nil {
+		return err
+	}
+
+	if t, _, err := readPacket(c.r); err != nil {
+		return err
+	} else if t != fxpVersion {
+		return fmt.Errorf("sftp: unexpected packet type %d", t)
+	}
+
+	return nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	if c.session != nil {
+		_ = c.session.Close()
+	}
+
+	return c.conn.Close()
+}
+
+// request sends a request and returns the response type and payload.
+func (c *Client) request(packetType byte, payload *buffer) (byte, *buffer, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.nextID++
+	id := c.nextID
+
+	data := new(buffer).uint32(id)
+	data.b = append(data.b, payload.b...)
+
+	if err := writePacket(c.w, packetType, data.b); err != nil {
+		return 0, nil, err
+	}
+
+	t, resp, err := readPacket(c.r)
+
+	if err != nil {
+		return 0, nil, err
+	} else if respID := resp.readUint32(); respID != id {
+		return 0, nil, fmt.Errorf("sftp: unexpected response id %d", respID)
+	}
+
+	return t, resp, nil
+}
+
+// status returns the error of a status response, or an error if the response has another type.
+func status(t byte, resp *buffer) error {
+	if t != fxpStatus {
+		return fmt.Errorf("sftp: unexpected packet type %d", t)
+	}
+
+	code := resp.readUint32()
+
+	if code == fxOk {
+		return nil
+	}
+
+	return &statusError{Code: code, Msg: resp.readString()}
+}
+
+// call sends a request that is answered with a status response.
+func (c *Client) call(packetType byte, payload *buffer) error {
+	t, resp, err := c.request(packetType, payload)
+
+	if err != nil {
+		return err
+	}
+
+	return status(t, resp)
+}
+
+// handle sends a request that is answered with a file or directory handle.
+func (c *Client) handle(packetType byte, payload *buffer) (string, error) {
+	t, resp, err := c.request(packetType, payload)
+
+	if err != nil {
+		return "", err
+	} else if t != fxpHandle {
+		return "", status(t, resp)
+	}
+
+	return resp.readString(), resp.err
+}
+
+// abs returns the server path of a remote file name, which cannot be outside the root.
+func (c *Client) abs(name string) string {
+	return path.Join(c.root, path.Clean("/"+name))
+}
+
+// stat returns the attributes of a remote file.
+func (c *Client) stat(name string) (attrs, error) {
+	t, resp, err := c.request(fxpStat, new(buffer).string(c.abs(name)))
+
+	if err != nil {
+		return attrs{}, err
+	} else if t != fxpAttrs {
+		return attrs{}, status(t, resp)
+	}
+
+	return resp.readAttrs(), resp.err
+}
+
+// readDir returns the contents of a remote directory, hidden files are only included if requested.
+func (c *Client) readDir(dir string, hidden bool) (result fs.FileInfos, err error) {
+	h, err := c.handle(fxpOpendir, new(buffer).string(c.abs(dir)))
+
+	if err != nil {
+		return result, err
+	}
+
+	defer func() {
+		_ = c.call(fxpClose, new(buffer).string(h))
+	}()
+
+	dir = path.Clean("/" + dir)
+
+	for {
+		t, resp, err := c.request(fxpReaddir, new(buffer).string(h))
+
+		if err != nil {
+			return result, err
+		} else if t != fxpName {
+			if err = status(t, resp); isStatus(err, fxEOF) {
+				return result, nil
+			}
+
+			return result, err
+		}
+
+		for n := resp.readUint32(); n > 0 && resp.err == nil; n-- {
+			name := resp.readString()
+			resp.readString()
+			a := resp.readAttrs()
+
+			if name == "." || name == ".." || !hidden && strings.HasPrefix(name, ".") {
+				continue
+			}
+
+			result = append(result, fs.FileInfo{
+				Name: name,
+				Abs:  path.Join(dir, name),
+				Size: int64(a.Size),
+				Date: a.ModTime(),
+				Dir:  a.IsDir(),
+			})
+		}
+
+		if resp.err != nil {
+			return result, resp.err
+		}
+	}
+}
+
+// Files returns all files in a directory.
+func (c *Client) Files(dir string) (result fs.FileInfos, err error) {
+	infos, err := c.readDir(dir, false)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range infos {
+		if !info.Dir {
+			result = append(result, info)
+		}
+	}
+
+	return result, nil
+}
+
+// Directories returns all subdirectories in a path.
+func (c *Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	if timeout == 0 {
+		timeout = c.timeout
+	}
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if timeout > 0 && time.Now().Sub(start) >= timeout {
+		log.Warnf("sftp: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c *Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	infos, err := c.readDir(root, false)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range infos {
+		if !info.Dir {
+			continue
+		}
+
+		result = append(result, info)
+
+		if recursive && (timeout < time.Second || time.Now().Sub(start) < timeout) {
+			subDirs, err := c.fetchDirs(info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Download downloads a single file to the given location.
+func (c *Client) Download(from, to string, force bool) error {
+	// Skip if file already exists.
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("sftp: download skipped, %s already exists", sanitize.Log(to))
+	}
+
+	dir := filepath.Dir(to)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create local storage path.
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("sftp: cannot create folder %s (%s)", sanitize.Log(dir), err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("sftp: %s is not a folder", sanitize.Log(dir))
+	}
+
+	h, err := c.handle(fxpOpen, new(buffer).string(c.abs(from)).uint32(fxfRead).attrs(attrs{}))
+
+	if err != nil {
+		log.Errorf("sftp: %s", sanitize.Log(err.Error()))
+		return fmt.Errorf("sftp: failed downloading %s", sanitize.Log(from))
+	}
+
+	defer func() {
+		_ = c.call(fxpClose, new(buffer).string(h))
+	}()
+
+	file, err := os.Create(to)
+
+	if err != nil {
+		return err
+	}
+
+	for offset := uint64(0); ; {
+		t, resp, err := c.request(fxpRead, new(buffer).string(h).uint64(offset).uint32(chunkSize))
+
+		if err == nil && t != fxpData {
+			if err = status(t, resp); isStatus(err, fxEOF) {
+				return file.Close()
+			}
+		}
+
+		var data []byte
+
+		if err == nil {
+			data = resp.readBytes()
+			err = resp.err
+		}
+
+		if err == nil {
+			_, err = file.Write(data)
+		}
+
+		if err != nil {
+			_ = file.Close()
+			_ = os.Remove(to)
+			return fmt.Errorf("sftp: failed downloading %s (%s)", sanitize.Log(from), err)
+		}
+
+		offset += uint64(len(data))
+	}
+}
+
+// DownloadDir downloads all files from a remote to a local directory.
+func (c *Client) DownloadDir(from, to string, recursive, force bool) (errs []error) {
+	files, err := c.Files(from)
+
+	if err != nil {
+		return append(errs, err)
+	}
+
+	for _, file := range files {
+		dest := to + string(os.PathSeparator) + file.Abs
+
+		if _, err = os.Stat(dest); err == nil {
+			// File already exists.
+			msg := fmt.Errorf("sftp: %s already exists", sanitize.Log(dest))
+			log.Warn(msg)
+			errs = append(errs, msg)
+			continue
+		}
+
+		if err = c.Download(file.Abs, dest, force); err != nil {
+			// Failed to download file.
+			errs = append(errs, err)
+			log.Error(err)
+			continue
+		}
+	}
+
+	if !recursive {
+		return errs
+	}
+
+	dirs, err := c.Directories(from, false, MaxRequestDuration)
+
+	for _, dir := range dirs {
+		errs = append(errs, c.DownloadDir(dir.Abs, to, true, force)...)
+	}
+
+	return errs
+}
+
+// CreateDir recursively creates directories if they don't exist.
+func (c *Client) CreateDir(dir string) error {
+	dir = path.Clean("/" + dir)
+
+	if dir == "/" {
+		return nil
+	}
+
+	if a, err := c.stat(dir); err == nil {
+		if a.IsDir() {
+			return nil
+		}
+
+		return fmt.Errorf("sftp: %s is not a folder", sanitize.Log(dir))
+	} else if !isStatus(err, fxNoSuchFile) {
+		return err
+	}
+
+	if err := c.CreateDir(path.Dir(dir)); err != nil {
+		return err
+	}
+
+	return c.call(fxpMkdir, new(buffer).string(c.abs(dir)).attrs(attrs{Mode: 0755}))
+}
+
+// Upload uploads a single file to the remote server.
+func (c *Client) Upload(from, to string) error {
+	file, err := os.Open(from)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	h, err := c.handle(fxpOpen, new(buffer).string(c.abs(to)).uint32(fxfWrite|fxfCreat|fxfTrunc).attrs(attrs{Mode: 0644}))
+
+	if err != nil {
+		return err
+	}
+
+	data := make([]byte, chunkSize)
+
+	for offset := uint64(0); ; {
+		n, readErr := file.Read(data)
+
+		if n > 0 {
+			if err := c.call(fxpWrite, new(buffer).string(h).uint64(offset).bytes(data[:n])); err != nil {
+				_ = c.call(fxpClose, new(buffer).string(h))
+				return err
+			}
+
+			offset += uint64(n)
+		}
+
+		if readErr == io.EOF {
+			break
+		} else if readErr != nil {
+			_ = c.call(fxpClose, new(buffer).string(h))
+			return readErr
+		}
+	}
+
+	return c.call(fxpClose, new(buffer).string(h))
+}
+
+// Delete deletes a single file or directory on a remote server.
+func (c *Client) Delete(name string) error {
+	name = path.Clean("/" + name)
+
+	if name == "/" {
+		return fmt.Errorf("sftp: cannot delete sync folder")
+	}
+
+	a, err := c.stat(name)
+
+	if err != nil {
+		return err
+	} else if !a.IsDir() {
+		return c.call(fxpRemove, new(buffer).string(c.abs(name)))
+	}
+
+	infos, err := c.readDir(name, true)
+
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		if err := c.Delete(info.Abs); err != nil {
+			return err
+		}
+	}
+
+	return c.call(fxpRmdir, new(buffer).string(c.abs(name)))
+}
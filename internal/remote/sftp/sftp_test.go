@@ -0,0 +1,447 @@
+package sftp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+const testUser = "admin"
+const testPass = "photoprism"
+
+// testServer starts an SSH server with a minimal sftp subsystem serving root.
+func testServer(t *testing.T, root string) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == testUser && string(pass) == testPass {
+				return nil, nil
+			}
+
+			return nil, os.ErrPermission
+		},
+	}
+
+	config.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go serveConn(conn, config, root)
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func serveConn(conn net.Conn, config *ssh.ServerConfig, root string) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+
+	if err != nil {
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		ch, requests, err := newChan.Accept()
+
+		if err != nil {
+			continue
+		}
+
+		go func() {
+			for req := range requests {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+
+				if ok {
+					go serveSftp(ch, root)
+				}
+			}
+		}()
+	}
+}
+
+// serveSftp handles the subset of sftp requests used by the client.
+func serveSftp(rw io.ReadWriteCloser, root string) {
+	defer rw.Close()
+
+	handles := make(map[string]*os.File)
+	listed := make(map[string]bool)
+
+	abs := func(name string) string {
+		return filepath.Join(root, filepath.Clean("/"+name))
+	}
+
+	fileAttrs := func(info os.FileInfo) attrs {
+		a := attrs{Size: uint64(info.Size()), Mode: uint32(info.Mode().Perm()) | modeReg, Mtime: uint32(info.ModTime().Unix())}
+
+		if info.IsDir() {
+			a.Mode = uint32(info.Mode().Perm()) | modeDir
+		}
+
+		return a
+	}
+
+	writeAttrs := func(b *buffer, a attrs) *buffer {
+		return b.uint32(attrSize | attrPermissions | attrAcModTime).uint64(a.Size).uint32(a.Mode).uint32(a.Mtime).uint32(a.Mtime)
+	}
+
+	for {
+		t, req, err := readPacket(rw)
+
+		if err != nil {
+			return
+		}
+
+		if t == fxpInit {
+			_ = writePacket(rw, fxpVersion, new(buffer).uint32(fxpVersion3).b)
+			continue
+		}
+
+		id := req.readUint32()
+		resp := new(buffer).uint32(id)
+		respType := byte(fxpStatus)
+
+		result := func(err error) {
+			switch {
+			case err == nil:
+				resp.uint32(fxOk).string("").string("")
+			case err == io.EOF:
+				resp.uint32(fxEOF).string("").string("")
+			case os.IsNotExist(err):
+				resp.uint32(fxNoSuchFile).string(err.Error()).string("")
+			default:
+				resp.uint32(fxFailure).string(err.Error()).string("")
+			}
+		}
+
+		switch t {
+		case fxpOpen:
+			name := req.readString()
+			flags := req.readUint32()
+			mode := os.O_RDONLY
+
+			if flags&fxfWrite != 0 {
+				mode = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+			}
+
+			if f, err := os.OpenFile(abs(name), mode, 0644); err != nil {
+				result(err)
+			} else {
+				handles[f.Name()] = f
+				respType = fxpHandle
+				resp.string(f.Name())
+			}
+		case fxpOpendir:
+			name := req.readString()
+
+			if f, err := os.Open(abs(name)); err != nil {
+				result(err)
+			} else {
+				handles[f.Name()] = f
+				respType = fxpHandle
+				resp.string(f.Name())
+			}
+		case fxpClose:
+			h := req.readString()
+
+			if f, ok := handles[h]; ok {
+				result(f.Close())
+				delete(handles, h)
+				delete(listed, h)
+			} else {
+				result(os.ErrNotExist)
+			}
+		case fxpRead:
+			f := handles[req.readString()]
+			offset := req.readUint64()
+			data := make([]byte, req.readUint32())
+
+			if n, err := f.ReadAt(data, int64(offset)); n > 0 {
+				respType = fxpData
+				resp.bytes(data[:n])
+			} else {
+				result(err)
+			}
+		case fxpWrite:
+			f := handles[req.readString()]
+			offset := req.readUint64()
+			_, err := f.WriteAt(req.readBytes(), int64(offset))
+			result(err)
+		case fxpReaddir:
+			h := req.readString()
+
+			if listed[h] {
+				result(io.EOF)
+				break
+			}
+
+			entries, err := handles[h].Readdir(-1)
+
+			if err != nil {
+				result(err)
+				break
+			}
+
+			listed[h] = true
+			respType = fxpName
+			resp.uint32(uint32(len(entries)))
+
+			for _, info := range entries {
+				writeAttrs(resp.string(info.Name()).string(info.Name()), fileAttrs(info))
+			}
+		case fxpStat:
+			if info, err := os.Stat(abs(req.readString())); err != nil {
+				result(err)
+			} else {
+				respType = fxpAttrs
+				writeAttrs(resp, fileAttrs(info))
+			}
+		case fxpMkdir:
+			result(os.Mkdir(abs(req.readString()), 0755))
+		case fxpRemove, fxpRmdir:
+			result(os.Remove(abs(req.readString())))
+		default:
+			resp.uint32(fxUnsupported).string("unsupported").string("")
+		}
+
+		if err := writePacket(rw, respType, resp.b); err != nil {
+			return
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		addr := testServer(t, t.TempDir())
+
+		c, err := New("sftp://"+addr+"/", "", testUser, testPass, testHostKey(t, addr), time.Minute)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, c.Close())
+	})
+	t.Run("UserFromUrl", func(t *testing.T) {
+		addr := testServer(t, t.TempDir())
+
+		c, err := New("sftp://"+testUser+"@"+addr+"/", "", "", testPass, testHostKey(t, addr), time.Minute)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, c.Close())
+	})
+	t.Run("WrongPassword", func(t *testing.T) {
+		addr := testServer(t, t.TempDir())
+
+		_, err := New("sftp://"+addr+"/", "", testUser, "wrong", testHostKey(t, addr), time.Minute)
+
+		assert.Error(t, err)
+	})
+	t.Run("HostKeyMismatch", func(t *testing.T) {
+		addr := testServer(t, t.TempDir())
+
+		_, err := New("sftp://"+addr+"/", "", testUser, testPass, "SHA256:invalid", time.Minute)
+
+		if err == nil {
+			t.Fatal("error expected")
+		}
+
+		assert.Contains(t, err.Error(), "host key mismatch")
+	})
+	t.Run("UnknownHost", func(t *testing.T) {
+		addr := testServer(t, t.TempDir())
+
+		t.Setenv("HOME", t.TempDir())
+
+		_, err := New("sftp://"+addr+"/", "", testUser, testPass, "", time.Minute)
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidUrl", func(t *testing.T) {
+		_, err := New("/photos", "", testUser, testPass, "", time.Minute)
+
+		assert.Error(t, err)
+	})
+	t.Run("KeyFileNotFound", func(t *testing.T) {
+		_, err := New("sftp://127.0.0.1/", "testdata/missing", testUser, "", "", time.Minute)
+
+		assert.Error(t, err)
+	})
+}
+
+// testHostKey returns the host key fingerprint of the test server.
+func testHostKey(t *testing.T, addr string) string {
+	hostKey, err := HostKey("sftp://" + addr + "/")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hostKey
+}
+
+func TestHostKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		addr := testServer(t, t.TempDir())
+
+		hostKey, err := HostKey("sftp://" + addr + "/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, strings.HasPrefix(hostKey, "SHA256:"))
+	})
+	t.Run("NotListening", func(t *testing.T) {
+		_, err := HostKey("sftp://127.0.0.1:1/")
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidUrl", func(t *testing.T) {
+		_, err := HostKey("/photos")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestAddr(t *testing.T) {
+	u, _ := url.Parse("sftp://127.0.0.1/photos")
+	assert.Equal(t, "127.0.0.1:22", Addr(u))
+
+	u, _ = url.Parse("sftp://nas.local:2222/photos")
+	assert.Equal(t, "nas.local:2222", Addr(u))
+}
+
+func TestClient(t *testing.T) {
+	root := t.TempDir()
+	addr := testServer(t, root)
+
+	c, err := New("sftp://"+addr+"/", "", testUser, testPass, testHostKey(t, addr), time.Minute)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	src := filepath.Join(t.TempDir(), "photo.jpg")
+	data := make([]byte, 3*chunkSize+100)
+
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("CreateDir", func(t *testing.T) {
+		assert.NoError(t, c.CreateDir("/2021/Holiday"))
+		assert.NoError(t, c.CreateDir("/2021/Holiday"))
+		assert.DirExists(t, filepath.Join(root, "2021", "Holiday"))
+	})
+	t.Run("Upload", func(t *testing.T) {
+		assert.NoError(t, c.Upload(src, "/2021/Holiday/photo.jpg"))
+
+		result, err := os.ReadFile(filepath.Join(root, "2021", "Holiday", "photo.jpg"))
+
+		assert.NoError(t, err)
+		assert.Equal(t, data, result)
+	})
+	t.Run("Files", func(t *testing.T) {
+		files, err := c.Files("/2021/Holiday")
+
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		assert.Equal(t, "photo.jpg", files[0].Name)
+		assert.Equal(t, "/2021/Holiday/photo.jpg", files[0].Abs)
+		assert.Equal(t, int64(len(data)), files[0].Size)
+		assert.False(t, files[0].Dir)
+	})
+	t.Run("Directories", func(t *testing.T) {
+		dirs, err := c.Directories("/", false, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, dirs, 1)
+		assert.Equal(t, "/2021", dirs[0].Abs)
+
+		dirs, err = c.Directories("/", true, 0)
+
+		assert.NoError(t, err)
+		assert.Len(t, dirs, 2)
+	})
+	t.Run("Download", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "download", "photo.jpg")
+
+		assert.NoError(t, c.Download("/2021/Holiday/photo.jpg", dest, false))
+		assert.Error(t, c.Download("/2021/Holiday/photo.jpg", dest, false))
+
+		result, err := os.ReadFile(dest)
+
+		assert.NoError(t, err)
+		assert.Equal(t, data, result)
+	})
+	t.Run("DownloadNotFound", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "missing.jpg")
+
+		assert.Error(t, c.Download("/missing.jpg", dest, false))
+		assert.NoFileExists(t, dest)
+	})
+	t.Run("DownloadDir", func(t *testing.T) {
+		dest := t.TempDir()
+
+		assert.Empty(t, c.DownloadDir("/", dest, true, false))
+		assert.FileExists(t, filepath.Join(dest, "2021", "Holiday", "photo.jpg"))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		assert.Error(t, c.Delete("/"))
+		assert.NoError(t, c.Delete("/2021"))
+		assert.NoDirExists(t, filepath.Join(root, "2021"))
+		assert.Error(t, c.Delete("/2021"))
+	})
+}
@@ -20,6 +20,9 @@ func AlbumsSlim(f form.SearchAlbums) (results AlbumResultsSlim, err error) {
 		Order("albums.album_favorite DESC, albums.album_title ASC, albums.album_uid DESC").
 		Where("albums.deleted_at IS NULL")
 
+	// Limit results to albums the user may access?
+	s = ScopeAlbums(s, f.Scope)
+
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
@@ -49,6 +52,15 @@ func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
 		Select("albums.*, 0 as photo_count, 0 as link_count, CASE WHEN albums.album_year = 0 THEN 0 ELSE 1 END AS has_year").
 		Where("albums.deleted_at IS NULL")
 
+	// Include the role of the user in albums shared with them?
+	if f.Scope.Member != "" {
+		s = s.Select("albums.*, 0 as photo_count, 0 as link_count, CASE WHEN albums.album_year = 0 THEN 0 ELSE 1 END AS has_year, album_members.member_role").
+			Joins("LEFT JOIN album_members ON album_members.album_uid = albums.album_uid AND album_members.user_uid = ?", f.Scope.Member)
+	}
+
+	// Limit results to albums the user may access?
+	s = ScopeAlbums(s, f.Scope)
+
 	// Limit result count.
 	if f.Count > 0 && f.Count <= MaxResults {
 		s = s.Limit(f.Count).Offset(f.Offset)
@@ -140,7 +152,7 @@ func Albums(f form.SearchAlbums) (results AlbumResults, err error) {
 	}
 
 	if f.Favorite {
-		s = s.Where("albums.album_favorite = 1")
+		s = s.Where("albums.album_favorite = TRUE")
 	}
 
 	if (f.Year > 0 && f.Year <= txt.YearMax) || f.Year == entity.UnknownYear {
@@ -9,6 +9,8 @@ type Album struct {
 	ID               uint      `json:"-"`
 	AlbumUID         string    `json:"UID"`
 	ParentUID        string    `json:"ParentUID"`
+	OwnerUID         string    `json:"OwnerUID,omitempty"`
+	MemberRole       string    `json:"MemberRole,omitempty"`
 	Thumb            string    `json:"Thumb"`
 	ThumbSrc         string    `json:"ThumbSrc,omitempty"`
 	AlbumSlug        string    `json:"Slug"`
@@ -0,0 +1,52 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// AuditLog returns audit log entries matching the search form, most recent first.
+func AuditLog(f form.SearchAudit) (result entity.AuditLogs, err error) {
+	s := Db()
+
+	if f.User != "" {
+		s = s.Where("user_uid = ? OR user_name = ?", f.User, strings.ToLower(f.User))
+	}
+
+	// Actions without a verb, e.g. "photos", match all actions of this type.
+	switch action := strings.TrimSuffix(f.Action, "."); {
+	case action == "":
+	case strings.Contains(action, "."):
+		s = s.Where("action = ?", action)
+	default:
+		s = s.Where("action LIKE ?", action+".%")
+	}
+
+	if f.UID != "" {
+		s = s.Where("entity_uid = ?", f.UID)
+	}
+
+	if !f.After.IsZero() {
+		s = s.Where("created_at >= ?", f.After)
+	}
+
+	if !f.Before.IsZero() {
+		s = s.Where("created_at < ?", f.Before)
+	}
+
+	s = s.Order("created_at DESC, id DESC")
+
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	if err := s.Find(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestAuditLog(t *testing.T) {
+	entity.AddAuditLog(entity.UserFixtures.Pointer("bob"), "", "", entity.AuditPhotosPrivate, []string{"pt9jtdre2lvl0y18"}, `{"Private":[false,true]}`)
+
+	t.Run("User", func(t *testing.T) {
+		results, err := AuditLog(form.SearchAudit{User: "bob", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.GreaterOrEqual(t, len(results), 1) {
+			assert.Equal(t, "uqxc08w3d0ej2283", results[0].UserUID)
+		}
+	})
+	t.Run("ActionType", func(t *testing.T) {
+		results, err := AuditLog(form.SearchAudit{Action: "photos", UID: "pt9jtdre2lvl0y18"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.GreaterOrEqual(t, len(results), 1) {
+			assert.Equal(t, entity.AuditPhotosPrivate, results[0].Action)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		results, err := AuditLog(form.SearchAudit{User: "alice", Action: "photos.private", UID: "pt9jtdre2lvl0y18"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+	})
+}
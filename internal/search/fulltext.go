@@ -0,0 +1,108 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Full-text index columns, see the "photo_search" migrations.
+var (
+	FullTextAll   = []string{"title", "description", "keywords", "notes", "ocr", "labels", "subjects", "places"}
+	FullTextNotes = []string{"notes", "ocr"}
+	FullTextOcr   = []string{"ocr"}
+)
+
+// FullTextWeights contains the BM25 column weights in the same order as FullTextAll. Matches in
+// titles are weighted highest, followed by descriptions, labels and people, keywords, notes and
+// places, and OCR text. MySQL does not support column weights, and PostgreSQL only supports four
+// weight classes, see the "document" column of its "photo_search" table.
+var FullTextWeights = []float64{10.0, 5.0, 3.0, 2.0, 1.0, 4.0, 4.0, 2.0}
+
+// FullTextSupported tests if full-text search is supported by the database dialect.
+func FullTextSupported() bool {
+	switch entity.DbDialect() {
+	case entity.SQLite3, entity.MySQL, entity.Postgres:
+		return true
+	default:
+		return false
+	}
+}
+
+// FullTextWhere returns a condition that matches photos with the query in one of the columns.
+func FullTextWhere(cols []string, query string) (string, []interface{}) {
+	switch entity.DbDialect() {
+	case entity.MySQL:
+		return fmt.Sprintf("photos.id IN (SELECT photo_id FROM photo_search WHERE MATCH (%s) AGAINST (? IN BOOLEAN MODE))",
+			strings.Join(cols, ", ")), []interface{}{BooleanQuery(query)}
+	case entity.Postgres:
+		return fmt.Sprintf("photos.id IN (SELECT photo_id FROM photo_search WHERE %s @@ plainto_tsquery('simple', ?))",
+			postgresDocument(cols)), []interface{}{query}
+	default:
+		return fmt.Sprintf("photos.id IN (SELECT rowid FROM photo_search WHERE photo_search MATCH %s)",
+			sqliteMatch(cols)), []interface{}{query}
+	}
+}
+
+// FullTextJoin returns a left join with the photos matching the query in any column and their rank as "fts.rank",
+// where lower values are better. Both "fts.photo_id" and "fts.rank" are NULL for photos that don't match.
+func FullTextJoin(query string) (string, []interface{}) {
+	switch entity.DbDialect() {
+	case entity.MySQL:
+		match := fmt.Sprintf("MATCH (%s) AGAINST (? IN BOOLEAN MODE)", strings.Join(FullTextAll, ", "))
+		return fmt.Sprintf("LEFT JOIN (SELECT photo_id, -%s AS `rank` FROM photo_search WHERE %s) fts ON fts.photo_id = photos.id",
+			match, match), []interface{}{BooleanQuery(query), BooleanQuery(query)}
+	case entity.Postgres:
+		return "LEFT JOIN (SELECT photo_id, -ts_rank_cd(document, plainto_tsquery('simple', ?)) AS rank FROM photo_search WHERE document @@ plainto_tsquery('simple', ?)) fts ON fts.photo_id = photos.id",
+			[]interface{}{query, query}
+	default:
+		weights := make([]string, len(FullTextWeights))
+
+		for i, w := range FullTextWeights {
+			weights[i] = fmt.Sprintf("%.1f", w)
+		}
+
+		return fmt.Sprintf("LEFT JOIN (SELECT rowid AS photo_id, bm25(photo_search, %s) AS rank FROM photo_search WHERE photo_search MATCH %s) fts ON fts.photo_id = photos.id",
+			strings.Join(weights, ", "), sqliteMatch(FullTextAll)), []interface{}{query}
+	}
+}
+
+// postgresDocument returns the PostgreSQL text search vector column for the columns.
+func postgresDocument(cols []string) string {
+	switch strings.Join(cols, " ") {
+	case strings.Join(FullTextNotes, " "):
+		return "notes_document"
+	case strings.Join(FullTextOcr, " "):
+		return "ocr_document"
+	default:
+		return "document"
+	}
+}
+
+// sqliteMatch returns the FTS5 match expression for the columns.
+func sqliteMatch(cols []string) string {
+	if len(cols) == len(FullTextAll) {
+		return "jieba_query(?)"
+	}
+
+	return fmt.Sprintf("'{%s} : (' || jieba_query(?) || ')'", strings.Join(cols, " "))
+}
+
+// BooleanQuery converts a search string into a MySQL boolean mode query that requires all words.
+func BooleanQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		switch r {
+		case ' ', '\t', '\n', '+', '-', '<', '>', '(', ')', '~', '*', '"', '@', ',', ';':
+			return true
+		default:
+			return false
+		}
+	})
+
+	for i, w := range words {
+		words[i] = fmt.Sprintf("+\"%s\"", w)
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,55 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestBooleanQuery(t *testing.T) {
+	t.Run("Words", func(t *testing.T) {
+		assert.Equal(t, `+"welcome" +"berlin"`, BooleanQuery("welcome berlin"))
+	})
+	t.Run("Operators", func(t *testing.T) {
+		assert.Equal(t, `+"foo" +"bar"`, BooleanQuery(`-foo* +"bar" (`))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", BooleanQuery("  "))
+	})
+}
+
+func TestFullTextWhere(t *testing.T) {
+	t.Run("Notes", func(t *testing.T) {
+		where, values := FullTextWhere(FullTextNotes, "berlin")
+		assert.Contains(t, where, "photo_search")
+		assert.Len(t, values, 1)
+	})
+	t.Run("Search", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Ocr = "welcome"
+		frm.Count = 10
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+}
+
+func TestFullTextJoin(t *testing.T) {
+	join, values := FullTextJoin("berlin")
+	assert.Contains(t, join, "fts.photo_id = photos.id")
+	assert.NotEmpty(t, values)
+}
+
+func TestPostgresDocument(t *testing.T) {
+	assert.Equal(t, "document", postgresDocument(FullTextAll))
+	assert.Equal(t, "notes_document", postgresDocument(FullTextNotes))
+	assert.Equal(t, "ocr_document", postgresDocument(FullTextOcr))
+}
@@ -4,12 +4,23 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/entity"
+
 	"github.com/photoprism/photoprism/pkg/sanitize"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/jinzhu/inflection"
 )
 
+// likeOp returns the case-insensitive pattern matching operator of the database dialect.
+func likeOp() string {
+	if entity.DbDialect() == entity.Postgres {
+		return "ILIKE"
+	}
+
+	return "LIKE"
+}
+
 // Like escapes a string for use in a query.
 func Like(s string) string {
 	return strings.Trim(sanitize.SqlString(s), " |&*%")
@@ -49,9 +60,9 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 
 		for _, w := range words {
 			if wildcardThreshold > 0 && len(w) >= wildcardThreshold {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s%%'", col, Like(w)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s%%'", col, likeOp(), Like(w)))
 			} else {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s'", col, Like(w)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s'", col, likeOp(), Like(w)))
 			}
 
 			if !keywords || !txt.ContainsASCIILetters(w) {
@@ -61,7 +72,7 @@ func LikeAny(col, s string, keywords, exact bool) (wheres []string) {
 			singular := inflection.Singular(w)
 
 			if singular != w {
-				orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s'", col, Like(singular)))
+				orWheres = append(orWheres, fmt.Sprintf("%s %s '%s'", col, likeOp(), Like(singular)))
 			}
 		}
 
@@ -108,9 +119,9 @@ func LikeAll(col, s string, keywords, exact bool) (wheres []string) {
 
 	for _, w := range words {
 		if wildcardThreshold > 0 && len(w) >= wildcardThreshold {
-			wheres = append(wheres, fmt.Sprintf("%s LIKE '%s%%'", col, Like(w)))
+			wheres = append(wheres, fmt.Sprintf("%s %s '%s%%'", col, likeOp(), Like(w)))
 		} else {
-			wheres = append(wheres, fmt.Sprintf("%s LIKE '%s'", col, Like(w)))
+			wheres = append(wheres, fmt.Sprintf("%s %s '%s'", col, likeOp(), Like(w)))
 		}
 	}
 
@@ -145,9 +156,9 @@ func LikeAllNames(cols Cols, s string) (wheres []string) {
 
 			for _, c := range cols {
 				if strings.Contains(w, txt.Space) {
-					orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%s%%'", c, Like(w)))
+					orWheres = append(orWheres, fmt.Sprintf("%s %s '%s%%'", c, likeOp(), Like(w)))
 				} else {
-					orWheres = append(orWheres, fmt.Sprintf("%s LIKE '%%%s%%'", c, Like(w)))
+					orWheres = append(orWheres, fmt.Sprintf("%s %s '%%%s%%'", c, likeOp(), Like(w)))
 				}
 			}
 		}
@@ -250,7 +261,7 @@ func OrLike(col, s string) (where string, values []interface{}) {
 		values[i] = terms[i]
 	}
 
-	like := fmt.Sprintf("%s LIKE ?", col)
+	like := fmt.Sprintf("%s %s ?", col, likeOp())
 	where = like + strings.Repeat(" OR "+like, len(terms)-1)
 
 	return where, values
@@ -3,6 +3,7 @@ package search
 import (
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -23,7 +24,7 @@ func PhotosSlim(f form.SearchPhotosSlim) (results PhotoResultsSlim, count int, e
 	s := UnscopedDb()
 	s = s.Table("photos").
 		Select(`photos.photo_uid, photos.taken_at, files.file_hash ,photos.photo_type, photos.photo_name, photos.photo_title`).
-		Where("+photos.deleted_at is NULL")
+		Where(NoIndex("photos.deleted_at") + " IS NULL")
 
 	switch f.Order {
 	case entity.SortOrderOldest:
@@ -37,16 +38,20 @@ func PhotosSlim(f form.SearchPhotosSlim) (results PhotoResultsSlim, count int, e
 			s = s.Where("photos.photo_path = ?", f.Path)
 		} else {
 			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = photos.photo_uid").
-				Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", f.Album)
+				Where("photos_albums.hidden = FALSE AND photos_albums.album_uid = ?", f.Album).
+				Where("photos.photo_uid NOT IN (" + PendingUploads + ")")
 		}
 	}
 	if f.Subject != "" {
-		s = s.Joins("CROSS JOIN files ON photos.id = files.photo_id AND files.file_primary = 1")
+		s = s.Joins("CROSS JOIN files ON photos.id = files.photo_id AND files.file_primary = TRUE")
 		s = s.Where("files.file_uid in (select file_uid from markers m where m.subj_uid = ?)", f.Subject)
 	} else {
-		s = s.Joins("CROSS JOIN files ON photos.id = files.photo_id AND files.file_primary = 1")
+		s = s.Joins("CROSS JOIN files ON photos.id = files.photo_id AND files.file_primary = TRUE")
 	}
 
+	// Limit results to pictures the user may access?
+	s = ScopePhotos(s, f.Scope)
+
 	if !f.Before.IsZero() {
 		s = s.Where("photos.taken_at <= ?", f.Before.Format("2006-01-02"))
 	}
@@ -56,11 +61,17 @@ func PhotosSlim(f form.SearchPhotosSlim) (results PhotoResultsSlim, count int, e
 	}
 
 	if f.Notes != "" {
-		s = s.Where("photos.id in (select rowid from photo_search where notes match jieba_query(?))", f.Notes)
+		where, values := FullTextWhere(FullTextNotes, f.Notes)
+		s = s.Where(where, values...)
+	}
+
+	if f.Ocr != "" {
+		where, values := FullTextWhere(FullTextOcr, f.Ocr)
+		s = s.Where(where, values...)
 	}
 
 	if txt.NotEmpty(f.Country) {
-		s = s.Where("+photos.photo_country IN (?)", strings.Split(strings.ToLower(f.Country), txt.Or))
+		s = s.Where(NoIndex("photos.photo_country")+" IN (?)", strings.Split(strings.ToLower(f.Country), txt.Or))
 	}
 
 	if txt.NotEmpty(f.State) {
@@ -123,31 +134,54 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 		s = s.Limit(MaxResults).Offset(f.Offset)
 	}
 
-	// Sort order.
+	// Sort order, see below for relevance.
 	switch f.Order {
 	case entity.SortOrderNewest:
 		s = s.Order("photos.taken_at desc")
 	case entity.SortOrderOldest:
 		s = s.Order("photos.taken_at")
+	case entity.SortOrderRelevance:
 	default:
 		s = s.Order("photos.taken_at desc")
 	}
 
+	// Search notes and text found by OCR?
 	if f.Notes != "" {
-		var pids []uint
-		if err = UnscopedDb().
-			Raw("select rowid from photo_search where notes match jieba_query(?) order by rank limit 100", f.Notes).
-			Pluck("rowid", &pids).Error; err != nil {
-			return results, 0, err
+		where, values := FullTextWhere(FullTextNotes, f.Notes)
+		s = s.Where(where, values...)
+	}
+
+	// Search text found by OCR only?
+	if f.Ocr != "" {
+		where, values := FullTextWhere(FullTextOcr, f.Ocr)
+		s = s.Where(where, values...)
+	}
+
+	// Find photos matching a description? They are ranked by similarity after the query,
+	// so that the result offset and count are applied later.
+	var semantic map[uint]float64
+
+	if f.Semantic != "" {
+		matches, err := Semantic(f.Semantic, SemanticLimit)
+
+		if err != nil {
+			return PhotoResults{}, 0, err
+		} else if len(matches) == 0 {
+			return PhotoResults{}, 0, nil
 		}
-		s = s.Where("photos.id in (?)", pids)
+
+		semantic = matches.Scores()
+		s = s.Where("photos.id IN (?)", matches.IDs()).Limit(MaxResults).Offset(0)
 	}
 
 	// Primary files only?
 	if f.Primary {
-		s = s.Where("files.file_primary = 1")
+		s = s.Where("files.file_primary = TRUE")
 	}
 
+	// Limit results to pictures the user may access?
+	s = ScopePhotos(s, f.Scope)
+
 	if txt.NotEmpty(f.UID) {
 		s = s.Where("photos.photo_uid IN (?)", strings.Split(strings.ToLower(f.UID), txt.Or))
 
@@ -169,6 +203,7 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 	}
 
 	// Filter by label, label category and keywords.
+	var ranked, grouped bool
 	var categories []entity.Category
 	var labels []entity.Label
 	var labelIds []uint
@@ -190,7 +225,8 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 			}
 
 			s = s.Joins("JOIN photos_labels ON photos_labels.photo_id = files.photo_id AND photos_labels.uncertainty < 100 AND photos_labels.label_id IN (?)", labelIds).
-				Group("photos.id, files.id")
+				Group("photos.id, files.id, cameras.id, lenses.id, places.id")
+			grouped = true
 		}
 	}
 
@@ -249,11 +285,15 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 			s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
 		}
 	} else if f.Query != "" {
+		var conditions []string
+		var values []interface{}
+
 		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
 			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
 
 			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
-				s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+				conditions = append(conditions, "files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))")
+				values = append(values, gorm.Expr(where))
 			}
 		} else {
 			for _, l := range labels {
@@ -270,13 +310,52 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 
 			if wheres := LikeAnyKeyword("k.keyword", f.Query); len(wheres) > 0 {
 				for _, where := range wheres {
-					s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
-						"files.photo_id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", gorm.Expr(where), labelIds)
+					conditions = append(conditions, "files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
+						"files.photo_id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))")
+					values = append(values, gorm.Expr(where), labelIds)
 				}
 			} else {
-				s = s.Where("files.photo_id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))", labelIds)
+				conditions = append(conditions, "files.photo_id IN (SELECT pl.photo_id FROM photos_labels pl WHERE pl.uncertainty < 100 AND pl.label_id IN (?))")
+				values = append(values, labelIds)
 			}
 		}
+
+		where := ""
+
+		if len(conditions) > 0 {
+			where = "(" + strings.Join(conditions, ") AND (") + ")"
+		}
+
+		// Photos found in the full-text index are ranked, others may still match by keyword or label.
+		if FullTextSupported() {
+			join, joinValues := FullTextJoin(f.Query)
+			s = s.Joins(join, joinValues...)
+			ranked = true
+
+			if where == "" {
+				where = "fts.photo_id IS NOT NULL"
+			} else {
+				where = "fts.photo_id IS NOT NULL OR " + where
+			}
+		}
+
+		if where != "" {
+			s = s.Where(where, values...)
+		}
+	}
+
+	// Sort by full-text search rank, if any. Photos that only match by keyword or label come last.
+	if f.Order == entity.SortOrderRelevance {
+		if ranked {
+			s = s.Order("COALESCE(fts.rank, 0), photos.taken_at desc")
+
+			// The rank must be grouped as well if photos are filtered by label, see above.
+			if grouped {
+				s = s.Group("photos.id, files.id, cameras.id, lenses.id, places.id, fts.rank")
+			}
+		} else {
+			s = s.Order("photos.taken_at desc")
+		}
 	}
 
 	// Search for one or more keywords?
@@ -301,17 +380,17 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 	// Filter for specific face clusters? Example: PLJ7A3G4MBGZJRMVDIUCBLC46IAP4N7O
 	if len(f.Face) >= 32 {
 		for _, f := range strings.Split(strings.ToUpper(f.Face), txt.And) {
-			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE face_id IN (?))",
+			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = FALSE WHERE face_id IN (?))",
 				entity.Marker{}.TableName()), strings.Split(f, txt.Or))
 		}
 	} else if txt.New(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
+		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = FALSE AND m.marker_type = ? WHERE subj_uid IS NULL OR subj_uid = '')",
 			entity.Marker{}.TableName()), entity.MarkerFace)
 	} else if txt.No(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NULL OR face_id = '')",
+		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = FALSE AND m.marker_type = ? WHERE face_id IS NULL OR face_id = '')",
 			entity.Marker{}.TableName()), entity.MarkerFace)
 	} else if txt.Yes(f.Face) {
-		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE face_id IS NOT NULL AND face_id <> '')",
+		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = FALSE AND m.marker_type = ? WHERE face_id IS NOT NULL AND face_id <> '')",
 			entity.Marker{}.TableName()), entity.MarkerFace)
 	}
 
@@ -319,16 +398,16 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 	if txt.NotEmpty(f.Subject) {
 		for _, subj := range strings.Split(strings.ToLower(f.Subject), txt.And) {
 			if subjects := strings.Split(subj, txt.Or); rnd.ContainsUIDs(subjects, 'j') {
-				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE subj_uid IN (?))",
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = FALSE WHERE subj_uid IN (?))",
 					entity.Marker{}.TableName()), subjects)
 			} else {
-				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = FALSE JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
 					entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(AnySlug("s.subj_slug", subj, txt.Or)))
 			}
 		}
 	} else if txt.NotEmpty(f.Subjects) {
 		for _, where := range LikeAllNames(Cols{"subj_name", "subj_alias"}, f.Subjects) {
-			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
+			s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = FALSE JOIN %s s ON s.subj_uid = m.subj_uid WHERE (?))",
 				entity.Marker{}.TableName(), entity.Subject{}.TableName()), gorm.Expr(where))
 		}
 	}
@@ -340,11 +419,11 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 		s = s.Where("photos.photo_quality > -1")
 		s = s.Where("photos.deleted_at IS NOT NULL")
 	} else {
-		s = s.Where("+photos.deleted_at is NULL")
+		s = s.Where(NoIndex("photos.deleted_at") + " IS NULL")
 		if f.Private {
-			s = s.Where("photos.photo_private = 1")
+			s = s.Where("photos.photo_private = TRUE")
 		} else if f.Public {
-			s = s.Where("photos.photo_private = 0")
+			s = s.Where("photos.photo_private = FALSE")
 		}
 	}
 
@@ -386,22 +465,22 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 
 	// Find favorites only?
 	if f.Favorite {
-		s = s.Where("photos.photo_favorite = 1")
+		s = s.Where("photos.photo_favorite = TRUE")
 	}
 
 	// Find scans only?
 	if f.Scan {
-		s = s.Where("photos.photo_scan = 1")
+		s = s.Where("photos.photo_scan = TRUE")
 	}
 
 	// Find panoramas only?
 	if f.Panorama {
-		s = s.Where("photos.photo_panorama = 1")
+		s = s.Where("photos.photo_panorama = TRUE")
 	}
 
 	// Find portraits only?
 	if f.Portrait {
-		s = s.Where("files.file_portrait = 1")
+		s = s.Where("files.file_portrait = TRUE")
 	}
 
 	if f.Stackable {
@@ -544,19 +623,20 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 	// Filter by album?
 	if rnd.IsPPID(f.Album, 'a') {
 		if f.Filter != "" {
-			s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", f.Album)
+			s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = TRUE AND pa.album_uid = ?)", f.Album)
 		} else {
 			s = s.Joins("JOIN photos_albums ON photos_albums.photo_uid = files.photo_uid").
-				Where("photos_albums.hidden = 0 AND photos_albums.album_uid = ?", f.Album)
+				Where("photos_albums.hidden = FALSE AND photos_albums.album_uid = ?", f.Album).
+				Where("files.photo_uid NOT IN (" + PendingUploads + ")")
 		}
 	} else if f.Unsorted && f.Filter == "" {
-		s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 0)")
+		s = s.Where("files.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = FALSE)")
 	} else if txt.NotEmpty(f.Album) {
 		v := strings.Trim(f.Album, "*%") + "%"
-		s = s.Where("files.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (a.album_title LIKE ? OR a.album_slug LIKE ?))", v, v)
+		s = s.Where("files.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = FALSE WHERE (a.album_title LIKE ? OR a.album_slug LIKE ?))", v, v)
 	} else if txt.NotEmpty(f.Albums) {
 		for _, where := range LikeAnyWord("a.album_title", f.Albums) {
-			s = s.Where("files.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (?))", gorm.Expr(where))
+			s = s.Where("files.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = FALSE WHERE (?))", gorm.Expr(where))
 		}
 	}
 
@@ -564,6 +644,10 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 		return results, 0, err
 	}
 
+	if semantic != nil {
+		results = semanticResults(results, semantic, f)
+	}
+
 	log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
 
 	results.PreventMarkers()
@@ -574,3 +658,31 @@ func searchPhotos(f form.SearchPhotos, resultCols string) (results PhotoResults,
 
 	return results, len(results), nil
 }
+
+// semanticResults sorts the results by similarity, unless another order was requested, and
+// returns the requested page.
+func semanticResults(results PhotoResults, scores map[uint]float64, f form.SearchPhotos) PhotoResults {
+	if f.Order == "" || f.Order == entity.SortOrderRelevance {
+		sort.SliceStable(results, func(i, j int) bool {
+			return scores[results[i].ID] > scores[results[j].ID]
+		})
+	}
+
+	count := f.Count
+
+	if count <= 0 || count > MaxResults {
+		count = MaxResults
+	}
+
+	if f.Offset >= len(results) {
+		return PhotoResults{}
+	} else if f.Offset > 0 {
+		results = results[f.Offset:]
+	}
+
+	if len(results) > count {
+		results = results[:count]
+	}
+
+	return results
+}
@@ -17,6 +17,7 @@ type Photo struct {
 	CompositeID      string        `json:"ID" select:"files.photo_id AS composite_id"`
 	UUID             string        `json:"DocumentID,omitempty" select:"photos.uuid"`
 	PhotoUID         string        `json:"UID" select:"photos.photo_uid"`
+	OwnerUID         string        `json:"OwnerUID,omitempty" select:"photos.owner_uid"`
 	PhotoType        string        `json:"Type" select:"photos.photo_type"`
 	TypeSrc          string        `json:"TypeSrc" select:"photos.taken_src"`
 	TakenAt          time.Time     `json:"TakenAt" select:"photos.taken_at"`
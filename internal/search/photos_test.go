@@ -197,6 +197,72 @@ func TestPhotos(t *testing.T) {
 
 		assert.LessOrEqual(t, 1, len(photos))
 	})
+	t.Run("search for ocr text in query", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "welcome"
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Order = entity.SortOrderRelevance
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photos[0].PhotoUID)
+	})
+	t.Run("search with offset beyond first page", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "bridge"
+		frm.Count = 1
+		frm.Offset = 1
+		frm.Order = entity.SortOrderRelevance
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("search by relevance with label filter", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "flower"
+		frm.Label = "flower"
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Order = entity.SortOrderRelevance
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
+	t.Run("search for keyword with relevance", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "kuh"
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Order = entity.SortOrderRelevance
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(photos))
+	})
 	t.Run("search for archived", func(t *testing.T) {
 		var f form.SearchPhotos
 
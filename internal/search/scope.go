@@ -0,0 +1,61 @@
+package search
+
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// PendingUploads selects the UIDs of photos uploaded with a moderated link that have not been approved yet.
+const PendingUploads = "SELECT lu.photo_uid FROM links_uploads lu WHERE lu.review = TRUE"
+
+// ScopePhotos limits a photo search query to pictures the user may access.
+func ScopePhotos(s *gorm.DB, scope form.SearchScope) *gorm.DB {
+	switch {
+	case scope.Empty():
+		return s
+	case scope.Library:
+		return s.Where("photos.owner_uid = ? OR photos.photo_private = FALSE", scope.Owner)
+	case len(scope.Shared) > 0:
+		return s.Where("photos.owner_uid = ? OR photos.photo_private = FALSE AND photos.photo_uid IN "+
+			"(SELECT sa.photo_uid FROM photos_albums sa WHERE sa.hidden = FALSE AND (sa.album_uid IN (?) OR sa.album_uid IN "+
+			"(SELECT sm.album_uid FROM album_members sm WHERE sm.user_uid = ?)) AND sa.photo_uid NOT IN ("+PendingUploads+"))", scope.Owner, scope.Shared, scope.Owner)
+	default:
+		return s.Where("photos.owner_uid = ? OR photos.photo_private = FALSE AND photos.photo_uid IN "+
+			"(SELECT sa.photo_uid FROM photos_albums sa WHERE sa.hidden = FALSE AND sa.album_uid IN "+
+			"(SELECT sm.album_uid FROM album_members sm WHERE sm.user_uid = ?) AND sa.photo_uid NOT IN ("+PendingUploads+"))", scope.Owner, scope.Owner)
+	}
+}
+
+// ScopeAlbums limits an album search query to albums the user may access.
+func ScopeAlbums(s *gorm.DB, scope form.SearchScope) *gorm.DB {
+	if scope.Empty() {
+		return s
+	}
+
+	where := "albums.owner_uid = ? OR albums.album_uid IN (SELECT sm.album_uid FROM album_members sm WHERE sm.user_uid = ?)"
+	values := []interface{}{scope.Owner, scope.Owner}
+
+	if len(scope.Shared) > 0 {
+		where += " OR albums.album_uid IN (?)"
+		values = append(values, scope.Shared)
+	}
+
+	if scope.Library {
+		where += " OR albums.album_private = FALSE"
+	}
+
+	return s.Where(where, values...)
+}
+
+// ScopeLabels limits a label search query to labels the user owns or that were assigned to their pictures.
+func ScopeLabels(s *gorm.DB, scope form.SearchScope) *gorm.DB {
+	switch {
+	case scope.Empty(), scope.Library:
+		return s
+	default:
+		return s.Where("labels.owner_uid = ? OR labels.id IN "+
+			"(SELECT sl.label_id FROM photos_labels sl JOIN photos sp ON sp.id = sl.photo_id WHERE sp.owner_uid = ? AND sl.uncertainty < 100)",
+			scope.Owner, scope.Owner)
+	}
+}
@@ -0,0 +1,182 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestScopePhotos(t *testing.T) {
+	alice := entity.UserFixtures.Get("alice").UserUID
+	bob := entity.UserFixtures.Get("bob").UserUID
+
+	t.Run("Owner", func(t *testing.T) {
+		f := form.SearchPhotos{Count: 100, Merged: true, Scope: form.SearchScope{Owner: bob}}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		uids := photos.UIDs()
+
+		// Bob is a member of album "at9lxuqxpogaaba8".
+		assert.Contains(t, uids, "pt9jtdre2lvl0y18")
+		assert.Contains(t, uids, "pt9jtdre2lvl0yh7")
+
+		for _, p := range photos {
+			if p.OwnerUID != bob {
+				assert.False(t, p.PhotoPrivate)
+			}
+		}
+	})
+	t.Run("OwnerUID", func(t *testing.T) {
+		f := form.SearchPhotos{UID: "pt9jtdre2lvl0yh7", Count: 10, Scope: form.SearchScope{Owner: alice}}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("Shared", func(t *testing.T) {
+		f := form.SearchPhotos{Count: 100, Merged: true, Scope: form.SearchScope{Owner: alice, Shared: []string{"at9lxuqxpogaaba8"}}}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		uids := photos.UIDs()
+
+		assert.NotContains(t, uids, "pt9jtdre2lvl0y18")
+		assert.Contains(t, uids, "pt9jtdre2lvl0yh7")
+
+		for _, p := range photos {
+			assert.False(t, p.PhotoPrivate)
+		}
+	})
+	t.Run("PendingUpload", func(t *testing.T) {
+		upload := entity.LinkUpload{PhotoUID: "pt9jtdre2lvl0yh7", Review: true}
+
+		if err := entity.Db().Create(&upload).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.Db().Delete(&upload)
+
+		f := form.SearchPhotos{Count: 100, Merged: true, Scope: form.SearchScope{Owner: alice, Shared: []string{"at9lxuqxpogaaba8"}}}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, photos.UIDs(), "pt9jtdre2lvl0yh7")
+	})
+	t.Run("Library", func(t *testing.T) {
+		f := form.SearchPhotos{Count: 1000, Merged: true, Scope: form.SearchScope{Owner: bob, Library: true}}
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, len(photos), 2)
+
+		for _, p := range photos {
+			if p.OwnerUID != bob {
+				assert.False(t, p.PhotoPrivate)
+			}
+		}
+	})
+}
+
+func TestScopeAlbums(t *testing.T) {
+	alice := entity.UserFixtures.Get("alice").UserUID
+	bob := entity.UserFixtures.Get("bob").UserUID
+
+	t.Run("Owner", func(t *testing.T) {
+		f := form.SearchAlbums{Count: 100, Scope: form.SearchScope{Owner: alice}}
+
+		albums, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, albums, 0)
+	})
+	t.Run("Member", func(t *testing.T) {
+		f := form.SearchAlbums{Count: 100, Scope: form.SearchScope{Owner: bob, Member: bob}}
+
+		albums, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, albums, 3)
+
+		roles := make(map[string]string, len(albums))
+
+		for _, a := range albums {
+			roles[a.AlbumUID] = a.MemberRole
+		}
+
+		assert.Equal(t, "", roles["at9lxuqxpogaaba9"])
+		assert.Equal(t, entity.MemberRoleViewer, roles["at9lxuqxpogaaba7"])
+		assert.Equal(t, entity.MemberRoleContributor, roles["at9lxuqxpogaaba8"])
+	})
+	t.Run("Shared", func(t *testing.T) {
+		f := form.SearchAlbums{Count: 100, Scope: form.SearchScope{Owner: alice, Shared: []string{"at9lxuqxpogaaba8"}}}
+
+		albums, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, albums, 1)
+	})
+	t.Run("Library", func(t *testing.T) {
+		f := form.SearchAlbums{Count: 1000, Scope: form.SearchScope{Owner: bob, Library: true}}
+
+		albums, err := Albums(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, len(albums), 2)
+	})
+}
+
+func TestScopeLabels(t *testing.T) {
+	t.Run("Owner", func(t *testing.T) {
+		f := form.SearchLabels{Count: 1000, Scope: form.SearchScope{Owner: entity.UserFixtures.Get("bob").UserUID}}
+
+		labels, err := Labels(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		all, err := Labels(form.SearchLabels{Count: 1000})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Less(t, len(labels), len(all))
+	})
+}
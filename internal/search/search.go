@@ -65,3 +65,13 @@ func Log(action string, err error) {
 		log.Errorf("search: %s (%s)", err, action)
 	}
 }
+
+// NoIndex returns the column name with a unary "+" operator for SQLite, so that the
+// query planner does not use an index on the column if there is a better one.
+func NoIndex(col string) string {
+	if entity.DbDialect() == entity.SQLite3 {
+		return "+" + col
+	}
+
+	return col
+}
@@ -0,0 +1,250 @@
+package search
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/clip"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// SemanticThreshold is the minimum cosine similarity of photos found by semantic search.
+const SemanticThreshold = 0.2
+
+// SemanticLimit is the maximum number of photos found by semantic search.
+const SemanticLimit = 1000
+
+var semanticModel clip.Model = clip.NewNone()
+var semanticMutex = sync.RWMutex{}
+
+// SetSemanticModel sets the model used to compute the embeddings of semantic search queries.
+func SetSemanticModel(m clip.Model) {
+	if m == nil {
+		return
+	}
+
+	semanticMutex.Lock()
+	defer semanticMutex.Unlock()
+
+	semanticModel = m
+}
+
+// SemanticMatch represents a photo and its similarity with a search query.
+type SemanticMatch struct {
+	PhotoID uint
+	Score   float64
+}
+
+// SemanticMatches represents a list of photos sorted by descending similarity.
+type SemanticMatches []SemanticMatch
+
+// IDs returns the photo IDs.
+func (m SemanticMatches) IDs() []uint {
+	result := make([]uint, len(m))
+
+	for i := range m {
+		result[i] = m[i].PhotoID
+	}
+
+	return result
+}
+
+// Scores returns the similarity scores by photo ID.
+func (m SemanticMatches) Scores() map[uint]float64 {
+	result := make(map[uint]float64, len(m))
+
+	for i := range m {
+		result[m[i].PhotoID] = m[i].Score
+	}
+
+	return result
+}
+
+// Semantic finds the photos most similar to a description, e.g. "dog on a beach".
+func Semantic(description string, limit int) (SemanticMatches, error) {
+	semanticMutex.RLock()
+	model := semanticModel
+	semanticMutex.RUnlock()
+
+	if model.Name() == clip.EngineNone {
+		return SemanticMatches{}, fmt.Errorf("semantic search disabled")
+	}
+
+	r, err := model.Text(description)
+
+	if err != nil {
+		return SemanticMatches{}, err
+	}
+
+	return vectors.Search(r.Model, r.Embedding, SemanticThreshold, limit)
+}
+
+// vectors caches the photo embeddings of the current model.
+var vectors = &vectorIndex{}
+
+// vectorIndex keeps quantized photo embeddings in memory for a brute-force nearest neighbor search,
+// which needs about 100 MB for 200k photos with 512 dimensions.
+type vectorIndex struct {
+	mutex   sync.Mutex
+	model   string
+	version string
+	dim     int
+	ids     []uint
+	data    []int8
+}
+
+// vectorStats is used to check if the cached embeddings are up to date.
+type vectorStats struct {
+	Count   int
+	Updated *string
+}
+
+// refresh reloads the embeddings of the model if they have changed.
+func (idx *vectorIndex) refresh(model string) error {
+	stats := vectorStats{}
+
+	if err := UnscopedDb().Table(entity.PhotoEmbedding{}.TableName()).
+		Select("COUNT(*) AS count, MAX(updated_at) AS updated").
+		Where("embedding_model = ?", model).
+		Scan(&stats).Error; err != nil {
+		return err
+	}
+
+	version := fmt.Sprintf("%d", stats.Count)
+
+	if stats.Updated != nil {
+		version += " " + *stats.Updated
+	}
+
+	if idx.model == model && idx.version == version {
+		return nil
+	}
+
+	rows, err := UnscopedDb().Table(entity.PhotoEmbedding{}.TableName()).
+		Select("photo_id, embedding").
+		Where("embedding_model = ?", model).
+		Rows()
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	idx.model, idx.version, idx.dim = model, "", 0
+	idx.ids = make([]uint, 0, stats.Count)
+	idx.data = idx.data[:0]
+
+	for rows.Next() {
+		var id uint
+		var b []byte
+
+		if err = rows.Scan(&id, &b); err != nil {
+			return err
+		}
+
+		v := clip.Embedding(entity.DecodeVector(b))
+
+		if idx.dim == 0 {
+			idx.dim = len(v)
+		} else if len(v) != idx.dim {
+			log.Warnf("search: embedding of photo %d has %d dimensions, expected %d", id, len(v), idx.dim)
+			continue
+		}
+
+		idx.ids = append(idx.ids, id)
+		idx.data = append(idx.data, v.Quantize()...)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	idx.version = version
+
+	log.Debugf("search: loaded %d %s embeddings", len(idx.ids), model)
+
+	return nil
+}
+
+// Search returns the photos with a cosine similarity of at least the threshold.
+func (idx *vectorIndex) Search(model string, query clip.Embedding, threshold float64, limit int) (SemanticMatches, error) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if err := idx.refresh(model); err != nil {
+		return SemanticMatches{}, err
+	}
+
+	if len(idx.ids) == 0 {
+		return SemanticMatches{}, nil
+	} else if len(query) != idx.dim {
+		return SemanticMatches{}, fmt.Errorf("query has %d dimensions, expected %d", len(query), idx.dim)
+	}
+
+	q := query.Quantize()
+	n := len(idx.ids)
+	scores := make([]int32, n)
+
+	// Compare the query with all photos, using one goroutine per CPU core.
+	workers := runtime.NumCPU()
+	size := (n + workers - 1) / workers
+
+	var wg sync.WaitGroup
+
+	for start := 0; start < n; start += size {
+		end := start + size
+
+		if end > n {
+			end = n
+		}
+
+		wg.Add(1)
+
+		go func(start, end int) {
+			defer wg.Done()
+
+			for i := start; i < end; i++ {
+				v := idx.data[i*idx.dim : (i+1)*idx.dim]
+
+				var dot int32
+
+				for j := range v {
+					dot += int32(v[j]) * int32(q[j])
+				}
+
+				scores[i] = dot
+			}
+		}(start, end)
+	}
+
+	wg.Wait()
+
+	// Both vectors are scaled by 127, see clip.Embedding.Quantize.
+	const scale = 127 * 127
+	minScore := int32(threshold * scale)
+
+	result := make(SemanticMatches, 0, 64)
+
+	for i, score := range scores {
+		if score >= minScore {
+			result = append(result, SemanticMatch{PhotoID: idx.ids[i], Score: float64(score) / scale})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score == result[j].Score {
+			return result[i].PhotoID < result[j].PhotoID
+		}
+
+		return result[i].Score > result[j].Score
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/clip"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// testModel returns fixed text embeddings, see entity.PhotoEmbeddingFixtures.
+type testModel struct{}
+
+func (m testModel) Name() string {
+	return "test"
+}
+
+func (m testModel) Image(fileName string) (clip.Result, error) {
+	return clip.Result{}, nil
+}
+
+func (m testModel) Text(query string) (clip.Result, error) {
+	switch query {
+	case "welcome sign":
+		return clip.Result{Model: "test", Embedding: clip.Embedding{0.9, 0.1, 0, 0}.Normalize()}, nil
+	case "beach":
+		return clip.Result{Model: "test", Embedding: clip.Embedding{0, 0, 1, 0}}, nil
+	default:
+		return clip.Result{Model: "test", Embedding: clip.Embedding{0, 0, 0, 1}}, nil
+	}
+}
+
+func TestSemantic(t *testing.T) {
+	SetSemanticModel(testModel{})
+	defer SetSemanticModel(clip.NewNone())
+
+	t.Run("Sign", func(t *testing.T) {
+		matches, err := Semantic("welcome sign", 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, matches, 1)
+		assert.Equal(t, entity.PhotoEmbeddingFixtures["sign"].PhotoID, matches[0].PhotoID)
+		assert.InDelta(t, 0.99, matches[0].Score, 0.01)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		matches, err := Semantic("car", 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		SetSemanticModel(clip.NewNone())
+		defer SetSemanticModel(testModel{})
+
+		_, err := Semantic("welcome sign", 10)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSemanticMatches(t *testing.T) {
+	m := SemanticMatches{{PhotoID: 2, Score: 0.5}, {PhotoID: 1, Score: 0.25}}
+
+	assert.Equal(t, []uint{2, 1}, m.IDs())
+	assert.Equal(t, map[uint]float64{2: 0.5, 1: 0.25}, m.Scores())
+}
+
+func TestVectorIndex_Search(t *testing.T) {
+	idx := &vectorIndex{}
+
+	t.Run("Ranked", func(t *testing.T) {
+		matches, err := idx.Search("test", clip.Embedding{0.5, 0.5, 0.5, 0}.Normalize(), 0.1, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, matches, 2)
+		assert.Equal(t, entity.PhotoEmbeddingFixtures["beach"].PhotoID, matches[0].PhotoID)
+		assert.Greater(t, matches[0].Score, matches[1].Score)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		matches, err := idx.Search("test", clip.Embedding{0.5, 0.5, 0.5, 0}.Normalize(), 0.1, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, matches, 1)
+	})
+	t.Run("Dimensions", func(t *testing.T) {
+		_, err := idx.Search("test", clip.Embedding{1, 0}, 0.1, 0)
+
+		assert.Error(t, err)
+	})
+	t.Run("OtherModel", func(t *testing.T) {
+		matches, err := idx.Search("foo", clip.Embedding{1, 0, 0, 0}, 0.1, 0)
+
+		assert.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+}
+
+func TestPhotosSemantic(t *testing.T) {
+	SetSemanticModel(testModel{})
+	defer SetSemanticModel(clip.NewNone())
+
+	t.Run("Query", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = `~"welcome sign"`
+		frm.Count = 10
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, "pt9jtdre2lvl0yh7", photos[0].PhotoUID)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Semantic = "welcome sign"
+		frm.Count = 10
+		frm.Offset = 1
+
+		photos, _, err := Photos(frm)
+
+		assert.NoError(t, err)
+		assert.Empty(t, photos)
+	})
+	t.Run("NoMatch", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Semantic = "car"
+		frm.Count = 10
+
+		photos, _, err := Photos(frm)
+
+		assert.NoError(t, err)
+		assert.Empty(t, photos)
+	})
+}
@@ -0,0 +1,217 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/phash"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SimilarDistance is the default maximum Hamming distance between the perceptual hashes of similar photos.
+const SimilarDistance = 12
+
+// DuplicateDistance is the maximum Hamming distance between the perceptual hashes of near-duplicates.
+const DuplicateDistance = 4
+
+// SimilarLimit is the maximum number of similar photos returned.
+const SimilarLimit = 1000
+
+// SimilarPhoto represents a photo that looks similar to another photo.
+type SimilarPhoto struct {
+	Photo
+	Distance  int  `json:"Distance"`
+	Duplicate bool `json:"Duplicate"`
+}
+
+// SimilarPhotos represents a list of similar photos sorted by ascending distance.
+type SimilarPhotos []SimilarPhoto
+
+// PerceptualHash represents the perceptual image hashes of a primary file.
+type PerceptualHash struct {
+	PhotoID         uint
+	PhotoUID        string
+	PhotoStack      int8
+	PhotoQuality    int
+	PhotoResolution int
+	TakenAt         time.Time
+	FileUID         string
+	FileWidth       int
+	FileHeight      int
+	PHash           phash.Hash
+	DHash           phash.Hash
+}
+
+// Pixels returns the number of pixels of the primary file.
+func (h PerceptualHash) Pixels() int {
+	return h.FileWidth * h.FileHeight
+}
+
+// Distance returns the Hamming distance between two files, using the dHash distance to break ties.
+func (h PerceptualHash) Distance(other PerceptualHash) (int, int) {
+	return phash.Distance(h.PHash, other.PHash), phash.Distance(h.DHash, other.DHash)
+}
+
+// PerceptualHashes returns the perceptual image hashes of all primary files that are not archived or missing.
+func PerceptualHashes() (result []PerceptualHash, err error) {
+	var rows []struct {
+		PhotoID         uint
+		PhotoUID        string
+		PhotoStack      int8
+		PhotoQuality    int
+		PhotoResolution int
+		TakenAt         time.Time
+		FileUID         string
+		FileWidth       int
+		FileHeight      int
+		FilePHash       string `gorm:"column:file_phash"`
+		FileDHash       string `gorm:"column:file_dhash"`
+	}
+
+	if err = Db().Table("files").
+		Select("files.photo_id, files.photo_uid, photos.photo_stack, photos.photo_quality, photos.photo_resolution, photos.taken_at, " +
+			"files.file_uid, files.file_width, files.file_height, files.file_phash, files.file_dhash").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = TRUE AND files.file_missing = FALSE AND files.deleted_at IS NULL").
+		Where("files.file_phash <> '' AND files.file_dhash <> ''").
+		Order("files.photo_id").
+		Scan(&rows).Error; err != nil {
+		return result, err
+	}
+
+	result = make([]PerceptualHash, 0, len(rows))
+
+	for _, row := range rows {
+		pHash, err := phash.Parse(row.FilePHash)
+
+		if err != nil {
+			log.Debugf("search: %s in file %s", err, row.FileUID)
+			continue
+		}
+
+		dHash, err := phash.Parse(row.FileDHash)
+
+		if err != nil {
+			log.Debugf("search: %s in file %s", err, row.FileUID)
+			continue
+		}
+
+		result = append(result, PerceptualHash{
+			PhotoID:         row.PhotoID,
+			PhotoUID:        row.PhotoUID,
+			PhotoStack:      row.PhotoStack,
+			PhotoQuality:    row.PhotoQuality,
+			PhotoResolution: row.PhotoResolution,
+			TakenAt:         row.TakenAt,
+			FileUID:         row.FileUID,
+			FileWidth:       row.FileWidth,
+			FileHeight:      row.FileHeight,
+			PHash:           pHash,
+			DHash:           dHash,
+		})
+	}
+
+	return result, nil
+}
+
+// Similar finds photos that look similar to the photo with the specified UID, including near-duplicates,
+// and returns them sorted by the Hamming distance of their perceptual hashes.
+func Similar(photoUID string, maxDistance, count int) (results SimilarPhotos, err error) {
+	start := time.Now()
+
+	if maxDistance <= 0 || maxDistance > phash.Bits {
+		maxDistance = SimilarDistance
+	}
+
+	if count <= 0 || count > SimilarLimit {
+		count = SimilarLimit
+	}
+
+	hashes, err := PerceptualHashes()
+
+	if err != nil {
+		return SimilarPhotos{}, err
+	}
+
+	// Find the perceptual hash of the photo to compare with.
+	var ref *PerceptualHash
+
+	for i := range hashes {
+		if hashes[i].PhotoUID == photoUID {
+			ref = &hashes[i]
+			break
+		}
+	}
+
+	if ref == nil {
+		return SimilarPhotos{}, nil
+	}
+
+	type match struct {
+		uid   string
+		dist  int
+		dDist int
+	}
+
+	var matches []match
+
+	for _, h := range hashes {
+		if h.PhotoUID == ref.PhotoUID {
+			continue
+		}
+
+		if dist, dDist := ref.Distance(h); dist <= maxDistance {
+			matches = append(matches, match{uid: h.PhotoUID, dist: dist, dDist: dDist})
+		}
+	}
+
+	if len(matches) == 0 {
+		return SimilarPhotos{}, nil
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].dist == matches[j].dist {
+			return matches[i].dDist < matches[j].dDist
+		}
+
+		return matches[i].dist < matches[j].dist
+	})
+
+	if len(matches) > count {
+		matches = matches[:count]
+	}
+
+	uids := make([]string, len(matches))
+
+	for i := range matches {
+		uids[i] = matches[i].uid
+	}
+
+	photos, _, err := Photos(form.SearchPhotos{UID: strings.Join(uids, txt.Or), Primary: true, Count: len(uids)})
+
+	if err != nil {
+		return SimilarPhotos{}, err
+	}
+
+	found := make(map[string]Photo, len(photos))
+
+	for _, p := range photos {
+		found[p.PhotoUID] = p
+	}
+
+	results = make(SimilarPhotos, 0, len(matches))
+
+	for _, m := range matches {
+		if p, ok := found[m.uid]; ok {
+			results = append(results, SimilarPhoto{Photo: p, Distance: m.dist, Duplicate: m.dist <= DuplicateDistance})
+		}
+	}
+
+	log.Debugf("similar: found %s for %s [%s]", english.Plural(len(results), "photo", "photos"), photoUID, time.Since(start))
+
+	return results, nil
+}
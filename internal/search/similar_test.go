@@ -0,0 +1,58 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerceptualHashes(t *testing.T) {
+	hashes, err := PerceptualHashes()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(hashes), 2)
+
+	for _, h := range hashes {
+		assert.NotEmpty(t, h.PhotoUID)
+		assert.NotEmpty(t, h.FileUID)
+		assert.NotZero(t, h.PHash)
+		assert.NotZero(t, h.DHash)
+	}
+}
+
+func TestSimilar(t *testing.T) {
+	t.Run("NearDuplicate", func(t *testing.T) {
+		results, err := Similar("pt9jtdre2lvl0yh7", 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.GreaterOrEqual(t, len(results), 1) {
+			assert.Equal(t, "pt9jtdre2lvl0y11", results[0].PhotoUID)
+			assert.Equal(t, 3, results[0].Distance)
+			assert.True(t, results[0].Duplicate)
+		}
+	})
+	t.Run("MaxDistance", func(t *testing.T) {
+		results, err := Similar("pt9jtdre2lvl0yh7", 1, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("NoHash", func(t *testing.T) {
+		results, err := Similar("pt9jtdre2lvl0yh8", 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"sync"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
@@ -40,6 +42,28 @@ func GetCredentials(c *gin.Context) (username, password, raw string) {
 	return credentials[0], credentials[1], data
 }
 
+// GetBearerToken returns the bearer token from the authorization header, if any.
+func GetBearerToken(c *gin.Context) string {
+	if data := c.GetHeader("Authorization"); strings.HasPrefix(data, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(data, "Bearer "))
+	}
+
+	return ""
+}
+
+// TokenAllowsMethod tests if the personal API token scope allows the WebDAV request method.
+func TokenAllowsMethod(scope acl.Scope, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND":
+		// Upload-only tokens may list folders, but not download files.
+		return scope.Allow(acl.ActionRead) || scope.Allow(acl.ActionUpload) && method != http.MethodGet
+	case http.MethodPut, "MKCOL":
+		return scope.Allow(acl.ActionUpload)
+	default:
+		return scope == acl.ScopeFull
+	}
+}
+
 func BasicAuth() gin.HandlerFunc {
 	realm := "Authorization Required"
 	realm = "Basic realm=" + strconv.Quote(realm)
@@ -49,6 +73,29 @@ func BasicAuth() gin.HandlerFunc {
 
 		username, password, raw := GetCredentials(c)
 
+		// Personal API tokens may be used as bearer token or instead of the password.
+		// They are checked on every request, so that revoked tokens can no longer be used.
+		if secret := GetBearerToken(c); entity.IsUserToken(secret) || entity.IsUserToken(password) {
+			if secret == "" {
+				secret = password
+			}
+
+			if token := entity.FindUserToken(secret); token != nil {
+				if !TokenAllowsMethod(token.Scope(), c.Request.Method) {
+					c.AbortWithStatus(http.StatusForbidden)
+					return
+				} else if user := token.User(); user != nil {
+					token.Used()
+					c.Set(gin.AuthUserKey, user.UserUID)
+					return
+				}
+			}
+
+			c.Header("WWW-Authenticate", realm)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
 		basicAuth.mutex.Lock()
 		defer basicAuth.mutex.Unlock()
 
@@ -59,8 +106,9 @@ func BasicAuth() gin.HandlerFunc {
 
 		user := entity.FindUserByName(username)
 
+		// Passwords can't be used if two-factor authentication is enabled, see personal API tokens.
 		if user != nil {
-			invalid = user.InvalidPassword(password)
+			invalid = user.InvalidPassword(password) || user.TwoFactorEnabled()
 		}
 
 		if user == nil || invalid {
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Limiter throttles failed authentication attempts by client IP and username. Once a client exceeds the
+// allowed number of failed attempts, it must wait for a backoff period that doubles with each further
+// failure, up to the maximum lockout. Failed attempts expire if there are none for the maximum lockout.
+//
+// Failures are counted per IP and username, as well as per IP and per username, so that passwords
+// can neither be guessed from many networks nor be sprayed across accounts. The latter allow
+// LimitFactor times as many attempts, so that other users on the same network are not locked out
+// as quickly.
+type Limiter struct {
+	attempts int
+	backoff  time.Duration
+	lockout  time.Duration
+	clients  map[string]*limiterClient
+	pruned   time.Time
+	mutex    sync.Mutex
+}
+
+// LimitFactor is the multiplier of allowed attempts per IP and per username.
+const LimitFactor = 5
+
+// limiterClient represents the failed attempts of a client.
+type limiterClient struct {
+	failures int
+	until    time.Time
+	seen     time.Time
+}
+
+// NewLimiter returns a new limiter, attempts < 1 disables it.
+func NewLimiter(attempts int, backoff, lockout time.Duration) *Limiter {
+	return &Limiter{
+		attempts: attempts,
+		backoff:  backoff,
+		lockout:  lockout,
+		clients:  make(map[string]*limiterClient),
+		pruned:   time.Now(),
+	}
+}
+
+// Disabled tests if the limiter does not throttle any requests.
+func (l *Limiter) Disabled() bool {
+	return l == nil || l.attempts < 1
+}
+
+// Delay returns the remaining lockout for the client with the specified key, if any.
+func (l *Limiter) Delay(key string) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if c, ok := l.clients[key]; !ok {
+		return 0
+	} else if d := time.Until(c.until); d > 0 {
+		return d
+	}
+
+	return 0
+}
+
+// Failure records a failed attempt and returns the resulting lockout, if any.
+func (l *Limiter) Failure(key string) time.Duration {
+	return l.failure(key, l.attempts)
+}
+
+// failure records a failed attempt and returns the resulting lockout once the attempts are exceeded.
+func (l *Limiter) failure(key string, attempts int) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+
+	l.prune(now)
+
+	c, ok := l.clients[key]
+
+	if !ok || c.expired(now, l.lockout) {
+		c = &limiterClient{}
+		l.clients[key] = c
+	}
+
+	c.failures++
+	c.seen = now
+
+	if c.failures < attempts {
+		return 0
+	}
+
+	d := l.lockout
+
+	// Double the backoff with each further failure, without exceeding the maximum lockout.
+	if n := c.failures - attempts; n < 32 {
+		if b := l.backoff << uint(n); b > 0 && b < l.lockout {
+			d = b
+		}
+	}
+
+	c.until = now.Add(d)
+
+	return d
+}
+
+// Success resets the failed attempts of the client with the specified key.
+func (l *Limiter) Success(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.clients, key)
+}
+
+// Lockouts returns the number of clients that are currently locked out.
+func (l *Limiter) Lockouts() (count int) {
+	if l.Disabled() {
+		return 0
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+
+	for _, c := range l.clients {
+		if c.until.After(now) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// prune removes clients that have neither failed nor been locked out recently, at most once per minute.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < time.Minute {
+		return
+	}
+
+	l.pruned = now
+
+	for key, c := range l.clients {
+		if c.expired(now, l.lockout) {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// expired tests if the client is not locked out and has not failed within the specified duration.
+func (c *limiterClient) expired(now time.Time, d time.Duration) bool {
+	return c.until.Before(now) && now.Sub(c.seen) > d
+}
+
+// Limit returns a middleware that rejects requests from locked out clients. Requests marked with
+// api.AuthFailed count as failed attempts.
+func Limit(l *Limiter) gin.HandlerFunc {
+	return limit(l, nil)
+}
+
+// LimitLogin returns a middleware like Limit that also throttles failed attempts by the username
+// in the login form, successful logins reset the counter.
+func LimitLogin(l *Limiter) gin.HandlerFunc {
+	return limit(l, limitUserName)
+}
+
+// limit returns a middleware that throttles failed attempts by client IP and the name returned by
+// the optional function.
+func limit(l *Limiter, userName func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l.Disabled() {
+			c.Next()
+			return
+		}
+
+		ip := c.ClientIP()
+		name := ""
+
+		if userName != nil {
+			name = userName(c)
+		}
+
+		buckets := limitBuckets(ip, name)
+
+		for _, b := range buckets {
+			if d := l.Delay(b.key); d > 0 {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": i18n.Msg(i18n.ErrTooManyAttempts)})
+				return
+			}
+		}
+
+		c.Next()
+
+		if c.GetBool(api.AuthFailedKey) {
+			for _, b := range buckets {
+				if d := l.failure(b.key, l.attempts*b.factor); d > 0 {
+					log.Warnf("auth: %s locked out for %s after failed attempts", sanitize.Log(b.key), d)
+					auditLockout(ip, name, d)
+				}
+			}
+		} else if name != "" && c.Writer.Status() < http.StatusMultipleChoices {
+			// Only reset the counters of the account after a successful login, since anyone who knows
+			// a single valid share token or account could otherwise keep guessing.
+			for _, b := range buckets {
+				if b.key != ip {
+					l.Success(b.key)
+				}
+			}
+		}
+	}
+}
+
+// limitBucket represents a limiter key with the multiplier of allowed attempts.
+type limitBucket struct {
+	key    string
+	factor int
+}
+
+// limitBuckets returns the limiter keys for the client IP and username.
+func limitBuckets(ip, name string) []limitBucket {
+	if name == "" {
+		return []limitBucket{{key: ip + "/", factor: 1}}
+	}
+
+	return []limitBucket{
+		{key: ip + "/" + name, factor: 1},
+		{key: ip, factor: LimitFactor},
+		{key: "/" + name, factor: LimitFactor},
+	}
+}
+
+// limitUserName returns the username in the login form, if any. The form is parsed in the same way
+// as by the handler, which uses it afterwards.
+func limitUserName(c *gin.Context) string {
+	if c.Request.Method != http.MethodPost || c.Request.Body == nil {
+		return ""
+	}
+
+	f, err := api.BindLogin(c)
+
+	if err != nil {
+		return ""
+	}
+
+	return sanitize.Username(f.UserName)
+}
+
+// auditLockout records the lockout of a client in the audit log.
+func auditLockout(ip, name string, d time.Duration) {
+	var u *entity.User
+
+	if name != "" {
+		u = entity.FindUserByName(name)
+	}
+
+	m := entity.NewAuditLog(u, entity.AuditAuthLockout, "", entity.AuditDiff(nil, gin.H{"Lockout": d.String()}))
+
+	// Record the username even if no such user exists.
+	if u == nil {
+		m.UserName = txt.Clip(name, 64)
+	}
+
+	if err := m.SetClient("", ip).Create(); err != nil {
+		log.Errorf("auth: %s (audit lockout)", err)
+	}
+}
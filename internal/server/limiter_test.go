@@ -0,0 +1,150 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/api"
+)
+
+func TestLimiter_Failure(t *testing.T) {
+	l := NewLimiter(3, time.Second, 5*time.Second)
+
+	assert.False(t, l.Disabled())
+	assert.Equal(t, time.Duration(0), l.Failure("127.0.0.1/alice"))
+	assert.Equal(t, time.Duration(0), l.Failure("127.0.0.1/alice"))
+	assert.Equal(t, time.Second, l.Failure("127.0.0.1/alice"))
+	assert.Equal(t, 2*time.Second, l.Failure("127.0.0.1/alice"))
+	assert.Equal(t, 4*time.Second, l.Failure("127.0.0.1/alice"))
+	assert.Equal(t, 5*time.Second, l.Failure("127.0.0.1/alice"))
+	assert.Equal(t, 5*time.Second, l.Failure("127.0.0.1/alice"))
+
+	assert.Greater(t, l.Delay("127.0.0.1/alice"), time.Duration(0))
+	assert.Equal(t, time.Duration(0), l.Delay("127.0.0.1/bob"))
+	assert.Equal(t, 1, l.Lockouts())
+
+	l.Success("127.0.0.1/alice")
+
+	assert.Equal(t, time.Duration(0), l.Delay("127.0.0.1/alice"))
+	assert.Equal(t, 0, l.Lockouts())
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	var l *Limiter
+
+	assert.True(t, l.Disabled())
+	assert.Equal(t, 0, l.Lockouts())
+	assert.True(t, NewLimiter(-1, time.Second, time.Minute).Disabled())
+}
+
+func TestLimiter_Expired(t *testing.T) {
+	l := NewLimiter(2, time.Millisecond, 10*time.Millisecond)
+
+	assert.Equal(t, time.Duration(0), l.Failure("127.0.0.1"))
+
+	time.Sleep(20 * time.Millisecond)
+
+	assert.Equal(t, time.Duration(0), l.Failure("127.0.0.1"))
+	assert.Equal(t, time.Millisecond, l.Failure("127.0.0.1"))
+}
+
+func TestLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	l := NewLimiter(3, time.Minute, time.Hour)
+	r := gin.New()
+
+	r.GET("/s/:token", Limit(l), func(c *gin.Context) {
+		if c.Param("token") != "valid" {
+			api.AuthFailed(c)
+		}
+
+		c.Redirect(http.StatusTemporaryRedirect, "/")
+	})
+
+	request := func(token string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/s/"+token, nil)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusTemporaryRedirect, request("valid"))
+	assert.Equal(t, http.StatusTemporaryRedirect, request("valid"))
+	assert.Equal(t, http.StatusTemporaryRedirect, request("valid"))
+	assert.Equal(t, 0, l.Lockouts())
+	assert.Equal(t, http.StatusTemporaryRedirect, request("invalid"))
+	assert.Equal(t, http.StatusTemporaryRedirect, request("invalid"))
+	assert.Equal(t, 0, l.Lockouts())
+
+	// The third failed attempt of the client locks it out.
+	assert.Equal(t, time.Minute, l.Failure("192.0.2.1/"))
+	assert.Equal(t, http.StatusTooManyRequests, request("valid"))
+}
+
+func TestLimitLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	l := NewLimiter(2, time.Minute, time.Hour)
+	r := gin.New()
+
+	r.POST("/session", LimitLogin(l), func(c *gin.Context) {
+		f, err := api.BindLogin(c)
+
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		} else if f.Password != "secret" {
+			api.AuthFailed(c)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		c.String(http.StatusOK, f.UserName)
+	})
+
+	request := func(ip, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/session", strings.NewReader(body))
+		req.RemoteAddr = ip + ":1234"
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("SameForm", func(t *testing.T) {
+		// The handler must see the same username as the limiter.
+		w := request("192.0.2.1", `{"username":"decoy","UserName":"alice","password":"secret"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "alice", w.Body.String())
+	})
+	t.Run("Decoy", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, request("192.0.2.2", `{"username":"decoy1","UserName":"bob"}`).Code)
+		assert.Equal(t, http.StatusUnauthorized, request("192.0.2.2", `{"username":"decoy2","UserName":"bob"}`).Code)
+		assert.Equal(t, http.StatusTooManyRequests, request("192.0.2.2", `{"username":"decoy3","UserName":"bob"}`).Code)
+	})
+	t.Run("Username", func(t *testing.T) {
+		// Failed attempts from many networks lock out the account.
+		for i := 0; i < 2*LimitFactor; i++ {
+			request(fmt.Sprintf("198.51.100.%d", i+1), `{"username":"carol"}`)
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, request("198.51.100.99", `{"username":"carol","password":"secret"}`).Code)
+		assert.Equal(t, http.StatusOK, request("198.51.100.99", `{"username":"dave","password":"secret"}`).Code)
+	})
+	t.Run("IP", func(t *testing.T) {
+		// Failed attempts for many accounts lock out the network.
+		for i := 0; i < LimitFactor*2; i++ {
+			request("203.0.113.1", fmt.Sprintf(`{"username":"user%d"}`, i))
+		}
+
+		assert.Equal(t, http.StatusTooManyRequests, request("203.0.113.1", `{"username":"erin","password":"secret"}`).Code)
+	})
+}
@@ -39,6 +39,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		c.HTML(http.StatusOK, "rainbow.tmpl", gin.H{"config": clientConfig})
 	})
 
+	// Throttles failed login attempts and share token guessing.
+	limiter := NewLimiter(conf.AuthAttempts(), conf.AuthBackoff(), conf.AuthLockout())
+
 	// JSON-REST API Version 1
 	v1 := router.Group(conf.BaseUri(config.ApiUri))
 	{
@@ -51,13 +54,26 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetSettings(v1)
 		api.SaveSettings(v1)
 		api.ChangePassword(v1)
-		api.CreateSession(v1)
+		api.GetUserTokens(v1)
+		api.CreateUserToken(v1)
+		api.RevokeUserToken(v1)
+		api.GetUserSessions(v1)
+		api.RevokeUserSession(v1)
+		api.GetUserTwoFactor(v1)
+		api.EnrollUserTwoFactor(v1)
+		api.VerifyUserTwoFactor(v1)
+		api.DeleteUserTwoFactor(v1)
+		api.CreateSession(v1.Group("", LimitLogin(limiter)))
 		api.DeleteSession(v1)
+		api.OidcLogin(v1)
+		api.OidcRedirect(v1)
 
 		// External account management.
 		api.SearchAccounts(v1)
 		api.GetAccount(v1)
 		api.GetAccountFolders(v1)
+		api.GetAccountConflicts(v1)
+		api.ResolveAccountConflict(v1)
 		api.ShareWithAccount(v1)
 		api.CreateAccount(v1)
 		api.DeleteAccount(v1)
@@ -76,6 +92,10 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.SearchGeo(v1)
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
+		api.GetPhotoText(v1)
+		api.GetPhotoSimilar(v1)
+		api.GetDuplicates(v1)
+		api.AcceptDuplicates(v1)
 		api.UpdatePhoto(v1)
 		api.GetPhotoDownload(v1)
 		api.GetPhotoLinks(v1)
@@ -110,11 +130,15 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.CreateAlbumLink(v1)
 		api.UpdateAlbumLink(v1)
 		api.DeleteAlbumLink(v1)
+		api.GetAlbumLinkAccess(v1)
 		api.LikeAlbum(v1)
 		api.DislikeAlbum(v1)
 		api.CloneAlbums(v1)
 		api.AddPhotosToAlbum(v1)
 		api.RemovePhotosFromAlbum(v1)
+		api.GetAlbumMembers(v1)
+		api.AddAlbumMember(v1)
+		api.RemoveAlbumMember(v1)
 
 		// Labels.
 		api.SearchLabels(v1)
@@ -147,6 +171,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 		// Indexing and importing.
 		api.Upload(v1)
+		api.GetUploadLink(v1.Group("", Limit(limiter)))
+		api.UploadWithLink(v1.Group("", Limit(limiter)))
 		api.StartImport(v1)
 		api.CancelImport(v1)
 		api.StartIndexing(v1)
@@ -163,14 +189,15 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 		// Other.
 		api.GetSvg(v1)
-		api.GetStatus(v1)
+		api.GetStatus(v1, limiter.Lockouts)
 		api.GetErrors(v1)
+		api.GetAuditLog(v1)
 		api.SendFeedback(v1)
 		api.Websocket(v1)
 	}
 
 	// Configure link sharing.
-	s := router.Group(conf.BaseUri("/s"))
+	s := router.Group(conf.BaseUri("/s"), Limit(limiter))
 	{
 		api.Shares(s)
 		api.SharePreview(s)
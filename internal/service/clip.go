@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/clip"
+)
+
+var onceClip sync.Once
+
+func initClip() {
+	services.Clip = clip.New(conf.ClipOptions())
+}
+
+func Clip() clip.Model {
+	onceClip.Do(initClip)
+
+	return services.Clip
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceDuplicates sync.Once
+
+func initDuplicates() {
+	services.Duplicates = photoprism.NewDuplicates(Config())
+}
+
+func Duplicates() *photoprism.Duplicates {
+	onceDuplicates.Do(initDuplicates)
+
+	return services.Duplicates
+}
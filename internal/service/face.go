@@ -9,7 +9,7 @@ import (
 var onceFaceNet sync.Once
 
 func initFaceNet() {
-	services.FaceNet = face.NewNet(conf.FaceNetModelPath(), "", conf.DisableFaces())
+	services.FaceNet = face.NewNet(face.NewDetector(conf.FaceDetectorOptions()), conf.DisableFaces())
 }
 
 func FaceNet() *face.Net {
@@ -9,7 +9,7 @@ import (
 var onceIndex sync.Once
 
 func initIndex() {
-	services.Index = photoprism.NewIndex(Config(), Classify(), NsfwDetector(), FaceNet(), Convert(), Files(), Photos())
+	services.Index = photoprism.NewIndex(Config(), Classify(), NsfwDetector(), FaceNet(), Ocr(), Clip(), Convert(), Files(), Photos())
 }
 
 func Index() *photoprism.Index {
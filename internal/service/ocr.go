@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/ocr"
+)
+
+var onceOcr sync.Once
+
+func initOcr() {
+	services.Ocr = ocr.New(conf.OcrOptions())
+}
+
+func Ocr() ocr.Engine {
+	onceOcr.Do(initOcr)
+
+	return services.Ocr
+}
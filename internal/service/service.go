@@ -2,9 +2,11 @@ package service
 
 import (
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/clip"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/ocr"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
@@ -25,12 +27,15 @@ var services struct {
 	Import      *photoprism.Import
 	Index       *photoprism.Index
 	Moments     *photoprism.Moments
+	Duplicates  *photoprism.Duplicates
 	Faces       *photoprism.Faces
 	Places      *photoprism.Places
 	Purge       *photoprism.Purge
 	CleanUp     *photoprism.CleanUp
 	Nsfw        *nsfw.Detector
 	FaceNet     *face.Net
+	Ocr         ocr.Engine
+	Clip        clip.Model
 	Query       *query.Query
 	Resample    *photoprism.Resample
 	Session     *session.Session
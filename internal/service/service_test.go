@@ -64,6 +64,10 @@ func TestMoments(t *testing.T) {
 	assert.IsType(t, &photoprism.Moments{}, Moments())
 }
 
+func TestDuplicates(t *testing.T) {
+	assert.IsType(t, &photoprism.Duplicates{}, Duplicates())
+}
+
 func TestPurge(t *testing.T) {
 	assert.IsType(t, &photoprism.Purge{}, Purge())
 }
@@ -11,7 +11,7 @@ var onceSession sync.Once
 
 func initSession() {
 	// keep sessions for 7 days by default
-	services.Session = session.New(168*time.Hour, Config().CachePath())
+	services.Session = session.New(168 * time.Hour)
 }
 
 func Session() *session.Session {
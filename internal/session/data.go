@@ -3,13 +3,14 @@ package session
 import (
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
-type Saved struct {
-	User       string   `json:"user"`
-	Tokens     []string `json:"tokens"`
-	Expiration int64    `json:"expiration"`
+// Client represents the device a session was created with.
+type Client struct {
+	UserAgent string
+	IP        string
 }
 
 // UIDs represents a slice of unique ID strings.
@@ -26,13 +27,10 @@ func (u UIDs) Join(s string) string {
 }
 
 type Data struct {
-	User   entity.User `json:"user"`   // Session user, guest or anonymous person.
-	Tokens []string    `json:"tokens"` // Slice of secret share tokens.
-	Shares UIDs        `json:"shares"` // Slice of shared entity UIDs.
-}
-
-func (s Data) Saved() Saved {
-	return Saved{User: s.User.UserUID, Tokens: s.Tokens}
+	User   entity.User `json:"user"`            // Session user, guest or anonymous person.
+	Tokens []string    `json:"tokens"`          // Slice of secret share tokens.
+	Shares UIDs        `json:"shares"`          // Slice of shared entity UIDs.
+	Scope  acl.Scope   `json:"scope,omitempty"` // Scope of the personal API token used, if any.
 }
 
 func (s Data) Invalid() bool {
@@ -43,6 +41,11 @@ func (s Data) Valid() bool {
 	return !s.Invalid()
 }
 
+// Allow tests if the API token scope, if any, allows the action.
+func (s Data) Allow(action acl.Action) bool {
+	return s.Scope == "" || s.Scope.Allow(action)
+}
+
 func (s Data) Guest() bool {
 	return s.User.Guest()
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
 )
 
 func TestUIDs_String(t *testing.T) {
@@ -21,3 +23,15 @@ func TestData_HasShare(t *testing.T) {
 	assert.True(t, data.HasShare("def444"))
 	assert.False(t, data.HasShare("xxx"))
 }
+
+func TestData_Allow(t *testing.T) {
+	t.Run("NoScope", func(t *testing.T) {
+		data := Data{}
+		assert.True(t, data.Allow(acl.ActionDelete))
+	})
+	t.Run("ReadScope", func(t *testing.T) {
+		data := Data{Scope: acl.ScopeRead}
+		assert.True(t, data.Allow(acl.ActionRead))
+		assert.False(t, data.Allow(acl.ActionDelete))
+	})
+}
@@ -25,14 +25,39 @@ Additional information can be found in our Developer Guide:
 package session
 
 import (
+	"time"
+
 	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 )
 
 var log = event.Log
 
-// Session represents a session store.
+// CheckInterval is the time after which cached sessions are checked again in the database,
+// so that sessions revoked by other processes, e.g. the CLI, can no longer be used.
+const CheckInterval = time.Minute
+
+// Session represents a session store. Sessions are stored in the database and cached in memory.
 type Session struct {
-	cacheFile string
-	cache     *gc.Cache
+	expiration time.Duration
+	cache      *gc.Cache
+}
+
+// cached represents a session in the memory cache.
+type cached struct {
+	Data    Data
+	Checked time.Time
+}
+
+// New returns a new session store, sessions expire after the specified duration.
+func New(expiration time.Duration) *Session {
+	if deleted, err := entity.DeleteExpiredSessions(); err != nil {
+		log.Errorf("session: %s (delete expired)", err)
+	} else if deleted > 0 {
+		log.Debugf("session: deleted %d expired sessions", deleted)
+	}
+
+	return &Session{expiration: expiration, cache: gc.New(expiration, 15*time.Minute)}
 }
@@ -2,20 +2,32 @@ package session
 
 import (
 	"fmt"
+	"time"
 
 	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 )
 
 // Create creates a new user session.
-func (s *Session) Create(data Data) string {
+func (s *Session) Create(data Data, client Client) string {
 	id := NewID()
-	s.cache.Set(id, data, gc.DefaultExpiration)
-	log.Debugf("session: created")
 
-	if err := s.Save(); err != nil {
+	m := entity.NewUserSession(id, data.User.UserUID, s.expiration)
+	m.SetTokens(data.Tokens)
+	m.SetShares(data.Shares)
+	m.SetClient(client.UserAgent, client.IP)
+	m.SessScope = string(data.Scope)
+
+	if err := m.Save(); err != nil {
 		log.Errorf("session: %s (create)", err)
 	}
 
+	s.cache.Set(id, cached{Data: data, Checked: time.Now()}, gc.DefaultExpiration)
+
+	log.Debugf("session: created")
+
 	return id
 }
 
@@ -25,29 +37,41 @@ func (s *Session) Update(id string, data Data) error {
 		return fmt.Errorf("session: empty id")
 	}
 
-	if _, found := s.cache.Get(id); !found {
+	m := entity.FindUserSession(id)
+
+	if m == nil || m.Expired() {
 		return fmt.Errorf("session: %s not found (update)", id)
 	}
 
-	s.cache.Set(id, data, gc.DefaultExpiration)
+	m.UserUID = data.User.UserUID
+	m.ExpiresAt = entity.TimeStamp().Add(s.expiration)
+	m.SetTokens(data.Tokens)
+	m.SetShares(data.Shares)
+	m.SessScope = string(data.Scope)
 
-	log.Debugf("session: updated")
-
-	if err := s.Save(); err != nil {
-		log.Errorf("session: %s (update)", err)
+	if err := m.Save(); err != nil {
+		return fmt.Errorf("session: %s (update)", err)
 	}
 
+	s.cache.Set(id, cached{Data: data, Checked: time.Now()}, gc.DefaultExpiration)
+
+	log.Debugf("session: updated")
+
 	return nil
 }
 
 // Delete deletes an existing user session.
 func (s *Session) Delete(id string) {
 	s.cache.Delete(id)
-	log.Debugf("session: deleted")
 
-	if err := s.Save(); err != nil {
+	if m := entity.FindUserSession(id); m == nil {
+		return
+	} else if err := m.Delete(); err != nil {
 		log.Errorf("session: %s (delete)", err)
+		return
 	}
+
+	log.Debugf("session: deleted")
 }
 
 // Get returns the data of an existing user session.
@@ -56,16 +80,112 @@ func (s *Session) Get(id string) Data {
 		return Data{}
 	}
 
-	if hit, ok := s.cache.Get(id); ok {
-		return hit.(Data)
+	if hit, ok := s.cache.Get(id); ok && time.Since(hit.(cached).Checked) < CheckInterval {
+		return hit.(cached).Data
 	}
 
-	return Data{}
+	m := entity.FindUserSession(id)
+
+	if m == nil || m.Expired() {
+		s.cache.Delete(id)
+		return Data{}
+	}
+
+	m.Seen()
+
+	data := restore(m)
+
+	if data.Invalid() {
+		s.cache.Delete(id)
+		return data
+	}
+
+	s.cache.Set(id, cached{Data: data, Checked: time.Now()}, time.Until(m.ExpiresAt))
+
+	return data
 }
 
 // Exists tests of a user session with the given id exists.
 func (s *Session) Exists(id string) bool {
-	_, found := s.cache.Get(id)
+	if _, found := s.cache.Get(id); found {
+		return true
+	}
+
+	m := entity.FindUserSession(id)
+
+	return m != nil && !m.Expired()
+}
+
+// Revoke deletes the session of a user with the specified UID, so that it can no longer be used.
+func (s *Session) Revoke(userUID, sessUID string) error {
+	m := entity.FindUserSessionByUID(userUID, sessUID)
+
+	if m == nil {
+		return fmt.Errorf("session: %s not found (revoke)", sessUID)
+	} else if err := m.Delete(); err != nil {
+		return err
+	}
+
+	for id := range s.cache.Items() {
+		if entity.SessionHash(id) == m.SessHash {
+			s.cache.Delete(id)
+		}
+	}
+
+	return nil
+}
+
+// RevokeUser deletes all sessions of a user, except the one with the specified id if not empty.
+func (s *Session) RevokeUser(userUID, exceptID string) (int64, error) {
+	deleted, err := entity.DeleteUserSessions(userUID, exceptID)
+
+	if err != nil {
+		return deleted, err
+	}
+
+	for id, item := range s.cache.Items() {
+		if id != exceptID && item.Object.(cached).Data.User.UserUID == userUID {
+			s.cache.Delete(id)
+		}
+	}
+
+	return deleted, nil
+}
+
+// restore returns the session data with the current user information, the scope of the API token
+// it was created with, if any, and the shares of valid tokens. Only shares that have been unlocked
+// are restored, since links with the same token may require a password.
+func restore(m *entity.UserSession) (data Data) {
+	user := entity.FindUserByUID(m.UserUID)
+
+	// Guests have no account, so it's ok that they are disabled.
+	if user == nil || user.UserDisabled && !user.Guest() {
+		return data
+	}
+
+	data.User = *user
+	data.Scope = acl.Scope(m.SessScope)
+
+	unlocked := make(map[string]bool)
+
+	for _, uid := range m.Shares() {
+		unlocked[uid] = true
+	}
+
+	for _, token := range m.Tokens() {
+		found := false
+
+		for _, link := range entity.FindValidLinks(token, "") {
+			if unlocked[link.ShareUID] && !data.HasShare(link.ShareUID) {
+				data.Shares = append(data.Shares, link.ShareUID)
+				found = true
+			}
+		}
+
+		if found {
+			data.Tokens = append(data.Tokens, token)
+		}
+	}
 
-	return found
+	return data
 }
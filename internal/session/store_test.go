@@ -4,24 +4,25 @@ import (
 	"testing"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestSession_Create(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour)
 
 	data := Data{
 		User: entity.Admin,
 	}
 
-	id := s.Create(data)
+	id := s.Create(data, Client{})
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
 }
 
 func TestSession_Update(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour)
 
 	data := Data{
 		User: entity.Admin,
@@ -38,7 +39,7 @@ func TestSession_Update(t *testing.T) {
 		t.Fatalf("update should fail for unknown session id %s", id)
 	}
 
-	newId := s.Create(data)
+	newId := s.Create(data, Client{})
 	assert.Equal(t, 48, len(newId))
 
 	cachedData := s.Get(newId)
@@ -64,13 +65,13 @@ func TestSession_Update(t *testing.T) {
 }
 
 func TestSession_UpdateError(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour)
 
 	data := Data{
 		User: entity.Admin,
 	}
 
-	id := s.Create(data)
+	id := s.Create(data, Client{})
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
 	newData := Data{
@@ -82,18 +83,18 @@ func TestSession_UpdateError(t *testing.T) {
 }
 
 func TestSession_Delete(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour)
 	s.Delete("abc")
 }
 
 func TestSession_Get(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour)
 	data := Data{
 		User:   entity.Guest,
 		Shares: UIDs{"a000000000000001"},
 	}
 
-	id := s.Create(data)
+	id := s.Create(data, Client{})
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
 
@@ -115,15 +116,139 @@ func TestSession_Get(t *testing.T) {
 }
 
 func TestSession_Exists(t *testing.T) {
-	s := New(time.Hour, "testdata")
+	s := New(time.Hour)
 	assert.False(t, s.Exists("xyz"))
 	data := Data{
 		User: entity.Guest,
 	}
-	id := s.Create(data)
+	id := s.Create(data, Client{})
 	t.Logf("id: %s", id)
 	assert.Equal(t, 48, len(id))
 	assert.True(t, s.Exists(id))
 	s.Delete(id)
 	assert.False(t, s.Exists(id))
 }
+
+func TestSession_Restore(t *testing.T) {
+	s := New(time.Hour)
+
+	data := Data{User: entity.UserFixtures.Get("alice")}
+
+	id := s.Create(data, Client{UserAgent: "Mozilla/5.0", IP: "192.168.1.2"})
+
+	m := entity.FindUserSession(id)
+
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+		assert.Equal(t, "Mozilla/5.0", m.UserAgent)
+		assert.Equal(t, "192.168.1.2", m.ClientIP)
+	}
+
+	// Sessions are restored from the database, e.g. after a restart.
+	restored := New(time.Hour).Get(id)
+
+	assert.True(t, restored.Valid())
+	assert.Equal(t, "alice", restored.User.UserName)
+
+	s.Delete(id)
+
+	assert.False(t, New(time.Hour).Get(id).Valid())
+}
+
+func TestSession_RestoreScope(t *testing.T) {
+	s := New(time.Hour)
+
+	data := Data{User: entity.UserFixtures.Get("alice"), Scope: acl.ScopeRead}
+
+	id := s.Create(data, Client{})
+
+	if m := entity.FindUserSession(id); assert.NotNil(t, m) {
+		assert.Equal(t, "read", m.SessScope)
+	}
+
+	// Sessions created with a scoped API token must not get more rights once restored.
+	restored := New(time.Hour).Get(id)
+
+	assert.True(t, restored.Valid())
+	assert.Equal(t, acl.ScopeRead, restored.Scope)
+	assert.False(t, restored.Allow(acl.ActionDelete))
+
+	s.Delete(id)
+}
+
+func TestSession_RestoreShares(t *testing.T) {
+	s := New(time.Hour)
+
+	open := entity.NewLink("at9lxuqxpogaaba7", false, false)
+	protected := entity.NewLink("at9lxuqxpogaaba8", false, false)
+	protected.LinkToken = open.LinkToken
+
+	if err := open.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer open.Delete()
+
+	if err := protected.SetPassword("secret"); err != nil {
+		t.Fatal(err)
+	} else if err = protected.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer protected.Delete()
+
+	// Only the link without password has been unlocked.
+	id := s.Create(Data{User: entity.Guest, Tokens: []string{open.LinkToken}, Shares: UIDs{open.ShareUID}}, Client{})
+
+	restored := New(time.Hour).Get(id)
+
+	assert.True(t, restored.Valid())
+	assert.Equal(t, UIDs{open.ShareUID}, restored.Shares)
+	assert.Equal(t, []string{open.LinkToken}, restored.Tokens)
+
+	s.Delete(id)
+}
+
+func TestSession_Revoke(t *testing.T) {
+	s := New(time.Hour)
+
+	id := s.Create(Data{User: entity.UserFixtures.Get("bob")}, Client{})
+	m := entity.FindUserSession(id)
+
+	if m == nil {
+		t.Fatal("session should exist")
+	}
+
+	assert.Error(t, s.Revoke("uqxetse3cy5eo9z2", m.SessUID))
+	assert.True(t, s.Get(id).Valid())
+
+	if err := s.Revoke("uqxc08w3d0ej2283", m.SessUID); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, s.Exists(id))
+	assert.False(t, s.Get(id).Valid())
+}
+
+func TestSession_RevokeUser(t *testing.T) {
+	s := New(time.Hour)
+
+	bob := Data{User: entity.UserFixtures.Get("bob")}
+	current := s.Create(bob, Client{})
+	other := s.Create(bob, Client{})
+	alice := s.Create(Data{User: entity.UserFixtures.Get("alice")}, Client{})
+
+	deleted, err := s.RevokeUser("uqxc08w3d0ej2283", current)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, deleted, int64(1))
+	assert.True(t, s.Get(current).Valid())
+	assert.False(t, s.Get(other).Valid())
+	assert.True(t, s.Get(alice).Valid())
+
+	s.Delete(current)
+	s.Delete(alice)
+}
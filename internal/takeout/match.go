@@ -0,0 +1,139 @@
+package takeout
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonExt is the file extension of metadata files.
+const jsonExt = ".json"
+
+// supplementalExt is appended to media file names by newer exports, e.g. "IMG_1234.JPG.supplemental-metadata.json".
+const supplementalExt = ".supplemental-metadata"
+
+// maxNameLength is the maximum length of JSON file names, longer names are truncated.
+const maxNameLength = 51
+
+// minTruncatedLength is the minimum length of a truncated name without extension.
+const minTruncatedLength = 30
+
+// EditedSuffixes contains the localized suffixes of edited copies, e.g. "IMG_1234-edited.JPG".
+var EditedSuffixes = []string{
+	"-edited",
+	"-bearbeitet",
+	"-modifié",
+	"-editado",
+	"-modificato",
+	"-bewerkt",
+	"-redigeret",
+	"-redigert",
+	"-redigerad",
+	"-muokattu",
+	"-edytowane",
+	"-upravené",
+}
+
+// counterSuffix matches the counter that is added to duplicate file names, e.g. "IMG_1234(1)".
+var counterSuffix = regexp.MustCompile(`^(.+)(\(\d+\))$`)
+
+// match returns the name of the JSON file with the metadata of a media file, or an empty string if none was found.
+func (f *folder) match(name string) string {
+	if name == "" {
+		return ""
+	}
+
+	// Default name, e.g. "IMG_1234.JPG.json".
+	if f.exists(name + jsonExt) {
+		return name + jsonExt
+	}
+
+	// Newer exports, e.g. "IMG_1234.JPG.supplemental-metadata.json".
+	if result := f.prefixed(name+".", ""); result != "" {
+		return result
+	}
+
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	// Duplicate names, e.g. "IMG_1234(1).JPG" with "IMG_1234.JPG(1).json".
+	if m := counterSuffix.FindStringSubmatch(base); m != nil {
+		if result := m[1] + ext + m[2] + jsonExt; f.exists(result) {
+			return result
+		} else if result = f.prefixed(m[1]+ext+".", m[2]); result != "" {
+			return result
+		}
+	}
+
+	// Edited copies share the metadata of the original, e.g. "IMG_1234-edited.JPG".
+	for _, suffix := range EditedSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return f.match(strings.TrimSuffix(base, suffix) + ext)
+		}
+	}
+
+	// Long names are truncated, e.g. "Screenshot_20190101-123456_Some_Long_App_Nam.json".
+	if result := f.truncated(name); result != "" {
+		return result
+	}
+
+	// The title contains the original file name if it was not changed.
+	if names := f.titles[name]; len(names) == 1 {
+		return names[0]
+	}
+
+	// Videos of live photos, e.g. "IMG_1234.MP4" with "IMG_1234.HEIC.json".
+	if result := f.prefixed(base+".", ""); result != "" {
+		return result
+	}
+
+	return ""
+}
+
+// exists tests if a JSON file with photo metadata exists.
+func (f *folder) exists(jsonName string) bool {
+	_, ok := f.photos[jsonName]
+	return ok
+}
+
+// prefixed returns the first JSON file name with the prefix and counter, if any.
+func (f *folder) prefixed(prefix, counter string) string {
+	for _, jsonName := range f.jsonNames {
+		stem := strings.TrimSuffix(jsonName, jsonExt)
+
+		if !strings.HasPrefix(stem, prefix) {
+			continue
+		}
+
+		// Counters must match, e.g. "IMG_1234.JPG.supplemental-metadata(1).json" belongs to "IMG_1234(1).JPG".
+		if counter == "" && counterSuffix.MatchString(stem) || counter != "" && !strings.HasSuffix(stem, counter) {
+			continue
+		}
+
+		return jsonName
+	}
+
+	return ""
+}
+
+// truncated returns the longest JSON file name that was truncated from the media file name.
+func (f *folder) truncated(name string) (result string) {
+	if utf8.RuneCountInString(name+supplementalExt+jsonExt) <= maxNameLength {
+		return ""
+	}
+
+	for _, jsonName := range f.jsonNames {
+		stem := strings.TrimSuffix(jsonName, jsonExt)
+
+		if utf8.RuneCountInString(stem) < minTruncatedLength || len(stem) <= len(result)-len(jsonExt) {
+			continue
+		}
+
+		if strings.HasPrefix(name+supplementalExt, stem) {
+			result = jsonName
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,252 @@
+/*
+Package takeout reads Google Takeout exports and matches media files with their JSON metadata.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package takeout
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/sanitize"
+)
+
+// Global log instance.
+var log = event.Log
+
+// Album represents an album folder in a Takeout export.
+type Album struct {
+	meta.GAlbum
+	Path string
+}
+
+// Photo represents a media file in a Takeout export and its JSON metadata, if found.
+type Photo struct {
+	FileName string
+	JsonName string
+	Meta     meta.GPhoto
+	Album    *Album
+}
+
+// Matched tests if JSON metadata was found for the media file.
+func (m Photo) Matched() bool {
+	return m.JsonName != ""
+}
+
+// Archive represents the contents of a Takeout export.
+type Archive struct {
+	Root      string
+	Photos    []Photo
+	Albums    []*Album
+	Unmatched []string
+	Unused    []string
+}
+
+// Scan reads a Takeout export folder and matches media files with their JSON metadata.
+func Scan(root string) (*Archive, error) {
+	root, err := filepath.Abs(root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make(map[string]*folder)
+
+	err = filepath.Walk(root, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := info.Name()
+
+		// Skip hidden files and folders.
+		if strings.HasPrefix(name, ".") && fileName != root {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		dir := filepath.Dir(fileName)
+		f, ok := folders[dir]
+
+		if !ok {
+			f = newFolder(dir)
+			folders[dir] = f
+		}
+
+		switch fs.GetMediaType(name) {
+		case fs.MediaImage, fs.MediaRaw, fs.MediaVideo:
+			f.media = append(f.media, name)
+		case fs.MediaSidecar:
+			if strings.HasSuffix(name, jsonExt) {
+				f.addJson(name)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Archive{Root: root}
+
+	// Process folders in a stable order.
+	dirs := make([]string, 0, len(folders))
+
+	for dir := range folders {
+		dirs = append(dirs, dir)
+	}
+
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		result.add(folders[dir])
+	}
+
+	return result, nil
+}
+
+// add adds the media files and albums of a folder to the archive.
+func (a *Archive) add(f *folder) {
+	if f.album != nil {
+		a.Albums = append(a.Albums, f.album)
+	}
+
+	used := make(map[string]bool)
+
+	sort.Strings(f.media)
+
+	for _, name := range f.media {
+		p := Photo{
+			FileName: filepath.Join(f.path, name),
+			Album:    f.album,
+		}
+
+		if jsonName := f.match(name); jsonName != "" {
+			p.JsonName = filepath.Join(f.path, jsonName)
+			p.Meta = f.photos[jsonName]
+			used[jsonName] = true
+		} else {
+			log.Debugf("takeout: found no metadata for %s", sanitize.Log(p.FileName))
+			a.Unmatched = append(a.Unmatched, p.FileName)
+		}
+
+		a.Photos = append(a.Photos, p)
+	}
+
+	for _, jsonName := range f.jsonNames {
+		if !used[jsonName] {
+			a.Unused = append(a.Unused, filepath.Join(f.path, jsonName))
+		}
+	}
+}
+
+// yearFolder matches the names of folders that contain all photos of a year.
+var yearFolder = regexp.MustCompile(`^Photos from \d{4}$`)
+
+// folder represents the files in a Takeout export folder.
+type folder struct {
+	path      string
+	media     []string
+	jsonNames []string
+	photos    map[string]meta.GPhoto
+	titles    map[string][]string
+	album     *Album
+}
+
+// newFolder creates a new folder.
+func newFolder(path string) *folder {
+	return &folder{
+		path:   path,
+		photos: make(map[string]meta.GPhoto),
+		titles: make(map[string][]string),
+	}
+}
+
+// addJson reads a JSON file with album or photo metadata.
+func (f *folder) addJson(name string) {
+	data, err := os.ReadFile(filepath.Join(f.path, name))
+
+	if err != nil {
+		log.Warnf("takeout: %s", err)
+		return
+	}
+
+	var probe struct {
+		Title   string      `json:"title"`
+		TakenAt *meta.GTime `json:"photoTakenTime"`
+		Album   meta.GAlbum `json:"albumData"`
+	}
+
+	// Ignore other files, e.g. with comments or print orders.
+	if err = json.Unmarshal(data, &probe); err != nil {
+		return
+	}
+
+	switch {
+	case probe.TakenAt != nil:
+		p := meta.GPhoto{}
+
+		if err = json.Unmarshal(data, &p); err != nil {
+			log.Warnf("takeout: %s in %s", err, sanitize.Log(name))
+			return
+		}
+
+		f.photos[name] = p
+		f.titles[p.Title] = append(f.titles[p.Title], name)
+		f.jsonNames = append(f.jsonNames, name)
+	case probe.Album.Exists():
+		f.setAlbum(probe.Album)
+	case probe.Title != "":
+		// Newer exports store album metadata at the top level.
+		album := meta.GAlbum{}
+
+		if err = json.Unmarshal(data, &album); err == nil {
+			f.setAlbum(album)
+		}
+	}
+}
+
+// setAlbum sets the album metadata unless the folder contains all photos of a year.
+func (f *folder) setAlbum(album meta.GAlbum) {
+	if yearFolder.MatchString(filepath.Base(f.path)) || yearFolder.MatchString(album.Title) {
+		return
+	}
+
+	f.album = &Album{GAlbum: album, Path: f.path}
+}
@@ -0,0 +1,224 @@
+package takeout
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// writeFiles creates test files relative to the root folder.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, data := range files {
+		fileName := filepath.Join(root, name)
+
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(fileName, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// writeZip creates a zip archive with the test files.
+func writeZip(t *testing.T, zipName string, files map[string]string) {
+	f, err := os.Create(zipName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	for name, data := range files {
+		z, err := w.Create(name)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = z.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// photoJson returns photo metadata as created by Google Photos.
+func photoJson(title string) string {
+	return `{"title": "` + title + `", "description": "Lunch", "photoTakenTime": {"timestamp": "1650000000"}, "favorited": true, "people": [{"name": "Jane Doe"}]}`
+}
+
+func TestScan(t *testing.T) {
+	longName := "Screenshot_20220101-120000_Example_Application.jpg"
+	truncatedJson := (longName + supplementalExt)[:maxNameLength-len(jsonExt)] + jsonExt
+
+	root := t.TempDir()
+
+	writeFiles(t, root, map[string]string{
+		"Google Photos/Berlin/metadata.json":                           `{"title": "Berlin", "description": "Summer 2022"}`,
+		"Google Photos/Berlin/IMG_1234.JPG":                            "jpeg",
+		"Google Photos/Berlin/IMG_1234.JPG.json":                       photoJson("IMG_1234.JPG"),
+		"Google Photos/Berlin/IMG_1234-edited.JPG":                     "jpeg",
+		"Google Photos/Berlin/IMG_2000.JPG":                            "jpeg",
+		"Google Photos/Berlin/IMG_2000.JPG.supplemental-metadata.json": photoJson("IMG_2000.JPG"),
+		"Google Photos/Berlin/IMG_3000.JPG":                            "jpeg",
+		"Google Photos/Berlin/IMG_3000.JPG.json":                       photoJson("IMG_3000.JPG"),
+		"Google Photos/Berlin/IMG_3000(1).JPG":                         "jpeg",
+		"Google Photos/Berlin/IMG_3000.JPG(1).json":                    photoJson("IMG_3000.JPG"),
+		"Google Photos/Berlin/" + longName:                             "jpeg",
+		"Google Photos/Berlin/" + truncatedJson:                        photoJson(longName),
+		"Google Photos/Berlin/NOMETA.JPG":                              "jpeg",
+		"Google Photos/Berlin/orphan.json":                             photoJson("gone.jpg"),
+		"Google Photos/Photos from 2022/metadata.json":                 `{"title": "Photos from 2022"}`,
+		"Google Photos/Photos from 2022/IMG_4000.JPG":                  "jpeg",
+		"Google Photos/Photos from 2022/IMG_4000.JPG.json":             photoJson("IMG_4000.JPG"),
+		"Google Photos/Hamburg/metadata.json":                          `{"albumData": {"title": "Hamburg", "description": "Harbor"}}`,
+		"Google Photos/Hamburg/IMG_5000.HEIC":                          "heic",
+		"Google Photos/Hamburg/IMG_5000.HEIC.json":                     photoJson("IMG_5000.HEIC"),
+		"Google Photos/Hamburg/IMG_5000.MP4":                           "mp4",
+		"Google Photos/.hidden/IMG_6000.JPG":                           "jpeg",
+	})
+
+	archive, err := Scan(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	berlin := filepath.Join(archive.Root, "Google Photos", "Berlin")
+
+	t.Run("Albums", func(t *testing.T) {
+		if assert.Len(t, archive.Albums, 2) {
+			assert.Equal(t, "Berlin", archive.Albums[0].Title)
+			assert.Equal(t, "Summer 2022", archive.Albums[0].Description)
+			assert.Equal(t, berlin, archive.Albums[0].Path)
+			assert.Equal(t, "Hamburg", archive.Albums[1].Title)
+			assert.Equal(t, "Harbor", archive.Albums[1].Description)
+		}
+	})
+	t.Run("Photos", func(t *testing.T) {
+		assert.Len(t, archive.Photos, 10)
+
+		expected := map[string]string{
+			"IMG_1234.JPG":        "IMG_1234.JPG.json",
+			"IMG_1234-edited.JPG": "IMG_1234.JPG.json",
+			"IMG_2000.JPG":        "IMG_2000.JPG.supplemental-metadata.json",
+			"IMG_3000.JPG":        "IMG_3000.JPG.json",
+			"IMG_3000(1).JPG":     "IMG_3000.JPG(1).json",
+			longName:              truncatedJson,
+			"IMG_4000.JPG":        "IMG_4000.JPG.json",
+			"IMG_5000.HEIC":       "IMG_5000.HEIC.json",
+			"IMG_5000.MP4":        "IMG_5000.HEIC.json",
+		}
+
+		for _, p := range archive.Photos {
+			name := filepath.Base(p.FileName)
+
+			if jsonName, ok := expected[name]; ok {
+				assert.True(t, p.Matched(), name)
+				assert.Equal(t, jsonName, filepath.Base(p.JsonName), name)
+				assert.True(t, p.Meta.Favorited, name)
+				assert.Equal(t, []string{"Jane Doe"}, p.Meta.PeopleNames(), name)
+			} else {
+				assert.False(t, p.Matched(), name)
+			}
+
+			switch filepath.Base(filepath.Dir(p.FileName)) {
+			case "Berlin", "Hamburg":
+				assert.NotNil(t, p.Album, name)
+			default:
+				assert.Nil(t, p.Album, name)
+			}
+		}
+	})
+	t.Run("Unmatched", func(t *testing.T) {
+		assert.Equal(t, []string{filepath.Join(berlin, "NOMETA.JPG")}, archive.Unmatched)
+	})
+	t.Run("Unused", func(t *testing.T) {
+		assert.Equal(t, []string{filepath.Join(berlin, "orphan.json")}, archive.Unused)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Scan(filepath.Join(root, "missing"))
+		assert.Error(t, err)
+	})
+}
+
+func TestScan_MultiPart(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "takeout")
+
+	// Media files may be in a different part than their album and sidecar files.
+	writeZip(t, filepath.Join(dir, "takeout-001.zip"), map[string]string{
+		"Takeout/Google Photos/Berlin/metadata.json":     `{"title": "Berlin"}`,
+		"Takeout/Google Photos/Berlin/IMG_1234.JPG.json": photoJson("IMG_1234.JPG"),
+		"Takeout/Google Photos/Berlin/IMG_2000.JPG":      "jpeg",
+		"Takeout/Google Photos/Berlin/IMG_2000.JPG.json": photoJson("IMG_2000.JPG"),
+	})
+	writeZip(t, filepath.Join(dir, "takeout-002.zip"), map[string]string{
+		"Takeout/Google Photos/Berlin/IMG_1234.JPG": "jpeg",
+	})
+
+	for _, zipName := range []string{"takeout-001.zip", "takeout-002.zip"} {
+		if _, err := fs.Unzip(filepath.Join(dir, zipName), root); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archive, err := Scan(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, archive.Albums, 1)
+	assert.Empty(t, archive.Unmatched)
+	assert.Empty(t, archive.Unused)
+
+	if assert.Len(t, archive.Photos, 2) {
+		for _, p := range archive.Photos {
+			assert.True(t, p.Matched(), p.FileName)
+			assert.NotNil(t, p.Album, p.FileName)
+		}
+	}
+}
+
+func TestFolder_Match(t *testing.T) {
+	f := newFolder("/takeout")
+	f.jsonNames = []string{"IMG_0001.JPG.supplemental-metadata.json", "IMG_0001.JPG.supplemental-metadata(1).json", "Original.jpg.json"}
+
+	for _, name := range f.jsonNames {
+		f.photos[name] = meta.GPhoto{}
+	}
+
+	f.titles["Renamed.jpg"] = []string{"Original.jpg.json"}
+
+	t.Run("Supplemental", func(t *testing.T) {
+		assert.Equal(t, "IMG_0001.JPG.supplemental-metadata.json", f.match("IMG_0001.JPG"))
+	})
+	t.Run("Counter", func(t *testing.T) {
+		assert.Equal(t, "IMG_0001.JPG.supplemental-metadata(1).json", f.match("IMG_0001(1).JPG"))
+	})
+	t.Run("Edited", func(t *testing.T) {
+		assert.Equal(t, "IMG_0001.JPG.supplemental-metadata.json", f.match("IMG_0001-bearbeitet.JPG"))
+	})
+	t.Run("Title", func(t *testing.T) {
+		assert.Equal(t, "Original.jpg.json", f.match("Renamed.jpg"))
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.Equal(t, "", f.match("IMG_0002.JPG"))
+		assert.Equal(t, "", f.match(""))
+	})
+}
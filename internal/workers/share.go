@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/internal/search"
 	"github.com/photoprism/photoprism/internal/thumb"
 )
@@ -78,7 +77,13 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+		client, err := a.Client()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
+
 		existingDirs := make(map[string]string)
 
 		for _, file := range files {
@@ -159,7 +164,12 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+		client, err := a.Client()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
 
 		for _, file := range files {
 			if mutex.ShareWorker.Canceled() {
@@ -40,6 +40,11 @@ func (worker *Sync) logWarn(err error) {
 	}
 }
 
+// closeClient closes the connection to the remote service, if any.
+func (worker *Sync) closeClient(client remote.Client) {
+	worker.logWarn(remote.CloseClient(client))
+}
+
 // Start starts the sync worker.
 func (worker *Sync) Start() (err error) {
 	defer func() {
@@ -62,7 +67,7 @@ func (worker *Sync) Start() (err error) {
 	accounts, err := search.Accounts(f)
 
 	for _, a := range accounts {
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.Supported(a.AccType) {
 			continue
 		}
 
@@ -9,7 +9,6 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -76,7 +75,14 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 
 	log.Infof("sync: downloading from %s", a.AccName)
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
+
+	if err != nil {
+		worker.logError(err)
+		return false, err
+	}
+
+	defer worker.closeClient(client)
 
 	var baseDir string
 
@@ -102,13 +108,16 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 
 			localName := baseDir + file.RemoteName
 
-			if _, err := os.Stat(localName); err == nil {
+			// Replace files that have been synced before if two-way sync is enabled.
+			replace := a.TwoWay() && file.FileID > 0
+
+			if _, err := os.Stat(localName); err == nil && !replace {
 				log.Warnf("sync: download skipped, %s already exists", localName)
 				file.Status = entity.FileSyncExists
 				file.Error = ""
 				file.Errors = 0
 			} else {
-				if err := client.Download(file.RemoteName, localName, false); err != nil {
+				if err := client.Download(file.RemoteName, localName, replace); err != nil {
 					file.Errors++
 					file.Error = err.Error()
 				} else {
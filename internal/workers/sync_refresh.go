@@ -10,11 +10,17 @@ import (
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
-	if a.AccType != remote.ServiceWebDAV {
+	if !remote.Supported(a.AccType) {
 		return false, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
+
+	if err != nil {
+		return false, err
+	}
+
+	defer worker.closeClient(client)
 
 	subDirs, err := client.Directories(a.SyncPath, true, webdav.MaxRequestDuration)
 
@@ -70,9 +76,23 @@ func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
 				worker.logError(f.Update("Status", entity.FileSyncNew))
 			}
 
-			if f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
+			switch f.Status {
+			case entity.FileSyncDownloaded, entity.FileSyncUploaded:
+				// Uploaded files are only downloaded again with two-way sync.
+				if !f.RemoteChanged(file.Date, file.Size) || f.Status == entity.FileSyncUploaded && !a.TwoWay() {
+					break
+				}
+
+				status := entity.FileSyncNew
+
+				// Don't overwrite files that have been modified on both sides.
+				if a.TwoWay() && f.LocalChanged() {
+					log.Warnf("sync: %s has been modified locally and on %s", f.RemoteName, a.AccName)
+					status = entity.FileSyncConflict
+				}
+
 				worker.logError(f.Updates(map[string]interface{}{
-					"Status":     entity.FileSyncNew,
+					"Status":     status,
 					"RemoteDate": file.Date,
 					"RemoteSize": file.Size,
 				}))
@@ -10,7 +10,7 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
+	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/photoprism/photoprism/pkg/sanitize"
 )
 
@@ -25,13 +25,41 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 		return false, err
 	}
 
-	if len(files) == 0 {
+	var changed []entity.FileSync
+
+	// Modified files are uploaded again with two-way sync.
+	if a.TwoWay() {
+		results, err := query.FileSyncsChanged(a.ID, maxResults)
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, fileSync := range results {
+			// Failed too often?
+			if a.RetryLimit > 0 && fileSync.Errors > a.RetryLimit {
+				log.Debugf("sync: uploading %s failed more than %d times", fileSync.RemoteName, a.RetryLimit)
+				continue
+			}
+
+			changed = append(changed, fileSync)
+		}
+	}
+
+	if len(files) == 0 && len(changed) == 0 {
 		log.Infof("sync: upload complete for %s", a.AccName)
 		event.Publish("sync.uploaded", event.Data{"account": a})
 		return true, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
+
+	if err != nil {
+		return false, err
+	}
+
+	defer worker.closeClient(client)
+
 	existingDirs := make(map[string]string)
 
 	for _, file := range files {
@@ -43,6 +71,32 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 		remoteName := path.Join(a.SyncPath, file.FileName)
 		remoteDir := filepath.Dir(remoteName)
 
+		fileSync := entity.NewFileSync(a.ID, remoteName)
+
+		var existing *entity.FileSync
+
+		if a.TwoWay() {
+			existing = entity.FindFileSync(a.ID, remoteName)
+		}
+
+		// Don't overwrite remote files with the same name that have not been synced yet.
+		if existing != nil && existing.FileID == 0 {
+			fileSync = existing
+			fileSync.FileID = file.ID
+			fileSync.FileHash = file.FileHash
+
+			if existing.RemoteSize == file.FileSize {
+				log.Infof("sync: %s already exists on %s", sanitize.Log(remoteName), a.AccName)
+				fileSync.Status = entity.FileSyncUploaded
+			} else {
+				log.Warnf("sync: %s differs from the existing file on %s", sanitize.Log(remoteName), a.AccName)
+				fileSync.Status = entity.FileSyncConflict
+			}
+
+			worker.logError(entity.Db().Save(fileSync).Error)
+			continue
+		}
+
 		if _, ok := existingDirs[remoteDir]; !ok {
 			if err := client.CreateDir(remoteDir); err != nil {
 				log.Errorf("sync: failed creating remote folder %s", remoteDir)
@@ -57,11 +111,11 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 
 		log.Infof("sync: uploaded %s to %s (%s)", sanitize.Log(file.FileName), sanitize.Log(remoteName), a.AccName)
 
-		fileSync := entity.NewFileSync(a.ID, remoteName)
 		fileSync.Status = entity.FileSyncUploaded
 		fileSync.RemoteDate = time.Now()
 		fileSync.RemoteSize = file.FileSize
 		fileSync.FileID = file.ID
+		fileSync.FileHash = file.FileHash
 		fileSync.Error = ""
 		fileSync.Errors = 0
 
@@ -69,8 +123,43 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 			return false, nil
 		}
 
-		worker.logError(entity.Db().Save(&fileSync).Error)
+		worker.logError(entity.Db().Save(fileSync).Error)
+	}
+
+	for _, fileSync := range changed {
+		if mutex.SyncWorker.Canceled() {
+			return false, nil
+		}
+
+		if err := worker.uploadChanged(client, a, fileSync); err != nil {
+			worker.logError(err)
+		}
 	}
 
 	return false, nil
 }
+
+// uploadChanged replaces a remote file with the modified local file.
+func (worker *Sync) uploadChanged(client remote.Client, a entity.Account, fileSync entity.FileSync) error {
+	if fileSync.File == nil {
+		return nil
+	}
+
+	fileName := photoprism.FileName(fileSync.File.FileRoot, fileSync.File.FileName)
+
+	if err := client.Upload(fileName, fileSync.RemoteName); err != nil {
+		worker.logError(err)
+		return fileSync.Updates(entity.Values{"Error": err.Error(), "Errors": fileSync.Errors + 1})
+	}
+
+	log.Infof("sync: uploaded modified %s to %s", sanitize.Log(fileSync.RemoteName), a.AccName)
+
+	return fileSync.Updates(entity.Values{
+		"Status":     entity.FileSyncUploaded,
+		"FileHash":   fileSync.File.FileHash,
+		"RemoteDate": time.Now(),
+		"RemoteSize": fileSync.File.FileSize,
+		"Error":      "",
+		"Errors":     0,
+	})
+}
@@ -0,0 +1,154 @@
+package phash
+
+import (
+	"errors"
+	"image"
+	"math"
+	"sort"
+
+	"golang.org/x/image/draw"
+)
+
+// dctSize is the edge length of the grayscale image used for computing the pHash.
+const dctSize = 32
+
+// lowFreq is the edge length of the low frequency DCT block that is hashed.
+const lowFreq = 8
+
+// PHash returns the DCT based perceptual hash of an image.
+func PHash(img image.Image) (Hash, error) {
+	px, err := gray(img, dctSize, dctSize)
+
+	if err != nil {
+		return 0, err
+	}
+
+	coeffs := dct2(px, dctSize)
+
+	// Use the top-left block of low frequency coefficients.
+	block := make([]float64, 0, lowFreq*lowFreq)
+
+	for y := 0; y < lowFreq; y++ {
+		for x := 0; x < lowFreq; x++ {
+			block = append(block, coeffs[y*dctSize+x])
+		}
+	}
+
+	// The DC coefficient only reflects the average brightness,
+	// so it is excluded when computing the median.
+	m := median(block[1:])
+
+	var h Hash
+
+	for i, c := range block {
+		if c > m {
+			h |= 1 << uint(i)
+		}
+	}
+
+	return h, nil
+}
+
+// DHash returns the gradient based difference hash of an image.
+func DHash(img image.Image) (Hash, error) {
+	const w, hgt = 9, 8
+
+	px, err := gray(img, w, hgt)
+
+	if err != nil {
+		return 0, err
+	}
+
+	var h Hash
+
+	i := 0
+
+	for y := 0; y < hgt; y++ {
+		for x := 0; x < w-1; x++ {
+			if px[y*w+x] < px[y*w+x+1] {
+				h |= 1 << uint(i)
+			}
+			i++
+		}
+	}
+
+	return h, nil
+}
+
+// gray resizes an image and returns the luminance of its pixels.
+func gray(img image.Image, w, h int) ([]float64, error) {
+	if img == nil {
+		return nil, errors.New("phash: image is nil")
+	} else if b := img.Bounds(); b.Dx() < 1 || b.Dy() < 1 {
+		return nil, errors.New("phash: image is empty")
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	px := make([]float64, w*h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			o := dst.PixOffset(x, y)
+			r, g, b := float64(dst.Pix[o]), float64(dst.Pix[o+1]), float64(dst.Pix[o+2])
+			px[y*w+x] = 0.299*r + 0.587*g + 0.114*b
+		}
+	}
+
+	return px, nil
+}
+
+// dct2 returns the two-dimensional DCT-II of a square matrix.
+func dct2(px []float64, n int) []float64 {
+	cos := make([]float64, n*n)
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			cos[k*n+i] = math.Cos(math.Pi / float64(n) * (float64(i) + 0.5) * float64(k))
+		}
+	}
+
+	// Transform rows.
+	rows := make([]float64, n*n)
+
+	for y := 0; y < n; y++ {
+		for k := 0; k < n; k++ {
+			var sum float64
+			for i := 0; i < n; i++ {
+				sum += px[y*n+i] * cos[k*n+i]
+			}
+			rows[y*n+k] = sum
+		}
+	}
+
+	// Transform columns.
+	result := make([]float64, n*n)
+
+	for x := 0; x < n; x++ {
+		for k := 0; k < n; k++ {
+			var sum float64
+			for i := 0; i < n; i++ {
+				sum += rows[i*n+x] * cos[k*n+i]
+			}
+			result[k*n+x] = sum
+		}
+	}
+
+	return result
+}
+
+// median returns the median of the values.
+func median(values []float64) float64 {
+	s := make([]float64, len(values))
+	copy(s, values)
+	sort.Float64s(s)
+
+	if l := len(s); l == 0 {
+		return 0
+	} else if l%2 == 0 {
+		return (s[l/2-1] + s[l/2]) / 2
+	} else {
+		return s[l/2]
+	}
+}
@@ -0,0 +1,117 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/draw"
+)
+
+// scene returns a synthetic test image with random rectangles, or its mirror image.
+func scene(w, h int, mirror bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	rnd := rand.New(rand.NewSource(42))
+
+	for i := 0; i < 24; i++ {
+		x0, y0 := rnd.Intn(w), rnd.Intn(h)
+		x1, y1 := x0+rnd.Intn(w/2), y0+rnd.Intn(h/2)
+		v := uint8(rnd.Intn(256))
+
+		for y := y0; y < y1 && y < h; y++ {
+			for x := x0; x < x1 && x < w; x++ {
+				if mirror {
+					img.Set(w-x-1, h-y-1, color.RGBA{R: v, G: v, B: v, A: 255})
+				} else {
+					img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// resize returns a scaled copy of the image.
+func resize(src image.Image, w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.CatmullRom.Scale(img, img.Bounds(), src, src.Bounds(), draw.Src, nil)
+	return img
+}
+
+// brighten returns a copy of the image with a brightness offset.
+func brighten(src image.Image, offset int) image.Image {
+	b := src.Bounds()
+	img := image.NewRGBA(b)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, _, _, _ := src.At(x, y).RGBA()
+			v := int(r>>8) + offset
+
+			if v > 255 {
+				v = 255
+			}
+
+			img.Set(x, y, color.RGBA{R: uint8(v), G: uint8(v), B: uint8(v), A: 255})
+		}
+	}
+
+	return img
+}
+
+func TestPHash(t *testing.T) {
+	t.Run("Similar", func(t *testing.T) {
+		a, err := PHash(scene(640, 480, false))
+		assert.NoError(t, err)
+
+		b, err := PHash(brighten(resize(scene(640, 480, false), 320, 240), 10))
+		assert.NoError(t, err)
+
+		assert.LessOrEqual(t, Distance(a, b), 6)
+	})
+	t.Run("Different", func(t *testing.T) {
+		a, err := PHash(scene(640, 480, false))
+		assert.NoError(t, err)
+
+		b, err := PHash(scene(640, 480, true))
+		assert.NoError(t, err)
+
+		assert.Greater(t, Distance(a, b), 16)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		_, err := PHash(nil)
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := PHash(image.NewRGBA(image.Rect(0, 0, 0, 0)))
+		assert.Error(t, err)
+	})
+}
+
+func TestDHash(t *testing.T) {
+	t.Run("Similar", func(t *testing.T) {
+		a, err := DHash(scene(640, 480, false))
+		assert.NoError(t, err)
+
+		b, err := DHash(brighten(resize(scene(640, 480, false), 320, 240), 10))
+		assert.NoError(t, err)
+
+		assert.LessOrEqual(t, Distance(a, b), 6)
+	})
+	t.Run("Different", func(t *testing.T) {
+		a, err := DHash(scene(640, 480, false))
+		assert.NoError(t, err)
+
+		b, err := DHash(scene(640, 480, true))
+		assert.NoError(t, err)
+
+		assert.Greater(t, Distance(a, b), 16)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		_, err := DHash(nil)
+		assert.Error(t, err)
+	})
+}
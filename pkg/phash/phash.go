@@ -0,0 +1,67 @@
+/*
+Package phash provides perceptual image hashes for finding visually similar photos.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package phash
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+)
+
+// Bits is the number of bits in a hash.
+const Bits = 64
+
+// Hash represents a 64-bit perceptual image hash.
+type Hash uint64
+
+// String returns the hash as 16 hexadecimal characters.
+func (h Hash) String() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// Distance returns the Hamming distance to another hash.
+func (h Hash) Distance(other Hash) int {
+	return Distance(h, other)
+}
+
+// Distance returns the Hamming distance between two hashes.
+func Distance(a, b Hash) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
+
+// Parse returns the hash encoded as hexadecimal string.
+func Parse(s string) (Hash, error) {
+	if s == "" {
+		return 0, fmt.Errorf("phash: empty hash")
+	}
+
+	h, err := strconv.ParseUint(s, 16, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("phash: invalid hash %q", s)
+	}
+
+	return Hash(h), nil
+}
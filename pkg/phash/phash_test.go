@@ -0,0 +1,41 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash_String(t *testing.T) {
+	assert.Equal(t, "0000000000000000", Hash(0).String())
+	assert.Equal(t, "00000000000000ff", Hash(255).String())
+	assert.Equal(t, "ffffffffffffffff", Hash(^uint64(0)).String())
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		h, err := Parse("00000000000000ff")
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(255), h)
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		h, err := Parse(Hash(0xd1c0ffee12345678).String())
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(0xd1c0ffee12345678), h)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := Parse("")
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Parse("xyz")
+		assert.Error(t, err)
+	})
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, Distance(0, 0))
+	assert.Equal(t, 1, Distance(0, 1))
+	assert.Equal(t, 8, Distance(0, 255))
+	assert.Equal(t, 64, Hash(0).Distance(Hash(^uint64(0))))
+}
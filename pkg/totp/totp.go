@@ -0,0 +1,160 @@
+/*
+Package totp implements time-based one-time passwords (RFC 6238) for two-factor authentication.
+
+Copyright (c) 2018 - 2022 Michael Mayer <hello@photoprism.app>
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://photoprism.app/trademark>
+
+Feel free to send an e-mail to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Digits is the number of digits in a passcode.
+const Digits = 6
+
+// Period is the number of seconds a passcode is valid.
+const Period = 30
+
+// Skew is the number of periods before and after the current time in which passcodes are accepted.
+const Skew = 1
+
+// SecretSize is the number of random bytes in a secret, as recommended by RFC 4226.
+const SecretSize = 20
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret as base32 encoded string.
+func GenerateSecret() (string, error) {
+	b := make([]byte, SecretSize)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// decode returns the key bytes of a base32 encoded secret.
+func decode(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+
+	if secret == "" {
+		return nil, errors.New("totp: empty secret")
+	}
+
+	return encoding.DecodeString(secret)
+}
+
+// counter returns the time step counter for the given time.
+func counter(t time.Time) uint64 {
+	return uint64(t.Unix() / Period)
+}
+
+// hotp returns the HMAC-based one-time password for a counter value (RFC 4226).
+func hotp(key []byte, c uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, c)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1000000)
+}
+
+// Code returns the passcode for the given secret and time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := decode(secret)
+
+	if err != nil {
+		return "", err
+	}
+
+	return hotp(key, counter(t)), nil
+}
+
+// Validate tests if the passcode matches the secret at the given time, allowing for a small clock skew.
+func Validate(secret, code string, t time.Time) bool {
+	_, ok := Match(secret, code, t)
+	return ok
+}
+
+// Match returns the time step counter of the passcode if it matches the secret at the given time,
+// allowing for a small clock skew. Callers can use it to reject passcodes that have already been used.
+func Match(secret, code string, t time.Time) (uint64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	key, err := decode(secret)
+
+	if err != nil {
+		return 0, false
+	}
+
+	c := counter(t)
+
+	for i := -Skew; i <= Skew; i++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, c+uint64(i))), []byte(code)) == 1 {
+			return c + uint64(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// URI returns the otpauth:// provisioning URI that authenticator apps can import by scanning a QR code.
+func URI(issuer, account, secret string) string {
+	label := url.PathEscape(account)
+
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+
+	v := url.Values{}
+	v.Set("secret", secret)
+
+	if issuer != "" {
+		v.Set("issuer", issuer)
+	}
+
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", Digits))
+	v.Set("period", fmt.Sprintf("%d", Period))
+
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
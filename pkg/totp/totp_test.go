@@ -0,0 +1,108 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA1 test key from RFC 6238, Appendix B.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateSecret(t *testing.T) {
+	s, err := GenerateSecret()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, s, 32)
+	assert.NotContains(t, s, "=")
+
+	other, _ := GenerateSecret()
+	assert.NotEqual(t, s, other)
+}
+
+func TestCode(t *testing.T) {
+	t.Run("RFC6238", func(t *testing.T) {
+		// Last six digits of the RFC 6238 test vectors.
+		vectors := map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		}
+
+		for ts, expected := range vectors {
+			code, err := Code(rfcSecret, time.Unix(ts, 0))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, expected, code)
+		}
+	})
+	t.Run("Lowercase", func(t *testing.T) {
+		code, err := Code(strings.ToLower(rfcSecret), time.Unix(59, 0))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "287082", code)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := Code("", time.Now())
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Code("1!", time.Now())
+		assert.Error(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	assert.True(t, Validate(rfcSecret, "050471", now))
+	assert.True(t, Validate(rfcSecret, "050 471", now))
+	assert.True(t, Validate(rfcSecret, "050471", now.Add(Period*time.Second)))
+	assert.False(t, Validate(rfcSecret, "050471", now.Add(3*Period*time.Second)))
+	assert.False(t, Validate(rfcSecret, "123456", now))
+	assert.False(t, Validate(rfcSecret, "", now))
+	assert.False(t, Validate("", "050471", now))
+}
+
+func TestMatch(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	c, ok := Match(rfcSecret, "050471", now)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1111111111/Period), c)
+
+	// The counter of the passcode remains the same within the allowed clock skew.
+	c, ok = Match(rfcSecret, "050471", now.Add(Period*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1111111111/Period), c)
+
+	c, ok = Match(rfcSecret, "123456", now)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), c)
+}
+
+func TestURI(t *testing.T) {
+	t.Run("Issuer", func(t *testing.T) {
+		uri := URI("PhotoPrism", "alice", "JBSWY3DPEHPK3PXP")
+		assert.Equal(t, "otpauth://totp/PhotoPrism:alice?algorithm=SHA1&digits=6&issuer=PhotoPrism&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+	})
+	t.Run("NoIssuer", func(t *testing.T) {
+		uri := URI("", "alice smith", "JBSWY3DPEHPK3PXP")
+		assert.Equal(t, "otpauth://totp/alice%20smith?algorithm=SHA1&digits=6&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+	})
+}